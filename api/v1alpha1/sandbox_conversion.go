@@ -25,6 +25,23 @@ import (
 
 const v1alpha1SandboxStateAnnotation = "api.agents.x-k8s.io/v1alpha1-sandbox-state"
 
+// v1beta1SandboxSpecAnnotation carries the v1beta1 spec fields that have no v1alpha1
+// equivalent, so that an update through v1alpha1 does not reset them.
+const v1beta1SandboxSpecAnnotation = "api.agents.x-k8s.io/v1beta1-sandbox-spec"
+
+// v1beta1OnlySpec holds the v1beta1 SandboxSpec fields stored in
+// v1beta1SandboxSpecAnnotation.
+type v1beta1OnlySpec struct {
+	ServiceName                          string                                               `json:"serviceName,omitempty"`
+	Paused                               bool                                                 `json:"paused,omitempty"`
+	PersistentVolumeClaimRetentionPolicy *v1beta1.SandboxPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	PrimaryContainer                     string                                               `json:"primaryContainer,omitempty"`
+	RestartPolicy                        *v1beta1.SandboxRestartPolicy                        `json:"restartPolicy,omitempty"`
+	InjectEnv                            bool                                                 `json:"injectEnv,omitempty"`
+	ConfigMapTemplates                   []v1beta1.ConfigMapTemplate                          `json:"configMapTemplates,omitempty"`
+	SecretTemplates                      []v1beta1.SecretTemplate                             `json:"secretTemplates,omitempty"`
+}
+
 // ConvertTo converts this Sandbox to the Hub version (v1beta1).
 func (s *Sandbox) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Sandbox)
@@ -42,6 +59,11 @@ func (s *Sandbox) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 
+	// Restore the v1beta1-only spec fields recorded by ConvertFrom
+	if err := restoreV1beta1OnlySpec(dst); err != nil {
+		return err
+	}
+
 	// Preserve the original v1alpha1 object state for lossless round-tripping
 	if dst.Annotations == nil {
 		dst.Annotations = make(map[string]string)
@@ -49,6 +71,7 @@ func (s *Sandbox) ConvertTo(dstRaw conversion.Hub) error {
 	sCopy := s.DeepCopy()
 	if sCopy.Annotations != nil {
 		delete(sCopy.Annotations, v1alpha1SandboxStateAnnotation)
+		delete(sCopy.Annotations, v1beta1SandboxSpecAnnotation)
 	}
 	stateJSON, err := json.Marshal(sCopy)
 	if err != nil {
@@ -112,6 +135,61 @@ func (s *Sandbox) ConvertFrom(srcRaw conversion.Hub) error {
 		s.Status.Replicas = original.Status.Replicas
 	}
 
+	// Keep the v1beta1-only spec fields so ConvertTo can restore them
+	return saveV1beta1OnlySpec(&src.Spec, s)
+}
+
+// saveV1beta1OnlySpec records the fields of src that v1alpha1 cannot represent in
+// the v1beta1SandboxSpecAnnotation of dst. The annotation is removed when they are
+// all unset.
+func saveV1beta1OnlySpec(src *v1beta1.SandboxSpec, dst *Sandbox) error {
+	extra := v1beta1OnlySpec{
+		ServiceName:                          src.ServiceName,
+		Paused:                               src.Paused,
+		PersistentVolumeClaimRetentionPolicy: src.PersistentVolumeClaimRetentionPolicy,
+		PrimaryContainer:                     src.PrimaryContainer,
+		RestartPolicy:                        src.RestartPolicy,
+		InjectEnv:                            src.InjectEnv,
+		ConfigMapTemplates:                   src.ConfigMapTemplates,
+		SecretTemplates:                      src.SecretTemplates,
+	}
+	specJSON, err := json.Marshal(extra)
+	if err != nil {
+		return fmt.Errorf("failed to marshal v1beta1 Sandbox spec: %w", err)
+	}
+	// Every field is omitempty, so an empty object means there is nothing to keep.
+	if string(specJSON) == "{}" {
+		delete(dst.Annotations, v1beta1SandboxSpecAnnotation)
+		return nil
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = make(map[string]string)
+	}
+	dst.Annotations[v1beta1SandboxSpecAnnotation] = string(specJSON)
+	return nil
+}
+
+// restoreV1beta1OnlySpec applies the fields recorded by saveV1beta1OnlySpec to dst
+// and strips the annotation, so it is not stored on the v1beta1 object.
+func restoreV1beta1OnlySpec(dst *v1beta1.Sandbox) error {
+	specJSON, ok := dst.Annotations[v1beta1SandboxSpecAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(dst.Annotations, v1beta1SandboxSpecAnnotation)
+
+	var extra v1beta1OnlySpec
+	if err := json.Unmarshal([]byte(specJSON), &extra); err != nil {
+		return fmt.Errorf("failed to unmarshal v1beta1 Sandbox spec: %w", err)
+	}
+	dst.Spec.ServiceName = extra.ServiceName
+	dst.Spec.Paused = extra.Paused
+	dst.Spec.PersistentVolumeClaimRetentionPolicy = extra.PersistentVolumeClaimRetentionPolicy
+	dst.Spec.PrimaryContainer = extra.PrimaryContainer
+	dst.Spec.RestartPolicy = extra.RestartPolicy
+	dst.Spec.InjectEnv = extra.InjectEnv
+	dst.Spec.ConfigMapTemplates = extra.ConfigMapTemplates
+	dst.Spec.SecretTemplates = extra.SecretTemplates
 	return nil
 }
 
@@ -197,7 +275,15 @@ func ConvertStatusTo(src *SandboxStatus, dst *v1beta1.SandboxStatus) error {
 	dst.Conditions = src.Conditions
 	dst.LabelSelector = src.LabelSelector
 	dst.PodIPs = src.PodIPs
-	dst.NodeName = "" // NodeName is new in v1beta1 and does not exist in v1alpha1
+	dst.NodeName = ""               // NodeName is new in v1beta1 and does not exist in v1alpha1
+	dst.PodName = ""                // PodName is new in v1beta1 and does not exist in v1alpha1
+	dst.RunningImage = ""           // RunningImage is new in v1beta1 and does not exist in v1alpha1
+	dst.PodFQDN = ""                // PodFQDN is new in v1beta1 and does not exist in v1alpha1
+	dst.AppliedPodTemplateHash = "" // AppliedPodTemplateHash is new in v1beta1 and does not exist in v1alpha1
+	dst.RestartCount = 0            // RestartCount is new in v1beta1 and does not exist in v1alpha1
+	dst.LastRestartTime = nil       // LastRestartTime is new in v1beta1 and does not exist in v1alpha1
+	dst.ExpiresIn = ""              // ExpiresIn is new in v1beta1 and does not exist in v1alpha1
+	dst.ObservedGeneration = 0      // ObservedGeneration is new in v1beta1 and does not exist in v1alpha1
 	return nil
 }
 
@@ -17,8 +17,10 @@ package v1alpha1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 )
@@ -256,3 +258,115 @@ func TestSandboxConversionFromHub(t *testing.T) {
 		})
 	}
 }
+
+func TestSandboxConversionPreservesV1beta1Spec(t *testing.T) {
+	// A v1alpha1 client reads the Sandbox, edits what it knows about and writes it
+	// back. Fields v1alpha1 can't represent must come back unchanged.
+	tests := []struct {
+		name   string
+		mutate func(*v1beta1.SandboxSpec)
+	}{
+		{
+			name:   "no v1beta1-only fields",
+			mutate: func(*v1beta1.SandboxSpec) {},
+		},
+		{
+			name:   "serviceName",
+			mutate: func(spec *v1beta1.SandboxSpec) { spec.ServiceName = "my-service" },
+		},
+		{
+			name:   "paused",
+			mutate: func(spec *v1beta1.SandboxSpec) { spec.Paused = true },
+		},
+		{
+			name: "persistentVolumeClaimRetentionPolicy",
+			mutate: func(spec *v1beta1.SandboxSpec) {
+				spec.PersistentVolumeClaimRetentionPolicy = &v1beta1.SandboxPersistentVolumeClaimRetentionPolicy{
+					WhenDeleted: v1beta1.RetainPersistentVolumeClaimRetentionPolicyType,
+				}
+			},
+		},
+		{
+			name:   "primaryContainer",
+			mutate: func(spec *v1beta1.SandboxSpec) { spec.PrimaryContainer = "agent" },
+		},
+		{
+			name: "restartPolicy",
+			mutate: func(spec *v1beta1.SandboxSpec) {
+				spec.RestartPolicy = &v1beta1.SandboxRestartPolicy{
+					Type:             v1beta1.SandboxRestartPolicyRecreatePod,
+					RestartThreshold: new(int32(3)),
+					Window:           &metav1.Duration{Duration: 10 * time.Minute},
+				}
+			},
+		},
+		{
+			name:   "injectEnv",
+			mutate: func(spec *v1beta1.SandboxSpec) { spec.InjectEnv = true },
+		},
+		{
+			name: "configMapTemplates and secretTemplates",
+			mutate: func(spec *v1beta1.SandboxSpec) {
+				spec.ConfigMapTemplates = []v1beta1.ConfigMapTemplate{{
+					EmbeddedObjectMetadata: v1beta1.EmbeddedObjectMetadata{Name: "config"},
+					Data:                   map[string]string{"settings.json": "{}"},
+				}}
+				spec.SecretTemplates = []v1beta1.SecretTemplate{{
+					EmbeddedObjectMetadata: v1beta1.EmbeddedObjectMetadata{Name: "token"},
+					Type:                   corev1.SecretTypeOpaque,
+					Data:                   map[string][]byte{"token": []byte("s3cr3t")},
+				}}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := &v1beta1.Sandbox{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-sandbox",
+					Namespace:   "default",
+					Annotations: map[string]string{"baz": "qux"},
+				},
+				Spec: v1beta1.SandboxSpec{
+					OperatingMode: v1beta1.SandboxOperatingModeRunning,
+				},
+			}
+			src.Spec.PodTemplate.Spec.Containers = []corev1.Container{{Name: "agent", Image: "agent-image:v1"}}
+			tc.mutate(&src.Spec)
+
+			spoke := &Sandbox{}
+			if err := spoke.ConvertFrom(src); err != nil {
+				t.Fatalf("failed to convert from v1beta1: %v", err)
+			}
+			if _, ok := src.Annotations[v1beta1SandboxSpecAnnotation]; ok {
+				t.Errorf("src.Annotations was mutated during ConvertFrom")
+			}
+			spoke.Spec.PodTemplate.Spec.Containers[0].Image = "agent-image:v2"
+
+			hub := &v1beta1.Sandbox{}
+			if err := spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("failed to convert to v1beta1: %v", err)
+			}
+
+			want := src.Spec.DeepCopy()
+			want.PodTemplate.Spec.Containers[0].Image = "agent-image:v2"
+			if !equality.Semantic.DeepEqual(*want, hub.Spec) {
+				t.Errorf("roundtrip spec mismatch:\nwant %+v\ngot  %+v", *want, hub.Spec)
+			}
+			if _, ok := hub.Annotations[v1beta1SandboxSpecAnnotation]; ok {
+				t.Errorf("hub.Annotations still contains the v1beta1 spec annotation after ConvertTo")
+			}
+			if hub.Annotations["baz"] != "qux" {
+				t.Errorf("expected annotation baz=qux to be kept, got %v", hub.Annotations)
+			}
+			var state Sandbox
+			if err := json.Unmarshal([]byte(hub.Annotations[v1alpha1SandboxStateAnnotation]), &state); err != nil {
+				t.Fatalf("failed to unmarshal state from hub: %v", err)
+			}
+			if _, ok := state.Annotations[v1beta1SandboxSpecAnnotation]; ok {
+				t.Errorf("the v1alpha1 state annotation nests the v1beta1 spec annotation")
+			}
+		})
+	}
+}
@@ -40,6 +40,13 @@ const (
 	// SandboxReasonDependenciesNotReady indicates the Sandbox is expected to be running
 	// but its underlying dependencies are not fully provisioned or ready yet.
 	SandboxReasonDependenciesNotReady = "DependenciesNotReady"
+	// SandboxReasonStarting indicates the backing Pod is running but a container has
+	// not passed its startup probe yet, e.g. while an agent loads a model.
+	SandboxReasonStarting = "Starting"
+	// SandboxReasonUnschedulable indicates the backing Pod is Pending because the
+	// scheduler cannot place it, e.g. for lack of resources or a node for its PVCs.
+	// The condition message carries the scheduler's explanation.
+	SandboxReasonUnschedulable = "Unschedulable"
 	// SandboxReasonSuspended indicates the Sandbox has been administratively suspended
 	// (i.e., intentional action by the user to suspend the Sandbox).
 	SandboxReasonSuspended = "SandboxSuspended"
@@ -50,14 +57,82 @@ const (
 	SandboxReasonPodSucceeded = "PodSucceeded"
 	// SandboxReasonPodFailed indicates the backing Pod completed unsuccessfully.
 	SandboxReasonPodFailed = "PodFailed"
+	// SandboxReasonNameHashCollision indicates another Sandbox in the namespace, created
+	// earlier, has a name hashing to the same tracking label value, so this Sandbox gets
+	// no Pod or Service until it is recreated under another name.
+	SandboxReasonNameHashCollision = "NameHashCollision"
+	// SandboxReasonPodCreateThrottled indicates the backing Pod has not been created yet
+	// because the controller's --max-concurrent-pod-creates limit was reached.
+	SandboxReasonPodCreateThrottled = "PodCreateThrottled"
+	// SandboxReasonPodRecreating indicates the backing Pod is being replaced to pick up
+	// changes to the pod template spec.
+	SandboxReasonPodRecreating = "PodRecreating"
+	// SandboxReasonQuotaExceeded indicates the API server forbade creating a child
+	// resource of the Sandbox, typically because a ResourceQuota is exhausted.
+	SandboxReasonQuotaExceeded = "QuotaExceeded"
+	// SandboxReasonRecreateLimitExceeded indicates the backing Pod kept restarting after
+	// spec.restartPolicy.maxRecreates recreations and the controller gave up replacing it.
+	SandboxReasonRecreateLimitExceeded = "RecreateLimitExceeded"
 
 	// SandboxReasonExpired indicates expired state for Sandbox.
 	SandboxReasonExpired = "SandboxExpired"
 
+	// SandboxConditionPodDrifted is set to True when the running Pod no longer matches the
+	// pod template spec the controller created it from, because something other than the
+	// controller mutated it. It is only reported, never acted on, and is removed once the
+	// Pod matches again or is replaced.
+	SandboxConditionPodDrifted ConditionType = "PodDrifted"
+	// SandboxReasonPodTemplateHashMismatch indicates the Pod's pod-spec-hash annotation
+	// differs from status.appliedPodTemplateHash.
+	SandboxReasonPodTemplateHashMismatch = "PodTemplateHashMismatch"
+	// SandboxReasonPodImageMismatch indicates a container of the Pod runs an image other
+	// than the one in the pod template spec it was created from.
+	SandboxReasonPodImageMismatch = "PodImageMismatch"
+
+	// SandboxConditionPaused indicates reconciliation of the Sandbox is paused.
+	SandboxConditionPaused ConditionType = "Paused"
+	// SandboxReasonPaused indicates spec.paused is set and the controller is not
+	// reconciling child resources or expiry for the Sandbox.
+	SandboxReasonPaused = "ReconciliationPaused"
+
+	// SandboxConditionDryRunValidated reports the outcome of validating the Sandbox's child
+	// resources with a server-side dry run, while SandboxDryRunAnnotation is set.
+	SandboxConditionDryRunValidated ConditionType = "DryRunValidated"
+	// SandboxReasonDryRunSucceeded indicates the API server accepted every child resource.
+	SandboxReasonDryRunSucceeded = "DryRunSucceeded"
+	// SandboxReasonDryRunFailed indicates the API server rejected at least one child resource.
+	SandboxReasonDryRunFailed = "DryRunFailed"
+
+	// SandboxCleanupFinalizer is the finalizer the Sandbox controller adds so it can tear down
+	// the Sandbox's Pod and Service in order before the Sandbox is removed.
+	SandboxCleanupFinalizer = "sandbox.agents.x-k8s.io/cleanup"
+
+	// SandboxForceDeleteAnnotation, set to "true", makes the controller delete the Sandbox's Pod
+	// with a zero grace period on expiry and deletion, and stop waiting for the Pod to terminate
+	// before removing SandboxCleanupFinalizer. It is meant for Pods stuck Terminating on a lost node.
+	SandboxForceDeleteAnnotation = "agents.x-k8s.io/force-delete"
+
 	// SandboxPodNameAnnotation is the annotation used to track the pod name adopted from a warm pool.
 	SandboxPodNameAnnotation = "agents.x-k8s.io/pod-name"
+	// SandboxPodSpecHashAnnotation is the annotation used to record the hash of the pod template spec a pod was created from.
+	SandboxPodSpecHashAnnotation = "agents.x-k8s.io/pod-spec-hash"
+	// SandboxDisablePodRecreationAnnotation, when set to "true" on a Sandbox, stops the controller from
+	// recreating the pod after changes to the pod template spec.
+	SandboxDisablePodRecreationAnnotation = "agents.x-k8s.io/disable-pod-recreation"
+	// SandboxDryRunAnnotation, when set to "true" on a Sandbox, makes the controller validate the
+	// Sandbox's Pod, Service and PVCs with a server-side dry run instead of creating them.
+	SandboxDryRunAnnotation = "agents.x-k8s.io/dry-run"
+	// SandboxPodRecreateAttemptsAnnotation is the annotation used to count the pods the controller
+	// recreated under spec.restartPolicy. Removing it allows further recreations.
+	SandboxPodRecreateAttemptsAnnotation = "agents.x-k8s.io/pod-recreate-attempts"
+	// SandboxRestartWindowAnnotation is the annotation used to track the current restart window of
+	// the pod, as "<pod UID>,<window start (RFC 3339)>,<restart count at window start>".
+	SandboxRestartWindowAnnotation = "agents.x-k8s.io/restart-window"
 	// SandboxTemplateRefAnnotation is the annotation used to track the sandbox template ref.
 	SandboxTemplateRefAnnotation = "agents.x-k8s.io/sandbox-template-ref"
+	// SandboxLastActivityAnnotation records, as an RFC 3339 timestamp, when the Sandbox last served
+	// a request. The sandbox-router refreshes it on proxied requests, at most once per interval.
+	SandboxLastActivityAnnotation = "agents.x-k8s.io/last-activity"
 	// SandboxLaunchTypeLabel is the label used to track whether the Sandbox was cold-created or originated from a warm pool.
 	SandboxLaunchTypeLabel = "agents.x-k8s.io/launch-type"
 	// CreatedByLabel is the label used to track which component created the resource (e.g. client, controller, etc.).
@@ -81,6 +156,9 @@ const (
 	SandboxWarmPoolLabel = "agents.x-k8s.io/warm-pool-sandbox"
 	// SandboxTemplateRefHashLabel identifies which SandboxTemplate a Sandbox originated from.
 	SandboxTemplateRefHashLabel = "agents.x-k8s.io/sandbox-template-ref-hash"
+	// SandboxTemplateRevisionLabel names the ControllerRevision snapshotting the SandboxTemplate
+	// a warm pool Sandbox was built from.
+	SandboxTemplateRevisionLabel = "agents.x-k8s.io/sandbox-template-revision"
 )
 
 type PodMetadata struct {
@@ -144,6 +222,45 @@ type PersistentVolumeClaimTemplate struct {
 	Spec corev1.PersistentVolumeClaimSpec `json:"spec"`
 }
 
+// ConfigMapTemplate describes a ConfigMap the controller creates for a Sandbox.
+type ConfigMapTemplate struct {
+	// metadata is the ConfigMap's metadata. The ConfigMap is named
+	// <sandbox name>-<metadata.name> and added to the pod as a volume named
+	// metadata.name.
+	// +required
+	EmbeddedObjectMetadata `json:"metadata"`
+
+	// data contains the ConfigMap's UTF-8 data.
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+
+	// binaryData contains the ConfigMap's binary data.
+	// +optional
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// SecretTemplate describes a Secret the controller creates for a Sandbox.
+type SecretTemplate struct {
+	// metadata is the Secret's metadata. The Secret is named
+	// <sandbox name>-<metadata.name> and added to the pod as a volume named
+	// metadata.name.
+	// +required
+	EmbeddedObjectMetadata `json:"metadata"`
+
+	// type is the Secret's type. Defaults to Opaque.
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+
+	// data contains the Secret's data, base64 encoded.
+	// +optional
+	Data map[string][]byte `json:"data,omitempty"`
+
+	// stringData contains the Secret's data as plain strings. It is merged into
+	// data, taking precedence for duplicate keys.
+	// +optional
+	StringData map[string]string `json:"stringData,omitempty"`
+}
+
 // SandboxOperatingMode defines the desired operational state of the Sandbox.
 type SandboxOperatingMode string
 
@@ -188,8 +305,9 @@ type SandboxBlueprint struct {
 }
 
 // SandboxSpec defines the desired state of Sandbox.
-// volumeClaimTemplates is immutable after creation.
+// volumeClaimTemplates and serviceName are immutable after creation.
 // +kubebuilder:validation:XValidation:rule="has(self.volumeClaimTemplates) == has(oldSelf.volumeClaimTemplates) && (!has(self.volumeClaimTemplates) || self.volumeClaimTemplates == oldSelf.volumeClaimTemplates)",message="volumeClaimTemplates is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.serviceName) == has(oldSelf.serviceName) && (!has(self.serviceName) || self.serviceName == oldSelf.serviceName)",message="serviceName is immutable"
 type SandboxSpec struct {
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
@@ -210,6 +328,139 @@ type SandboxSpec struct {
 	// +kubebuilder:validation:Enum=Running;Suspended
 	// +optional
 	OperatingMode SandboxOperatingMode `json:"operatingMode,omitempty"`
+
+	// persistentVolumeClaimRetentionPolicy describes the lifecycle of PVCs created
+	// from volumeClaimTemplates. By default PVCs are deleted with the Sandbox and
+	// kept while the Sandbox is suspended or expired.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *SandboxPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// configMapTemplates is a list of ConfigMaps the controller creates for the
+	// Sandbox and adds to its pod as volumes, which containers mount by the
+	// template's name. The ConfigMaps are kept in sync with their templates and
+	// deleted with the Sandbox.
+	// +optional
+	// +listType=atomic
+	ConfigMapTemplates []ConfigMapTemplate `json:"configMapTemplates,omitempty"`
+
+	// secretTemplates is a list of Secrets the controller creates for the
+	// Sandbox and adds to its pod as volumes, which containers mount by the
+	// template's name. The Secrets are kept in sync with their templates and
+	// deleted with the Sandbox.
+	// +optional
+	// +listType=atomic
+	SecretTemplates []SecretTemplate `json:"secretTemplates,omitempty"`
+
+	// paused indicates that the controller should stop reconciling the Sandbox.
+	// While paused, the Pod, Service and PVCs are left untouched and expiry is not
+	// enforced. Unpausing resumes normal reconciliation, including expiry.
+	//nolint:kubeapilinter // Mirrors the paused field of apps/v1 Deployment.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// primaryContainer is the name of the pod template container that the Sandbox
+	// tracks: it must be Ready for the Sandbox to be Ready, and its image is
+	// reported in status.runningImage. Defaults to the first container.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	PrimaryContainer string `json:"primaryContainer,omitempty"`
+
+	// restartPolicy controls what the controller does when the Sandbox's Pod keeps
+	// restarting its containers, e.g. while stuck in CrashLoopBackOff. It is distinct
+	// from the pod template's restartPolicy, which governs the kubelet.
+	// +optional
+	RestartPolicy *SandboxRestartPolicy `json:"restartPolicy,omitempty"`
+
+	// injectEnv makes the controller add SANDBOX_NAME, SANDBOX_NAMESPACE and SANDBOX_FQDN
+	// environment variables to every container of the Pod, so agents can advertise
+	// the Sandbox's Service address. Variables the pod template already defines are
+	// left as is. Only Pods created after it is set are affected.
+	//nolint:kubeapilinter // A plain opt-in switch; false keeps the pod template unchanged.
+	// +optional
+	InjectEnv bool `json:"injectEnv,omitempty"`
+
+	// serviceName is the name of the headless Service created for the Sandbox,
+	// giving it a DNS name independent of the Sandbox's own name. Defaults to the
+	// Sandbox name. The Service still selects the Pod by the sandbox tracking
+	// label, and an existing Service of that name not controlled by the Sandbox
+	// is never taken over.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// SandboxRestartPolicyType describes how the controller reacts to a restarting Pod.
+// +kubebuilder:validation:Enum=Never;RecreatePod
+type SandboxRestartPolicyType string
+
+const (
+	// SandboxRestartPolicyNever leaves a restarting Pod to the kubelet.
+	SandboxRestartPolicyNever SandboxRestartPolicyType = "Never"
+
+	// SandboxRestartPolicyRecreatePod deletes and recreates the Pod once its containers
+	// restart too often, which also resets the kubelet's crash loop backoff.
+	SandboxRestartPolicyRecreatePod SandboxRestartPolicyType = "RecreatePod"
+)
+
+// SandboxRestartPolicy describes when the controller recreates a restarting Pod.
+type SandboxRestartPolicy struct {
+	// type selects the policy.
+	// +kubebuilder:default=Never
+	// +optional
+	Type SandboxRestartPolicyType `json:"type,omitempty"`
+
+	// restartThreshold is the number of container restarts within window after which
+	// the Pod is recreated.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RestartThreshold *int32 `json:"restartThreshold,omitempty"`
+
+	// window is the period over which restarts are counted. A window that ends below
+	// restartThreshold starts a new one.
+	// +kubebuilder:default="10m"
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// maxRecreates caps how many times the Pod is recreated. Once reached, a Pod that hits
+	// restartThreshold again is left in place and the Ready condition reports
+	// RecreateLimitExceeded. The count is kept in the agents.x-k8s.io/pod-recreate-attempts
+	// annotation; removing it allows further recreations.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRecreates *int32 `json:"maxRecreates,omitempty"`
+}
+
+// PersistentVolumeClaimRetentionPolicyType describes what happens to PVCs created from
+// volumeClaimTemplates.
+// +kubebuilder:validation:Enum=Retain;Delete
+type PersistentVolumeClaimRetentionPolicyType string
+
+const (
+	// RetainPersistentVolumeClaimRetentionPolicyType keeps the PVCs. When the Sandbox is
+	// deleted they are released from its ownership so garbage collection leaves them alone.
+	RetainPersistentVolumeClaimRetentionPolicyType PersistentVolumeClaimRetentionPolicyType = "Retain"
+
+	// DeletePersistentVolumeClaimRetentionPolicyType deletes the PVCs.
+	DeletePersistentVolumeClaimRetentionPolicyType PersistentVolumeClaimRetentionPolicyType = "Delete"
+)
+
+// SandboxPersistentVolumeClaimRetentionPolicy describes the lifecycle of PVCs created
+// from volumeClaimTemplates, mirroring the StatefulSet policy of the same name.
+type SandboxPersistentVolumeClaimRetentionPolicy struct {
+	// whenDeleted specifies what happens to PVCs when the Sandbox is deleted.
+	// +kubebuilder:default=Delete
+	// +optional
+	WhenDeleted PersistentVolumeClaimRetentionPolicyType `json:"whenDeleted,omitempty"`
+
+	// whenScaled specifies what happens to PVCs when the Sandbox's Pod is removed but
+	// the Sandbox is kept, i.e. when it is suspended or expires with shutdownPolicy Retain.
+	// PVCs deleted on suspend are recreated empty on resume.
+	// +kubebuilder:default=Retain
+	// +optional
+	WhenScaled PersistentVolumeClaimRetentionPolicyType `json:"whenScaled,omitempty"`
 }
 
 // ShutdownPolicy describes the policy for deleting the Sandbox when it expires.
@@ -253,10 +504,21 @@ type SandboxStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// observedGeneration is the metadata.generation the controller last reconciled
+	// the Sandbox's child resources for. Status lags the spec while it is below
+	// metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// selector is the label selector for pods.
 	// +optional
 	LabelSelector string `json:"selector,omitempty"`
 
+	// podName is the name of the underlying pod. It differs from the Sandbox
+	// name when the pod was adopted from a SandboxWarmPool.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
 	// podIPs are the IP addresses of the underlying pod.
 	// A pod may have multiple IPs in dual-stack clusters.
 	// +optional
@@ -265,6 +527,42 @@ type SandboxStatus struct {
 	// nodeName is the name of the node where the underlying pod is scheduled.
 	// +optional
 	NodeName string `json:"nodeName,omitempty"`
+
+	// podFQDN is the stable DNS name of the underlying pod,
+	// <hostname>.<service>.<namespace>.svc.<cluster domain>. It is set while the
+	// pod is a member of the Sandbox's headless Service.
+	// +optional
+	PodFQDN string `json:"podFQDN,omitempty"`
+
+	// appliedPodTemplateHash is the hash of spec.podTemplate.spec the controller
+	// created the underlying pod from, as recorded in the pod's
+	// agents.x-k8s.io/pod-spec-hash annotation. A pod whose annotation no longer
+	// matches it was mutated externally; see the PodDrifted condition.
+	// +optional
+	AppliedPodTemplateHash string `json:"appliedPodTemplateHash,omitempty"`
+
+	// runningImage is the image the primary container is running, as resolved by
+	// the kubelet (typically including the digest). It is empty until the
+	// container has been started.
+	// +optional
+	RunningImage string `json:"runningImage,omitempty"`
+
+	// restartCount is the total number of container restarts in the underlying
+	// pod, summed over its containers. It resets when the pod is replaced.
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// lastRestartTime is when a container in the underlying pod was most
+	// recently restarted.
+	// +optional
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+
+	// expiresIn is the time left until spec.shutdownTime, formatted like kubectl
+	// ages (e.g. "12m"). It is refreshed periodically, so it is approximate; use
+	// spec.shutdownTime for exact comparisons. Empty when no shutdownTime is set
+	// or the Sandbox has expired.
+	// +optional
+	ExpiresIn string `json:"expiresIn,omitempty"`
 }
 
 // +genclient
@@ -273,6 +571,11 @@ type SandboxStatus struct {
 // +kubebuilder:resource:scope=Namespaced,shortName=sandbox
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason"
+// +kubebuilder:printcolumn:name="Pod",type="string",JSONPath=".status.podName"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.podIPs[0]"
+// +kubebuilder:printcolumn:name="Restarts",type="integer",JSONPath=".status.restartCount"
+// +kubebuilder:printcolumn:name="Expires In",type="string",JSONPath=".status.expiresIn"
+// +kubebuilder:printcolumn:name="Observed Generation",type="integer",JSONPath=".status.observedGeneration",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 // +kubebuilder:conversion:strategy=Webhook
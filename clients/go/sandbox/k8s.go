@@ -424,7 +424,11 @@ func extractState(sb *sandboxv1beta1.Sandbox) *sandboxState {
 		state.Annotations = make(map[string]string, len(sb.Annotations))
 		maps.Copy(state.Annotations, sb.Annotations)
 	}
-	if name, ok := sb.Annotations[PodNameAnnotation]; ok {
+	// Prefer the typed status field; fall back to the annotation for
+	// controllers that predate status.podName.
+	if sb.Status.PodName != "" {
+		state.PodName = sb.Status.PodName
+	} else if name, ok := sb.Annotations[PodNameAnnotation]; ok {
 		state.PodName = name
 	} else {
 		state.PodName = sb.Name
@@ -2390,6 +2390,34 @@ func TestSetState_ClearsStaleAnnotations(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// extractState pod name resolution
+// ---------------------------------------------------------------------------
+
+func TestExtractState_PodName(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		statusPod   string
+		want        string
+	}{
+		{name: "status field wins", annotations: map[string]string{PodNameAnnotation: "ann-pod"}, statusPod: "status-pod", want: "status-pod"},
+		{name: "annotation fallback", annotations: map[string]string{PodNameAnnotation: "ann-pod"}, want: "ann-pod"},
+		{name: "sandbox name fallback", want: "sb1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &sandboxv1beta1.Sandbox{}
+			sb.Name = "sb1"
+			sb.Annotations = tt.annotations
+			sb.Status.PodName = tt.statusPod
+			if got := extractState(sb).PodName; got != tt.want {
+				t.Errorf("PodName = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Watch re-list loop after watch close
 // ---------------------------------------------------------------------------
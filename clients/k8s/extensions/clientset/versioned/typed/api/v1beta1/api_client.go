@@ -27,6 +27,7 @@ import (
 type ExtensionsV1beta1Interface interface {
 	RESTClient() rest.Interface
 	SandboxClaimsGetter
+	SandboxClaimSetsGetter
 	SandboxTemplatesGetter
 	SandboxWarmPoolsGetter
 }
@@ -40,6 +41,10 @@ func (c *ExtensionsV1beta1Client) SandboxClaims(namespace string) SandboxClaimIn
 	return newSandboxClaims(c, namespace)
 }
 
+func (c *ExtensionsV1beta1Client) SandboxClaimSets(namespace string) SandboxClaimSetInterface {
+	return newSandboxClaimSets(c, namespace)
+}
+
 func (c *ExtensionsV1beta1Client) SandboxTemplates(namespace string) SandboxTemplateInterface {
 	return newSandboxTemplates(c, namespace)
 }
@@ -30,6 +30,10 @@ func (c *FakeExtensionsV1beta1) SandboxClaims(namespace string) v1beta1.SandboxC
 	return newFakeSandboxClaims(c, namespace)
 }
 
+func (c *FakeExtensionsV1beta1) SandboxClaimSets(namespace string) v1beta1.SandboxClaimSetInterface {
+	return newFakeSandboxClaimSets(c, namespace)
+}
+
 func (c *FakeExtensionsV1beta1) SandboxTemplates(namespace string) v1beta1.SandboxTemplateInterface {
 	return newFakeSandboxTemplates(c, namespace)
 }
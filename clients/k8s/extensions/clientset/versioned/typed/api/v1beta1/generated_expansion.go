@@ -18,6 +18,8 @@ package v1beta1
 
 type SandboxClaimExpansion interface{}
 
+type SandboxClaimSetExpansion interface{}
+
 type SandboxTemplateExpansion interface{}
 
 type SandboxWarmPoolExpansion interface{}
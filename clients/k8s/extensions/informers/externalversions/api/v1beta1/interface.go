@@ -24,6 +24,8 @@ import (
 type Interface interface {
 	// SandboxClaims returns a SandboxClaimInformer.
 	SandboxClaims() SandboxClaimInformer
+	// SandboxClaimSets returns a SandboxClaimSetInformer.
+	SandboxClaimSets() SandboxClaimSetInformer
 	// SandboxTemplates returns a SandboxTemplateInformer.
 	SandboxTemplates() SandboxTemplateInformer
 	// SandboxWarmPools returns a SandboxWarmPoolInformer.
@@ -46,6 +48,11 @@ func (v *version) SandboxClaims() SandboxClaimInformer {
 	return &sandboxClaimInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SandboxClaimSets returns a SandboxClaimSetInformer.
+func (v *version) SandboxClaimSets() SandboxClaimSetInformer {
+	return &sandboxClaimSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SandboxTemplates returns a SandboxTemplateInformer.
 func (v *version) SandboxTemplates() SandboxTemplateInformer {
 	return &sandboxTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -62,6 +62,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=extensions.agents.x-k8s.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("sandboxclaims"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1beta1().SandboxClaims().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("sandboxclaimsets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1beta1().SandboxClaimSets().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("sandboxtemplates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1beta1().SandboxTemplates().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("sandboxwarmpools"):
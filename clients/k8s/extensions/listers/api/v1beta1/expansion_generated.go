@@ -24,6 +24,14 @@ type SandboxClaimListerExpansion interface{}
 // SandboxClaimNamespaceLister.
 type SandboxClaimNamespaceListerExpansion interface{}
 
+// SandboxClaimSetListerExpansion allows custom methods to be added to
+// SandboxClaimSetLister.
+type SandboxClaimSetListerExpansion interface{}
+
+// SandboxClaimSetNamespaceListerExpansion allows custom methods to be added to
+// SandboxClaimSetNamespaceLister.
+type SandboxClaimSetNamespaceListerExpansion interface{}
+
 // SandboxTemplateListerExpansion allows custom methods to be added to
 // SandboxTemplateLister.
 type SandboxTemplateListerExpansion interface{}
@@ -17,12 +17,14 @@ package main
 import (
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/controllers"
 )
 
@@ -40,6 +42,15 @@ import (
 //     the only spec field any controller reads (see PodCacheTransform) —
 //     and metadata.finalizers, which no controller reads on Pods.
 //
+// The ConfigMap and Secret informers are always restricted to objects carrying
+// the sandbox tracking label: the controller only reads the ones it created from
+// a Sandbox's configMapTemplates and secretTemplates, and caching every Secret in
+// the cluster would be both costly and needlessly sensitive.
+//
+// The ControllerRevision informer is always restricted to the SandboxTemplate
+// revisions, which carry the template ref hash label; StatefulSets and
+// DaemonSets keep a full pod template in each of theirs.
+//
 // With scopeToTrackingLabel, the Pod and Service informers are additionally
 // restricted to objects carrying the sandbox tracking label; see the
 // --cache-label-selectors flag help for the trade-off.
@@ -49,19 +60,30 @@ import (
 // scoped entry through a second &corev1.Pod{} literal would ADD a duplicate
 // Pod entry instead of replacing the unscoped one.
 func buildCacheOptions(scopeToTrackingLabel bool) (cache.Options, error) {
+	trackedOnly, err := labels.NewRequirement(controllers.SandboxNameHashLabel, selection.Exists, nil)
+	if err != nil {
+		return cache.Options{}, fmt.Errorf("building cache label selector: %w", err)
+	}
+	sel := labels.NewSelector().Add(*trackedOnly)
+
+	templateRevisions, err := labels.NewRequirement(sandboxv1beta1.SandboxTemplateRefHashLabel, selection.Exists, nil)
+	if err != nil {
+		return cache.Options{}, fmt.Errorf("building cache label selector: %w", err)
+	}
+
 	pod := &corev1.Pod{}
 	opts := cache.Options{
 		DefaultTransform: cache.TransformStripManagedFields(),
 		ByObject: map[client.Object]cache.ByObject{
-			pod: {Transform: controllers.PodCacheTransform},
+			pod:                 {Transform: controllers.PodCacheTransform},
+			&corev1.ConfigMap{}: {Label: sel},
+			&corev1.Secret{}:    {Label: sel},
+			&appsv1.ControllerRevision{}: {
+				Label: labels.NewSelector().Add(*templateRevisions),
+			},
 		},
 	}
 	if scopeToTrackingLabel {
-		trackedOnly, err := labels.NewRequirement(controllers.SandboxNameHashLabel, selection.Exists, nil)
-		if err != nil {
-			return cache.Options{}, fmt.Errorf("building cache label selector: %w", err)
-		}
-		sel := labels.NewSelector().Add(*trackedOnly)
 		podEntry := opts.ByObject[pod]
 		podEntry.Label = sel
 		opts.ByObject[pod] = podEntry
@@ -17,10 +17,12 @@ package main
 import (
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/controllers"
 )
 
@@ -51,14 +53,68 @@ func assertStripsManagedFields(t *testing.T, opts cache.Options) {
 	}
 }
 
+// assertConfigObjectsScoped asserts the ConfigMap and Secret informers are
+// scoped to the sandbox tracking label, regardless of --cache-label-selectors.
+func assertConfigObjectsScoped(t *testing.T, opts cache.Options) {
+	t.Helper()
+	found := map[string]int{}
+	for obj, entry := range opts.ByObject {
+		var name string
+		switch obj.(type) {
+		case *corev1.ConfigMap:
+			name = "ConfigMap"
+		case *corev1.Secret:
+			name = "Secret"
+		default:
+			continue
+		}
+		found[name]++
+		if entry.Label == nil {
+			t.Errorf("%s cache not label-scoped", name)
+		} else if got, want := entry.Label.String(), controllers.SandboxNameHashLabel; got != want {
+			t.Errorf("%s cache selector = %q, want %q", name, got, want)
+		}
+	}
+	for _, name := range []string{"ConfigMap", "Secret"} {
+		if found[name] != 1 {
+			t.Errorf("got %d %s entries in ByObject, want 1", found[name], name)
+		}
+	}
+}
+
+// assertControllerRevisionsScoped asserts the ControllerRevision informer only
+// sees SandboxTemplate revisions, regardless of --cache-label-selectors.
+func assertControllerRevisionsScoped(t *testing.T, opts cache.Options) {
+	t.Helper()
+	found := 0
+	for obj, entry := range opts.ByObject {
+		if _, ok := obj.(*appsv1.ControllerRevision); !ok {
+			continue
+		}
+		found++
+		if entry.Label == nil {
+			t.Error("ControllerRevision cache not label-scoped")
+		} else if got, want := entry.Label.String(), sandboxv1beta1.SandboxTemplateRefHashLabel; got != want {
+			t.Errorf("ControllerRevision cache selector = %q, want %q", got, want)
+		}
+	}
+	if found != 1 {
+		t.Errorf("got %d ControllerRevision entries in ByObject, want 1", found)
+	}
+}
+
 // entriesByType splits the ByObject map into the per-type entries, failing on
 // duplicates: ByObject is keyed by pointer, so an accidental second
-// &corev1.Pod{} key would silently produce two Pod configurations.
+// &corev1.Pod{} key would silently produce two Pod configurations. ConfigMap
+// and Secret entries are checked by assertConfigObjectsScoped, ControllerRevision
+// entries by assertControllerRevisionsScoped.
 func entriesByType(t *testing.T, opts cache.Options) (pod, svc *cache.ByObject) {
 	t.Helper()
 	for obj, entry := range opts.ByObject {
 		e := entry
 		switch obj.(type) {
+		case *corev1.ConfigMap, *corev1.Secret, *appsv1.ControllerRevision:
+			continue
 		case *corev1.Pod:
 			if pod != nil {
 				t.Fatal("duplicate *corev1.Pod entries in ByObject")
@@ -82,6 +138,8 @@ func TestBuildCacheOptionsUnscoped(t *testing.T) {
 		t.Fatalf("buildCacheOptions(false): %v", err)
 	}
 	assertStripsManagedFields(t, opts)
+	assertConfigObjectsScoped(t, opts)
+	assertControllerRevisionsScoped(t, opts)
 	pod, svc := entriesByType(t, opts)
 	if pod == nil {
 		t.Fatal("no Pod entry in ByObject")
@@ -103,6 +161,8 @@ func TestBuildCacheOptionsScopedToTrackingLabel(t *testing.T) {
 		t.Fatalf("buildCacheOptions(true): %v", err)
 	}
 	assertStripsManagedFields(t, opts)
+	assertConfigObjectsScoped(t, opts)
+	assertControllerRevisionsScoped(t, opts)
 	pod, svc := entriesByType(t, opts)
 	if pod == nil {
 		t.Fatal("no Pod entry in ByObject")
@@ -32,8 +32,10 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/felixge/fgprof"
+	"golang.org/x/sync/semaphore"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1alpha1 "sigs.k8s.io/agent-sandbox/extensions/api/v1alpha1"
@@ -46,6 +48,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	//+kubebuilder:scaffold:imports
@@ -57,11 +60,15 @@ var (
 
 func main() {
 	var metricsAddr string
-	var enableLeaderElection bool
-	var leaderElectionNamespace string
+	var metricsSecure bool
+	var leaderElection leaderElectionOptions
 	var probeAddr string
 	var extensions bool
 	var clusterDomain string
+	var propagateLabels string
+	var propagateAnnotations string
+	var defaultResources defaultResourceOptions
+	var hardenPods bool
 	var enableTracing bool
 	var enablePprof bool
 	var enablePprofDebug bool
@@ -70,9 +77,12 @@ func main() {
 	var kubeAPIQPS float64
 	var kubeAPIBurst int
 	var sandboxConcurrentWorkers int
+	var maxConcurrentPodCreates int
+	var reconcileTimeout time.Duration
 	var sandboxClaimConcurrentWorkers int
 	var sandboxWarmPoolConcurrentWorkers int
 	var sandboxTemplateConcurrentWorkers int
+	var sandboxClaimSetConcurrentWorkers int
 	var sandboxWarmPoolMaxBatchSize int
 	var enableWarmPoolEviction bool
 	var cacheLabelSelectors bool
@@ -96,12 +106,30 @@ func main() {
 	flag.BoolVar(&manageWebhookCerts, "manage-webhook-certs", true, "Manage webhook serving certs and patch CRD conversion caBundles on startup. Set to false when certs and CRD/webhook configuration are managed externally by a certificate provisioner.")
 	flag.BoolVar(&enableWebhook, "enable-webhook", true, "Enable webhook server and webhook registrations.")
 	flag.StringVar(&clusterDomain, "cluster-domain", "cluster.local", "Kubernetes cluster domain for service FQDN generation")
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&propagateLabels, "propagate-labels", "", "Comma-separated Sandbox label keys to copy onto the Sandbox's Pod and Service. Entries ending in '*' match by prefix. Empty copies none.")
+	flag.StringVar(&propagateAnnotations, "propagate-annotations", "", "Comma-separated Sandbox annotation keys to copy onto the Sandbox's Pod and Service. Entries ending in '*' match by prefix. Empty copies none.")
+	flag.StringVar(&defaultResources.cpuRequest, "default-cpu-request", "", "CPU request given to sandbox containers that set neither a CPU request nor a CPU limit, e.g. 100m. Empty sets none.")
+	flag.StringVar(&defaultResources.memoryRequest, "default-memory-request", "", "Memory request given to sandbox containers that set neither a memory request nor a memory limit, e.g. 128Mi. Empty sets none.")
+	flag.StringVar(&defaultResources.cpuLimit, "default-cpu-limit", "", "CPU limit given to sandbox containers that set no CPU limit and request no more than it, e.g. 1. Empty sets none.")
+	flag.StringVar(&defaultResources.memoryLimit, "default-memory-limit", "", "Memory limit given to sandbox containers that set no memory limit and request no more than it, e.g. 1Gi. Empty sets none.")
+	flag.BoolVar(&hardenPods, "harden-pods", false, "Give sandbox Pods a restrictive security context wherever the pod template leaves it unset: run as non-root, RuntimeDefault seccomp profile, all capabilities dropped, no privilege escalation and a read-only root filesystem with a writable /tmp.")
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to. Set to 0 to disable the metrics endpoint.")
+	flag.BoolVar(&metricsSecure, "metrics-secure", false,
+		"Serve metrics over HTTPS and require callers to be authenticated and authorized by the API server "+
+			"(get on the /metrics non-resource URL). The default serves plain HTTP without authentication.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
+	flag.BoolVar(&leaderElection.enabled, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "The namespace in which the leader election resource will be created.")
+	flag.StringVar(&leaderElection.namespace, "leader-election-namespace", "", "The namespace in which the leader election resource will be created.")
+	flag.StringVar(&leaderElection.id, "leader-election-id", defaultLeaderElectionID,
+		"The name of the leader election Lease. Only set it to run isolated controller instances side by side.")
+	flag.DurationVar(&leaderElection.leaseDuration, "leader-elect-lease-duration", defaultLeaderElectionLeaseDuration,
+		"How long non-leaders wait after the last observed renewal before trying to acquire leadership.")
+	flag.DurationVar(&leaderElection.renewDeadline, "leader-elect-renew-deadline", defaultLeaderElectionRenewDeadline,
+		"How long the leader keeps retrying to renew its lease before giving up leadership. Must be less than the lease duration.")
+	flag.DurationVar(&leaderElection.retryPeriod, "leader-elect-retry-period", defaultLeaderElectionRetryPeriod,
+		"How long clients wait between attempts to acquire or renew leadership.")
 	flag.BoolVar(&extensions, "extensions", false, "Enable extensions controllers.")
 	flag.BoolVar(&enableTracing, "enable-tracing", false, "Enable OpenTelemetry tracing via OTLP.")
 	flag.BoolVar(&enablePprof, "enable-pprof", false,
@@ -118,10 +146,13 @@ func main() {
 	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", -1.0, "Client-side QPS limit for the Kubernetes API client (default: -1, no client-side rate limiting)")
 	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 10, "The maximum burst for client-side throttling of the Kubernetes API client.")
 	flag.IntVar(&sandboxConcurrentWorkers, "sandbox-concurrent-workers", 100, "Max concurrent reconciles for the Sandbox controller")
+	flag.IntVar(&maxConcurrentPodCreates, "max-concurrent-pod-creates", 0, "Max pod creations in flight across all Sandbox reconciles. Sandboxes over the limit are requeued. 0 means unlimited.")
 	flag.IntVar(&sandboxClaimConcurrentWorkers, "sandbox-claim-concurrent-workers", 50, "Max concurrent reconciles for the SandboxClaim controller")
 	flag.IntVar(&sandboxWarmPoolConcurrentWorkers, "sandbox-warm-pool-concurrent-workers", 1, "Max concurrent reconciles for the SandboxWarmPool controller")
 	flag.IntVar(&sandboxTemplateConcurrentWorkers, "sandbox-template-concurrent-workers", 1, "Max concurrent reconciles for the SandboxTemplate controller")
+	flag.IntVar(&sandboxClaimSetConcurrentWorkers, "sandbox-claim-set-concurrent-workers", 1, "Max concurrent reconciles for the SandboxClaimSet controller")
 	flag.IntVar(&sandboxWarmPoolMaxBatchSize, "sandbox-warm-pool-max-batch-size", 300, "Max batch size for parallel sandbox creation and deletion in SandboxWarmPool controller. Default is 300.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 0, "Deadline for each Reconcile call of every controller. A reconcile that exceeds it is cancelled and requeued. 0 means no deadline.")
 	flag.BoolVar(&enableWarmPoolEviction, "enable-warm-pool-eviction", true, "Mark pods created by a warm pool as ready-to-evict by default.")
 	flag.BoolVar(&cacheLabelSelectors, "cache-label-selectors", false,
 		"Scope the manager's Pod and Service informer caches to objects carrying the sandbox tracking label ("+
@@ -158,21 +189,30 @@ func main() {
 		"sandboxClaim", sandboxClaimConcurrentWorkers,
 		"sandboxWarmPool", sandboxWarmPoolConcurrentWorkers,
 		"sandboxTemplate", sandboxTemplateConcurrentWorkers,
+		"sandboxClaimSet", sandboxClaimSetConcurrentWorkers,
 		"sandboxWarmPoolMaxBatchSize", sandboxWarmPoolMaxBatchSize,
 	)
 
 	// Validation checks for concurrency flags
-	if sandboxConcurrentWorkers <= 0 || sandboxClaimConcurrentWorkers <= 0 || sandboxWarmPoolConcurrentWorkers <= 0 {
+	if sandboxConcurrentWorkers <= 0 || sandboxClaimConcurrentWorkers <= 0 || sandboxWarmPoolConcurrentWorkers <= 0 || sandboxClaimSetConcurrentWorkers <= 0 {
 		setupLog.Error(nil, "concurrent workers must be greater than 0")
 		os.Exit(1)
 	}
+	if maxConcurrentPodCreates < 0 {
+		setupLog.Error(nil, "max-concurrent-pod-creates must not be negative")
+		os.Exit(1)
+	}
+	if reconcileTimeout < 0 {
+		setupLog.Error(nil, "reconcile-timeout must not be negative")
+		os.Exit(1)
+	}
 	// Validation checks for sandboxWarmPoolMaxBatchSize (maximum batch size for sandbox creation and deletion in SandboxWarmPool controller)
 	if sandboxWarmPoolMaxBatchSize <= 0 {
 		setupLog.Error(nil, "sandbox-warm-pool-max-batch-size must be greater than 0")
 		os.Exit(1)
 	}
 	// A logical maximum (too much will create unnecessary load on the API server)
-	totalWorkers := sandboxConcurrentWorkers + sandboxClaimConcurrentWorkers + sandboxWarmPoolConcurrentWorkers + sandboxTemplateConcurrentWorkers
+	totalWorkers := sandboxConcurrentWorkers + sandboxClaimConcurrentWorkers + sandboxWarmPoolConcurrentWorkers + sandboxTemplateConcurrentWorkers + sandboxClaimSetConcurrentWorkers
 	if totalWorkers > 1000 {
 		setupLog.Info("Warning: total concurrent workers exceeds 1000, which could lead to resource exhaustion", "total", totalWorkers)
 	}
@@ -189,7 +229,25 @@ func main() {
 		)
 	}
 
-	if enableLeaderElection && leaderElectionNamespace == "" {
+	podDefaultResources, err := defaultResources.build()
+	if err != nil {
+		setupLog.Error(err, "invalid default container resources")
+		os.Exit(1)
+	}
+
+	if strings.TrimSpace(leaderElection.id) == "" {
+		setupLog.Error(nil, "--leader-election-id cannot be empty")
+		os.Exit(1)
+	}
+	if leaderElection.leaseDuration <= 0 || leaderElection.renewDeadline <= 0 || leaderElection.retryPeriod <= 0 {
+		setupLog.Error(nil, "leader election durations must be greater than 0")
+		os.Exit(1)
+	}
+	if leaderElection.renewDeadline >= leaderElection.leaseDuration {
+		setupLog.Error(nil, "--leader-elect-renew-deadline must be less than --leader-elect-lease-duration")
+		os.Exit(1)
+	}
+	if leaderElection.enabled && leaderElection.namespace == "" {
 		setupLog.V(1).Info("leader election is enabled (--leader-elect=true), but --leader-election-namespace is empty; attempting auto-detection")
 	}
 
@@ -235,6 +293,12 @@ func main() {
 	metricsOpts := metricsserver.Options{
 		BindAddress: metricsAddr,
 	}
+	if metricsSecure {
+		// Serves with a self-signed certificate and checks every request, including
+		// the pprof handlers below, with a TokenReview and a SubjectAccessReview.
+		metricsOpts.SecureServing = true
+		metricsOpts.FilterProvider = filters.WithAuthenticationAndAuthorization
+	}
 	if enablePprof || enablePprofDebug {
 		setupLog.Info("pprof enabled", "debug", enablePprofDebug)
 		metricsOpts.ExtraHandlers = map[string]http.Handler{
@@ -327,7 +391,7 @@ func main() {
 		}
 	}
 
-	mgrOpts := buildManagerOptions(scheme, metricsOpts, probeAddr, enableLeaderElection, leaderElectionNamespace)
+	mgrOpts := buildManagerOptions(scheme, metricsOpts, probeAddr, leaderElection, reconcileTimeout)
 	// managedFields stripping, the Pod spec diet, and (optionally) the
 	// tracking-label scoping; see buildCacheOptions for the rationale.
 	cacheOpts, err := buildCacheOptions(cacheLabelSelectors)
@@ -363,11 +427,21 @@ func main() {
 	// Register the custom Sandbox metric collector globally.
 	asmetrics.RegisterSandboxCollector(mgr.GetClient(), mgr.GetLogger().WithName("sandbox-collector"))
 
+	var podCreateLimiter *semaphore.Weighted
+	if maxConcurrentPodCreates > 0 {
+		podCreateLimiter = semaphore.NewWeighted(int64(maxConcurrentPodCreates))
+	}
 	if err = (&controllers.SandboxReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		Tracer:        instrumenter,
-		ClusterDomain: clusterDomain,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Tracer:               instrumenter,
+		Recorder:             mgr.GetEventRecorder("sandbox-controller"),
+		ClusterDomain:        clusterDomain,
+		PropagateLabels:      controllers.ParseMetadataAllowlist(propagateLabels),
+		PropagateAnnotations: controllers.ParseMetadataAllowlist(propagateAnnotations),
+		PodCreateLimiter:     podCreateLimiter,
+		DefaultResources:     podDefaultResources,
+		HardenPods:           hardenPods,
 	}).SetupWithManager(mgr, sandboxConcurrentWorkers); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Sandbox")
 		os.Exit(1)
@@ -425,6 +499,15 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&extensionscontrollers.SandboxTemplateRevisionReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Tracer: instrumenter,
+		}).SetupWithManager(mgr, sandboxTemplateConcurrentWorkers); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SandboxTemplateRevision")
+			os.Exit(1)
+		}
+
 		if err = (&extensionscontrollers.SandboxWarmPoolReconciler{
 			Client:                 mgr.GetClient(),
 			Scheme:                 mgr.GetScheme(),
@@ -435,6 +518,15 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&extensionscontrollers.SandboxClaimSetReconciler{
+			Client:       mgr.GetClient(),
+			Scheme:       mgr.GetScheme(),
+			MaxBatchSize: sandboxWarmPoolMaxBatchSize,
+		}).SetupWithManager(mgr, sandboxClaimSetConcurrentWorkers); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SandboxClaimSet")
+			os.Exit(1)
+		}
+
 		if enableWebhook {
 			if err = ctrl.NewWebhookManagedBy(mgr, &extensionsv1beta1.SandboxClaim{}).
 				Complete(); err != nil {
@@ -466,6 +558,15 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("crds", crdReadyzCheck(discoveryClient, requiredKinds(extensions))); err != nil {
+		setupLog.Error(err, "unable to set up CRD ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -15,22 +15,53 @@
 package main
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
+const (
+	// defaultLeaderElectionID is the name of the leader-election Lease. Changing
+	// it lets two controller versions run as leaders concurrently during an
+	// upgrade, so --leader-election-id is only for isolated instances.
+	defaultLeaderElectionID = "a3317529.agent-sandbox.x-k8s.io"
+
+	// The lease timings default to controller-runtime's own defaults.
+	defaultLeaderElectionLeaseDuration = 15 * time.Second
+	defaultLeaderElectionRenewDeadline = 10 * time.Second
+	defaultLeaderElectionRetryPeriod   = 2 * time.Second
+)
+
+// leaderElectionOptions holds the --leader-elect* and --leader-election-*
+// flag values.
+type leaderElectionOptions struct {
+	enabled       bool
+	namespace     string
+	id            string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+}
+
 // buildManagerOptions constructs the controller manager options used by
 // main(). The webhook server option is applied separately in main() when the
-// webhook subsystem is enabled.
-func buildManagerOptions(scheme *runtime.Scheme, metricsOpts metricsserver.Options, probeAddr string, enableLeaderElection bool, leaderElectionNamespace string) ctrl.Options {
+// webhook subsystem is enabled. A non-zero reconcileTimeout bounds the context
+// of every Reconcile call, so a wedged API server cannot hold a worker forever;
+// a reconcile that hits it fails with the context error and is requeued.
+func buildManagerOptions(scheme *runtime.Scheme, metricsOpts metricsserver.Options, probeAddr string, leaderElection leaderElectionOptions, reconcileTimeout time.Duration) ctrl.Options {
 	return ctrl.Options{
 		Scheme:                  scheme,
 		Metrics:                 metricsOpts,
 		HealthProbeBindAddress:  probeAddr,
-		LeaderElection:          enableLeaderElection,
-		LeaderElectionNamespace: leaderElectionNamespace,
-		LeaderElectionID:        "a3317529.agent-sandbox.x-k8s.io",
+		LeaderElection:          leaderElection.enabled,
+		LeaderElectionNamespace: leaderElection.namespace,
+		LeaderElectionID:        leaderElection.id,
+		LeaseDuration:           new(leaderElection.leaseDuration),
+		RenewDeadline:           new(leaderElection.renewDeadline),
+		RetryPeriod:             new(leaderElection.retryPeriod),
 		// Release the leader Lease on graceful shutdown so a rolling update
 		// hands over leadership in ~0-2s instead of waiting out the full 15s
 		// LeaseDuration with no active controller — at a sustained 500
@@ -42,5 +73,8 @@ func buildManagerOptions(scheme *runtime.Scheme, metricsOpts metricsserver.Optio
 		// and any deferred shutdown work (e.g. tracing cleanup) must stay
 		// bounded so the process still exits promptly.
 		LeaderElectionReleaseOnCancel: true,
+		Controller: config.Controller{
+			ReconciliationTimeout: reconcileTimeout,
+		},
 	}
 }
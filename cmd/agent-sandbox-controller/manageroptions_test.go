@@ -16,6 +16,7 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,16 +28,17 @@ import (
 // cleanly, so a rolling update hands leadership over immediately instead of
 // waiting out the LeaseDuration.
 func TestBuildManagerOptionsReleasesLeaseOnCancel(t *testing.T) {
-	opts := buildManagerOptions(runtime.NewScheme(), metricsserver.Options{}, ":8081", true, "agent-sandbox-system")
+	opts := buildManagerOptions(runtime.NewScheme(), metricsserver.Options{}, ":8081", leaderElectionOptions{enabled: true, namespace: "agent-sandbox-system", id: defaultLeaderElectionID}, 0)
 	assert.True(t, opts.LeaderElectionReleaseOnCancel,
 		"LeaderElectionReleaseOnCancel must stay true so graceful shutdowns hand over leadership without waiting out the LeaseDuration")
 }
 
-// TestBuildManagerOptionsLeaderElectionID pins the leader-election lock name;
-// changing it would let two controller versions run as leaders concurrently
-// during an upgrade.
+// TestBuildManagerOptionsLeaderElectionID pins the default leader-election lock
+// name; changing it would let two controller versions run as leaders
+// concurrently during an upgrade.
 func TestBuildManagerOptionsLeaderElectionID(t *testing.T) {
-	opts := buildManagerOptions(runtime.NewScheme(), metricsserver.Options{}, ":8081", true, "")
+	assert.Equal(t, "a3317529.agent-sandbox.x-k8s.io", defaultLeaderElectionID)
+	opts := buildManagerOptions(runtime.NewScheme(), metricsserver.Options{}, ":8081", leaderElectionOptions{enabled: true, id: defaultLeaderElectionID}, 0)
 	assert.Equal(t, "a3317529.agent-sandbox.x-k8s.io", opts.LeaderElectionID)
 }
 
@@ -48,14 +50,28 @@ func TestBuildManagerOptionsPassThrough(t *testing.T) {
 
 	for _, enableLeaderElection := range []bool{true, false} {
 		for _, namespace := range []string{"", "agent-sandbox-system"} {
-			opts := buildManagerOptions(scheme, metricsOpts, ":8081", enableLeaderElection, namespace)
+			opts := buildManagerOptions(scheme, metricsOpts, ":8081", leaderElectionOptions{
+				enabled:       enableLeaderElection,
+				namespace:     namespace,
+				id:            "tenant-a.agent-sandbox.x-k8s.io",
+				leaseDuration: 30 * time.Second,
+				renewDeadline: 20 * time.Second,
+				retryPeriod:   5 * time.Second,
+			}, time.Minute)
 			assert.Equal(t, enableLeaderElection, opts.LeaderElection,
 				"LeaderElection must pass through --leader-elect")
 			assert.Equal(t, namespace, opts.LeaderElectionNamespace,
 				"LeaderElectionNamespace must pass through --leader-election-namespace")
+			assert.Equal(t, "tenant-a.agent-sandbox.x-k8s.io", opts.LeaderElectionID,
+				"LeaderElectionID must pass through --leader-election-id")
+			assert.Equal(t, new(30*time.Second), opts.LeaseDuration)
+			assert.Equal(t, new(20*time.Second), opts.RenewDeadline)
+			assert.Equal(t, new(5*time.Second), opts.RetryPeriod)
 			assert.Same(t, scheme, opts.Scheme)
 			assert.Equal(t, ":8081", opts.HealthProbeBindAddress)
 			assert.Equal(t, ":8080", opts.Metrics.BindAddress)
+			assert.Equal(t, time.Minute, opts.Controller.ReconciliationTimeout,
+				"Controller.ReconciliationTimeout must pass through --reconcile-timeout")
 		}
 	}
 }
@@ -0,0 +1,71 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// defaultResourceOptions holds the --default-*-request and --default-*-limit
+// flag values.
+type defaultResourceOptions struct {
+	cpuRequest    string
+	memoryRequest string
+	cpuLimit      string
+	memoryLimit   string
+}
+
+// build parses the flag values into the requests and limits the Sandbox
+// controller fills into containers lacking them. Empty values are left out. A
+// default request above the default limit for the same resource is rejected,
+// since it would produce Pods the API server refuses.
+func (o defaultResourceOptions) build() (corev1.ResourceRequirements, error) {
+	var res corev1.ResourceRequirements
+	for _, v := range []struct {
+		flag  string
+		value string
+		name  corev1.ResourceName
+		list  *corev1.ResourceList
+	}{
+		{"--default-cpu-request", o.cpuRequest, corev1.ResourceCPU, &res.Requests},
+		{"--default-memory-request", o.memoryRequest, corev1.ResourceMemory, &res.Requests},
+		{"--default-cpu-limit", o.cpuLimit, corev1.ResourceCPU, &res.Limits},
+		{"--default-memory-limit", o.memoryLimit, corev1.ResourceMemory, &res.Limits},
+	} {
+		if v.value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(v.value)
+		if err != nil {
+			return corev1.ResourceRequirements{}, fmt.Errorf("%s: %w", v.flag, err)
+		}
+		if q.Sign() <= 0 {
+			return corev1.ResourceRequirements{}, fmt.Errorf("%s must be greater than 0", v.flag)
+		}
+		if *v.list == nil {
+			*v.list = corev1.ResourceList{}
+		}
+		(*v.list)[v.name] = q
+	}
+	for name, request := range res.Requests {
+		if limit, ok := res.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return corev1.ResourceRequirements{}, fmt.Errorf("default %s request %s is above the default %s limit %s", name, request.String(), name, limit.String())
+		}
+	}
+	return res, nil
+}
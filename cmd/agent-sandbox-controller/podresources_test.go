@@ -0,0 +1,84 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDefaultResourceOptionsBuild(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    defaultResourceOptions
+		want    corev1.ResourceRequirements
+		wantErr string
+	}{
+		{
+			name: "no flags set",
+		},
+		{
+			name: "requests and limits",
+			opts: defaultResourceOptions{cpuRequest: "100m", memoryRequest: "128Mi", cpuLimit: "1", memoryLimit: "1Gi"},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			name: "requests only",
+			opts: defaultResourceOptions{cpuRequest: "250m"},
+			want: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			},
+		},
+		{
+			name:    "malformed quantity",
+			opts:    defaultResourceOptions{memoryLimit: "lots"},
+			wantErr: "--default-memory-limit",
+		},
+		{
+			name:    "zero quantity",
+			opts:    defaultResourceOptions{cpuRequest: "0"},
+			wantErr: "--default-cpu-request must be greater than 0",
+		},
+		{
+			name:    "request above limit",
+			opts:    defaultResourceOptions{memoryRequest: "2Gi", memoryLimit: "1Gi"},
+			wantErr: "default memory request 2Gi is above the default memory limit 1Gi",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.opts.build()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
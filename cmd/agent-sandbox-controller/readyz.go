@@ -0,0 +1,84 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// requiredKinds lists the custom resource kinds the enabled controllers watch.
+func requiredKinds(extensions bool) []schema.GroupVersionKind {
+	kinds := []schema.GroupVersionKind{
+		sandboxv1beta1.GroupVersion.WithKind(sandboxv1beta1.SandboxKind),
+	}
+	if extensions {
+		kinds = append(kinds,
+			extensionsv1beta1.GroupVersion.WithKind(extensionsv1beta1.SandboxClaimKind),
+			extensionsv1beta1.GroupVersion.WithKind(extensionsv1beta1.SandboxClaimSetKind),
+			extensionsv1beta1.GroupVersion.WithKind(extensionsv1beta1.SandboxTemplateKind),
+			extensionsv1beta1.GroupVersion.WithKind(extensionsv1beta1.SandboxWarmPoolKind),
+		)
+	}
+	return kinds
+}
+
+// crdReadyzCheck fails until the API server serves every kind in kinds, which
+// it only does once their CRDs are installed and established. Without it, a
+// controller started with --extensions against a cluster missing the
+// extensions CRDs reports ready while its watches silently fail. Once every
+// kind has been found the check passes without further discovery calls.
+func crdReadyzCheck(dc discovery.DiscoveryInterface, kinds []schema.GroupVersionKind) healthz.Checker {
+	var ready atomic.Bool
+	return func(_ *http.Request) error {
+		if ready.Load() {
+			return nil
+		}
+		served := map[schema.GroupVersion]map[string]bool{}
+		var missing []string
+		for _, gvk := range kinds {
+			gv := gvk.GroupVersion()
+			if _, ok := served[gv]; !ok {
+				resources, err := dc.ServerResourcesForGroupVersion(gv.String())
+				if err != nil && !k8errors.IsNotFound(err) {
+					return fmt.Errorf("discovering %s: %w", gv, err)
+				}
+				served[gv] = map[string]bool{}
+				if resources != nil {
+					for _, r := range resources.APIResources {
+						served[gv][r.Kind] = true
+					}
+				}
+			}
+			if !served[gv][gvk.Kind] {
+				missing = append(missing, gvk.Kind+"."+gv.String())
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("CRDs not established: %s", strings.Join(missing, ", "))
+		}
+		ready.Store(true)
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+)
+
+// TestCRDReadyzCheck verifies the ready check fails while a required kind is
+// not served and passes, without re-querying discovery, once all are.
+func TestCRDReadyzCheck(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: sandboxv1beta1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "sandboxes", Kind: sandboxv1beta1.SandboxKind}},
+	}}
+	check := crdReadyzCheck(dc, requiredKinds(true))
+
+	err := check(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SandboxClaim."+extensionsv1beta1.GroupVersion.String())
+	assert.NotContains(t, err.Error(), "Sandbox."+sandboxv1beta1.GroupVersion.String())
+
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: extensionsv1beta1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{Name: "sandboxclaims", Kind: extensionsv1beta1.SandboxClaimKind},
+			{Name: "sandboxclaimsets", Kind: extensionsv1beta1.SandboxClaimSetKind},
+			{Name: "sandboxtemplates", Kind: extensionsv1beta1.SandboxTemplateKind},
+			{Name: "sandboxwarmpools", Kind: extensionsv1beta1.SandboxWarmPoolKind},
+		},
+	})
+	require.NoError(t, check(nil))
+
+	dc.Resources = nil
+	assert.NoError(t, check(nil), "the check must stay ready once the CRDs were found")
+}
+
+// TestCRDReadyzCheckCoreOnly verifies the extensions kinds are only required
+// with --extensions.
+func TestCRDReadyzCheckCoreOnly(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: sandboxv1beta1.GroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "sandboxes", Kind: sandboxv1beta1.SandboxKind}},
+	}}
+	assert.NoError(t, crdReadyzCheck(dc, requiredKinds(false))(nil))
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -60,7 +61,8 @@ func fullPodFixture() *corev1.Pod {
 
 // TestPodCacheTransform verifies exactly what the informer transform keeps
 // and drops: everything the controllers read survives (metadata, status,
-// spec.nodeName); managedFields and the bulky spec payload do not.
+// spec.nodeName, container names and images); managedFields and the bulky
+// spec payload do not.
 func TestPodCacheTransform(t *testing.T) {
 	out, err := PodCacheTransform(fullPodFixture())
 	if err != nil {
@@ -78,15 +80,23 @@ func TestPodCacheTransform(t *testing.T) {
 	if pod.Finalizers != nil {
 		t.Error("finalizers not stripped")
 	}
-	if len(pod.Spec.Containers) != 0 || len(pod.Spec.InitContainers) != 0 ||
-		len(pod.Spec.Volumes) != 0 || len(pod.Spec.Tolerations) != 0 {
+	if len(pod.Spec.Volumes) != 0 || len(pod.Spec.Tolerations) != 0 {
 		t.Errorf("pod spec not stripped: %+v", pod.Spec)
 	}
+	if len(pod.Spec.Containers) == 1 && pod.Spec.Containers[0].Env != nil {
+		t.Errorf("container env not stripped: %+v", pod.Spec.Containers[0])
+	}
 
 	// Kept: the fields the controllers actually read.
 	if pod.Spec.NodeName != "node-7" {
 		t.Errorf("spec.nodeName lost: %q", pod.Spec.NodeName)
 	}
+	wantContainers := []corev1.Container{{Name: "c", Image: "debian:latest"}}
+	wantInitContainers := []corev1.Container{{Name: "init", Image: "busybox"}}
+	if !equality.Semantic.DeepEqual(pod.Spec.Containers, wantContainers) ||
+		!equality.Semantic.DeepEqual(pod.Spec.InitContainers, wantInitContainers) {
+		t.Errorf("container names and images lost: %+v %+v", pod.Spec.InitContainers, pod.Spec.Containers)
+	}
 	if pod.Labels[sandboxLabel] != "hash-1" {
 		t.Error("labels lost")
 	}
@@ -15,15 +15,19 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"hash/fnv"
 	"maps"
+	"path"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/sync/semaphore"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,20 +35,29 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sandboxv1alpha1 "sigs.k8s.io/agent-sandbox/api/v1alpha1"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
 	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 	"sigs.k8s.io/agent-sandbox/internal/utils"
 )
 
@@ -57,9 +70,32 @@ const (
 	SandboxNameHashLabel = sandboxLabel
 	// podSandboxNameHashIndex is the cache field index over the sandboxLabel
 	// value on Pods, so per-reconcile pod lookups are O(1).
-	podSandboxNameHashIndex     = ".metadata.labels[" + sandboxLabel + "]"
+	podSandboxNameHashIndex = ".metadata.labels[" + sandboxLabel + "]"
+	// sandboxNameHashIndex is the cache field index over NameHash of the Sandbox
+	// name, used to find Sandboxes whose names hash to the same sandboxLabel value.
+	sandboxNameHashIndex        = ".metadata.nameHash"
 	sandboxControllerFieldOwner = "sandbox-controller"
 	immediateRequeueDelay       = time.Millisecond
+	// forbiddenRequeueDelay is how long to wait before retrying after child resource
+	// creation was forbidden. Quota being freed up is not signaled by any watched
+	// object, so the Sandbox is polled instead of retried with backoff.
+	forbiddenRequeueDelay = 30 * time.Second
+	// podCreateThrottledRequeueDelay is the base delay before retrying a pod creation
+	// that found every --max-concurrent-pod-creates slot taken. It is jittered so a
+	// burst of throttled Sandboxes does not come back in lockstep.
+	podCreateThrottledRequeueDelay = time.Second
+	// Environment variables injected into the Sandbox's containers when
+	// spec.injectEnv is set.
+	sandboxNameEnvVar      = "SANDBOX_NAME"
+	sandboxNamespaceEnvVar = "SANDBOX_NAMESPACE"
+	sandboxFQDNEnvVar      = "SANDBOX_FQDN"
+	// hardenedTmpVolume is the emptyDir --harden-pods mounts at /tmp in containers
+	// whose root filesystem it makes read-only.
+	hardenedTmpVolume = "agent-sandbox-tmp"
+	// expiresInMinRefresh and expiresInMaxRefresh bound how often status.expiresIn
+	// is recomputed: a tenth of the time left, so the value drifts by at most ~10%.
+	expiresInMinRefresh = 30 * time.Second
+	expiresInMaxRefresh = time.Hour
 )
 
 // PodCacheTransform is a client-go informer transform for the manager's Pod
@@ -72,9 +108,10 @@ const (
 //   - metadata.finalizers: never read on Pods by any controller in this repo
 //     (the sandboxes/finalizers RBAC is for the Sandbox CR itself, not Pods).
 //     Stripping is safe and saves a trivial amount of memory.
-//   - spec: the only spec field any controller reads is spec.nodeName
-//     (propagated to Sandbox status), so it is the only field preserved. The
-//     pod spec the controller WRITES is built from the Sandbox's PodTemplate
+//   - spec: the only spec fields any controller reads are spec.nodeName,
+//     spec.hostname and spec.subdomain (propagated to Sandbox status) and the
+//     name and image of each container (compared with the pod template to
+//     report PodDrifted), so they are the only fields preserved. The pod spec the controller WRITES is built from the Sandbox's PodTemplate
 //     (reconcilePod's create path), never from the cached pod, and every pod write in this
 //     repo is a metadata-only merge patch diffed against the same transformed
 //     cache object — stripped fields appear on neither side of the diff, so
@@ -91,10 +128,102 @@ func PodCacheTransform(obj any) (any, error) {
 	}
 	pod.ManagedFields = nil
 	pod.Finalizers = nil
-	pod.Spec = corev1.PodSpec{NodeName: pod.Spec.NodeName}
+	pod.Spec = corev1.PodSpec{
+		NodeName:       pod.Spec.NodeName,
+		Hostname:       pod.Spec.Hostname,
+		Subdomain:      pod.Spec.Subdomain,
+		InitContainers: containerImagesOnly(pod.Spec.InitContainers),
+		Containers:     containerImagesOnly(pod.Spec.Containers),
+	}
 	return pod, nil
 }
 
+// containerImagesOnly returns containers reduced to their names and images.
+func containerImagesOnly(containers []corev1.Container) []corev1.Container {
+	if len(containers) == 0 {
+		return nil
+	}
+	out := make([]corev1.Container, len(containers))
+	for i := range containers {
+		out[i] = corev1.Container{Name: containers[i].Name, Image: containers[i].Image}
+	}
+	return out
+}
+
+// primaryContainerName returns the name of the Sandbox's primary container:
+// spec.primaryContainer if set, otherwise the first pod template container.
+// The template is used rather than the pod because the pod cache strips the spec.
+func primaryContainerName(sandbox *sandboxv1beta1.Sandbox) string {
+	if sandbox.Spec.PrimaryContainer != "" {
+		return sandbox.Spec.PrimaryContainer
+	}
+	if containers := sandbox.Spec.PodTemplate.Spec.Containers; len(containers) > 0 {
+		return containers[0].Name
+	}
+	return ""
+}
+
+// primaryContainerStatus returns the pod's status for the Sandbox's primary
+// container, or nil if the kubelet has not reported it yet.
+func primaryContainerStatus(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) *corev1.ContainerStatus {
+	name := primaryContainerName(sandbox)
+	if name == "" {
+		return nil
+	}
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == name {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// primaryContainerReady reports whether the Sandbox's primary container is Ready,
+// returning a condition message when it is not. A pod can report Ready while a
+// container status lags behind, so multi-container sandboxes track the container
+// that matters explicitly. A missing status only blocks readiness when the
+// primary container was named explicitly, since it then may not exist at all.
+func primaryContainerReady(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) (string, bool) {
+	cs := primaryContainerStatus(sandbox, pod)
+	if cs == nil {
+		if sandbox.Spec.PrimaryContainer != "" {
+			return fmt.Sprintf("Pod is Ready but has no status for primary container %q", sandbox.Spec.PrimaryContainer), false
+		}
+		return "", true
+	}
+	if !cs.Ready {
+		return fmt.Sprintf("Pod is Ready but primary container %q is not Ready", cs.Name), false
+	}
+	return "", true
+}
+
+// podRestarts sums the restart counts of the pod's containers and returns the
+// most recent time one of them was restarted: the start of its current run,
+// or, while it is waiting to be restarted again (e.g. in CrashLoopBackOff),
+// the end of its last one.
+func podRestarts(pod *corev1.Pod) (int32, *metav1.Time) {
+	var count int32
+	var last *metav1.Time
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		if cs.RestartCount == 0 {
+			continue
+		}
+		count += cs.RestartCount
+		var t metav1.Time
+		switch {
+		case cs.State.Running != nil:
+			t = cs.State.Running.StartedAt
+		case cs.LastTerminationState.Terminated != nil:
+			t = cs.LastTerminationState.Terminated.FinishedAt
+		}
+		if !t.IsZero() && (last == nil || last.Before(&t)) {
+			last = &t
+		}
+	}
+	return count, last
+}
+
 // resourceOwnership represents the ownership state of a Kubernetes resource relative to a Sandbox.
 type resourceOwnership int
 
@@ -132,24 +261,25 @@ func resolvePodName(sandbox *sandboxv1beta1.Sandbox) string {
 	return sandbox.Name
 }
 
-// MergeVolumeClaimVolumes merges PVC-backed volumes into an existing volume
-// list, replacing any volumes with matching names. This follows StatefulSet
-// semantics where volumeClaimTemplate volumes take priority.
-func MergeVolumeClaimVolumes(existing []corev1.Volume, pvcVolumes []corev1.Volume) []corev1.Volume {
-	if len(pvcVolumes) == 0 {
+// MergeGeneratedVolumes merges the volumes the controller generates for a
+// Sandbox's volumeClaimTemplates, configMapTemplates and secretTemplates into an
+// existing volume list, replacing any volumes with matching names. This follows
+// StatefulSet semantics where volumeClaimTemplate volumes take priority.
+func MergeGeneratedVolumes(existing []corev1.Volume, generated []corev1.Volume) []corev1.Volume {
+	if len(generated) == 0 {
 		return existing
 	}
-	vctNames := make(map[string]struct{}, len(pvcVolumes))
-	for _, v := range pvcVolumes {
-		vctNames[v.Name] = struct{}{}
+	generatedNames := make(map[string]struct{}, len(generated))
+	for _, v := range generated {
+		generatedNames[v.Name] = struct{}{}
 	}
 	filtered := make([]corev1.Volume, 0, len(existing))
 	for _, v := range existing {
-		if _, ok := vctNames[v.Name]; !ok {
+		if _, ok := generatedNames[v.Name]; !ok {
 			filtered = append(filtered, v)
 		}
 	}
-	return append(filtered, pvcVolumes...)
+	return append(filtered, generated...)
 }
 
 var (
@@ -168,17 +298,39 @@ type SandboxReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	Tracer        asmetrics.Instrumenter
+	Recorder      events.EventRecorder
 	ClusterDomain string
+	// PropagateLabels selects the Sandbox labels copied onto its Pod and Service.
+	PropagateLabels MetadataAllowlist
+	// PropagateAnnotations selects the Sandbox annotations copied onto its Pod and Service.
+	PropagateAnnotations MetadataAllowlist
+	// PodCreateLimiter, if set, bounds the number of pod Create calls in flight across
+	// all Sandbox reconciles. A reconcile that finds it full is requeued.
+	PodCreateLimiter *semaphore.Weighted
+	// DefaultResources holds the requests and limits filled into sandbox containers
+	// that leave them unset; see applyDefaultResources. Empty leaves containers as is.
+	DefaultResources corev1.ResourceRequirements
+	// HardenPods applies a restrictive security context to sandbox Pods wherever the
+	// pod template leaves it unset; see hardenPodSpec.
+	HardenPods bool
 }
 
+// errPodCreateThrottled is returned by reconcilePod when PodCreateLimiter is full.
+var errPodCreateThrottled = errors.New("too many concurrent pod creations, retrying later")
+
 //+kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes/finalizers,verbs=get;update;patch
 //+kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch
+// The metrics endpoint authenticates and authorizes callers when --metrics-secure is set.
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update;patch,resourceNames=sandboxes.agents.x-k8s.io;sandboxclaims.extensions.agents.x-k8s.io;sandboxtemplates.extensions.agents.x-k8s.io;sandboxwarmpools.extensions.agents.x-k8s.io
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -213,10 +365,20 @@ func (r *SandboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	ctx, end := r.Tracer.StartSpan(ctx, sandbox, "ReconcileSandbox", initialAttrs)
 	defer end()
 
-	// If the sandbox is being deleted, do nothing
 	if !sandbox.DeletionTimestamp.IsZero() {
-		logger.Info("Sandbox is being deleted")
-		return ctrl.Result{}, nil
+		if !controllerutil.ContainsFinalizer(sandbox, sandboxv1beta1.SandboxCleanupFinalizer) {
+			logger.Info("Sandbox is being deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.finalizeSandbox(ctx, sandbox)
+	}
+
+	if !controllerutil.ContainsFinalizer(sandbox, sandboxv1beta1.SandboxCleanupFinalizer) {
+		patch := client.MergeFromWithOptions(sandbox.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		controllerutil.AddFinalizer(sandbox, sandboxv1beta1.SandboxCleanupFinalizer)
+		if err := r.Patch(ctx, sandbox, patch); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	// Initialize trace ID for active resources missing an ID (inline, no re-reconcile)
@@ -234,6 +396,26 @@ func (r *SandboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	oldStatus := sandbox.Status.DeepCopy()
+
+	if sandbox.Spec.Paused {
+		logger.V(4).Info("Sandbox reconciliation is paused")
+		meta.SetStatusCondition(&sandbox.Status.Conditions, metav1.Condition{
+			Type:               string(sandboxv1beta1.SandboxConditionPaused),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: sandbox.Generation,
+			Reason:             sandboxv1beta1.SandboxReasonPaused,
+			Message:            "Reconciliation is paused",
+		})
+		return ctrl.Result{}, r.updateStatus(ctx, oldStatus, sandbox)
+	}
+
+	if sandbox.Annotations[sandboxv1beta1.SandboxDryRunAnnotation] == "true" {
+		logger.V(4).Info("Sandbox is in dry-run mode, validating child resources without creating them")
+		meta.SetStatusCondition(&sandbox.Status.Conditions, dryRunCondition(sandbox, r.dryRunChildResources(ctx, sandbox)))
+		meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPaused))
+		return ctrl.Result{}, r.updateStatus(ctx, oldStatus, sandbox)
+	}
+
 	var err error
 	sandboxDeleted := false
 	result := ctrl.Result{}
@@ -242,6 +424,8 @@ func (r *SandboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if expired {
 		if !sandboxMarkedExpired(sandbox) {
 			setSandboxExpiredCondition(sandbox)
+			r.recordExpiredEvent(sandbox)
+			meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPaused))
 			if statusUpdateErr := r.updateStatus(ctx, oldStatus, sandbox); statusUpdateErr != nil {
 				return ctrl.Result{}, statusUpdateErr
 			}
@@ -251,16 +435,55 @@ func (r *SandboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		logger.Info("Sandbox has expired, deleting child resources and checking shutdown policy")
 		sandboxDeleted, err = r.handleSandboxExpiry(ctx, sandbox)
 	} else {
+		reconcileStart := time.Now()
 		err = r.reconcileChildResources(ctx, sandbox)
-		expiredAfterReconcile, requeueAfter := checkSandboxExpiry(sandbox, time.Now())
+		asmetrics.RecordSandboxReconcileDuration(time.Since(reconcileStart))
+		throttled := errors.Is(err, errPodCreateThrottled)
+		if err != nil && !throttled && r.Recorder != nil {
+			r.Recorder.Eventf(sandbox, nil, corev1.EventTypeWarning, "ReconcileError", "Reconcile", "Failed to reconcile Sandbox: %v", err)
+		}
+		if throttled {
+			// Not a failure: the pod is created once a slot frees up, without
+			// growing the rate limiter's backoff for this Sandbox.
+			logger.V(4).Info("Pod creation throttled, retrying later")
+			err = nil
+		}
+		forbidden := k8serrors.IsForbidden(err)
+		if forbidden {
+			// Retrying with the rate limiter would hot-loop against the quota; the
+			// QuotaExceeded condition surfaces the error and the Sandbox is polled.
+			logger.Info("Creating Sandbox child resources was forbidden, retrying later", "error", err.Error(), "requeueAfter", forbiddenRequeueDelay)
+			err = nil
+		}
+		now := time.Now()
+		expiredAfterReconcile, requeueAfter := checkSandboxExpiry(sandbox, now)
 		result.RequeueAfter = requeueAfter
+		var refreshAfter time.Duration
+		sandbox.Status.ExpiresIn, refreshAfter = expiresIn(sandbox, now)
+		if refreshAfter > 0 && refreshAfter < result.RequeueAfter {
+			result.RequeueAfter = refreshAfter
+		}
+		if forbidden && (result.RequeueAfter == 0 || result.RequeueAfter > forbiddenRequeueDelay) {
+			result.RequeueAfter = forbiddenRequeueDelay
+		}
+		if throttled {
+			if delay := wait.Jitter(podCreateThrottledRequeueDelay, 1.0); result.RequeueAfter == 0 || result.RequeueAfter > delay {
+				result.RequeueAfter = delay
+			}
+		}
 		if expiredAfterReconcile {
+			sandbox.Status.ExpiresIn = ""
 			setSandboxExpiredCondition(sandbox)
+			r.recordExpiredEvent(sandbox)
 			result.RequeueAfter = immediateRequeueDelay
 		}
 	}
 
 	if !sandboxDeleted {
+		// Child reconciliation may re-read the sandbox, so clear a stale Paused
+		// condition only once the status is about to be written.
+		meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPaused))
+		meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionDryRunValidated))
 		// Update status
 		if statusUpdateErr := r.updateStatus(ctx, oldStatus, sandbox); statusUpdateErr != nil {
 			// Surface update error
@@ -273,47 +496,249 @@ func (r *SandboxReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 func (r *SandboxReconciler) reconcileChildResources(ctx context.Context, sandbox *sandboxv1beta1.Sandbox) error {
 	// Create a hash from the sandbox.Name and use it as label value
-	nameHash := NameHash(sandbox.Name)
+	nameHash := naming.NameHash(sandbox.Name)
+
+	// Two Sandboxes sharing a label value would share each other's Service
+	// endpoints, so only the one holding the hash gets child resources.
+	holder, err := r.nameHashHolder(ctx, sandbox, nameHash)
+	if err != nil {
+		return err
+	}
+	if holder != sandbox.Name {
+		log.FromContext(ctx).Info("Sandbox name hash collides with another Sandbox, not creating child resources",
+			"nameHash", nameHash, "holder", holder)
+		meta.SetStatusCondition(&sandbox.Status.Conditions, metav1.Condition{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: sandbox.Generation,
+			Reason:             sandboxv1beta1.SandboxReasonNameHashCollision,
+			Message:            fmt.Sprintf("Sandbox %q already uses the name hash %s; recreate this Sandbox under another name", holder, nameHash),
+		})
+		// Drop status left from before the collision, which would otherwise point
+		// at the holder's pod through the shared label selector.
+		sandbox.Status.LabelSelector = ""
+		sandbox.Status.PodName = ""
+		sandbox.Status.PodIPs = nil
+		sandbox.Status.NodeName = ""
+		sandbox.Status.RunningImage = ""
+		sandbox.Status.AppliedPodTemplateHash = ""
+		sandbox.Status.RestartCount = 0
+		sandbox.Status.LastRestartTime = nil
+		sandbox.Status.PodFQDN = ""
+		r.clearServiceStatus(sandbox)
+		return nil
+	}
 
 	var allErrors error
 
 	// Reconcile PVCs from volumeClaimTemplates
-	err := r.reconcilePVCs(ctx, sandbox, nameHash)
+	err = r.reconcilePVCs(ctx, sandbox, nameHash)
+	if err != nil {
+		asmetrics.RecordSandboxReconcileError(asmetrics.ReconcileErrorReasonPVC)
+	}
+	allErrors = errors.Join(allErrors, err)
+
+	// Reconcile ConfigMaps and Secrets from configMapTemplates and secretTemplates
+	err = r.reconcileConfigMaps(ctx, sandbox, nameHash)
+	if err != nil {
+		asmetrics.RecordSandboxReconcileError(asmetrics.ReconcileErrorReasonConfigMap)
+	}
+	allErrors = errors.Join(allErrors, err)
+	err = r.reconcileSecrets(ctx, sandbox, nameHash)
+	if err != nil {
+		asmetrics.RecordSandboxReconcileError(asmetrics.ReconcileErrorReasonSecret)
+	}
 	allErrors = errors.Join(allErrors, err)
 
 	// Reconcile Pod
 	pod, err := r.reconcilePod(ctx, sandbox, nameHash)
+	if err != nil && !errors.Is(err, errPodCreateThrottled) {
+		asmetrics.RecordSandboxReconcileError(asmetrics.ReconcileErrorReasonPod)
+	}
 	allErrors = errors.Join(allErrors, err)
 	if pod == nil {
+		sandbox.Status.PodName = ""
 		sandbox.Status.PodIPs = nil
 		sandbox.Status.NodeName = ""
+		sandbox.Status.RunningImage = ""
+		sandbox.Status.AppliedPodTemplateHash = ""
+		sandbox.Status.RestartCount = 0
+		sandbox.Status.LastRestartTime = nil
 	} else {
 		sandbox.Status.LabelSelector = sandboxLabel + "=" + nameHash
+		sandbox.Status.PodName = pod.Name
 		sandbox.Status.PodIPs = podIPsFromStatus(pod.Status.PodIPs)
 		sandbox.Status.NodeName = pod.Spec.NodeName
+		sandbox.Status.RunningImage = ""
+		if cs := primaryContainerStatus(sandbox, pod); cs != nil {
+			sandbox.Status.RunningImage = cs.ImageID
+		}
+		sandbox.Status.RestartCount, sandbox.Status.LastRestartTime = podRestarts(pod)
+		sandbox.Status.AppliedPodTemplateHash = appliedPodTemplateHash(sandbox, pod)
 	}
 
 	// Reconcile Service
 	svc, err := r.reconcileService(ctx, sandbox, nameHash)
+	if err != nil {
+		asmetrics.RecordSandboxReconcileError(asmetrics.ReconcileErrorReasonService)
+	}
 	allErrors = errors.Join(allErrors, err)
 
+	sandbox.Status.PodFQDN = ""
+	if pod != nil && svc != nil && pod.Spec.Hostname != "" && pod.Spec.Subdomain == svc.Name {
+		sandbox.Status.PodFQDN = pod.Spec.Hostname + "." + svc.Name + "." + svc.Namespace + ".svc." + r.ClusterDomain
+	}
+
 	// compute and set overall conditions
 	conditions := r.computeConditions(sandbox, allErrors, svc, pod)
-	hasFinished := false
+	hasFinished, hasPodDrifted := false, false
 	for _, condition := range conditions {
 		meta.SetStatusCondition(&sandbox.Status.Conditions, condition)
-		if condition.Type == string(sandboxv1beta1.SandboxConditionFinished) {
+		switch condition.Type {
+		case string(sandboxv1beta1.SandboxConditionFinished):
 			hasFinished = true
+		case string(sandboxv1beta1.SandboxConditionPodDrifted):
+			hasPodDrifted = true
 		}
 	}
 
 	if !hasFinished {
 		meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionFinished))
 	}
+	if !hasPodDrifted {
+		meta.RemoveStatusCondition(&sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted))
+	}
+
+	sandbox.Status.ObservedGeneration = sandbox.Generation
+	return allErrors
+}
+
+// nameHashHolder returns the name of the Sandbox in the namespace that owns
+// nameHash: the oldest of those whose names hash to it, ties broken by name.
+// Collisions are rare with 32 bits but the hash is persisted in labels on live
+// objects, so it cannot be widened without orphaning them.
+func (r *SandboxReconciler) nameHashHolder(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, nameHash string) (string, error) {
+	sandboxes := &sandboxv1beta1.SandboxList{}
+	if err := r.List(ctx, sandboxes, client.InNamespace(sandbox.Namespace), client.MatchingFields{sandboxNameHashIndex: nameHash}); err != nil {
+		return "", fmt.Errorf("failed to list sandboxes by name hash: %w", err)
+	}
+	holder := sandbox
+	for i := range sandboxes.Items {
+		other := &sandboxes.Items[i]
+		if other.CreationTimestamp.Before(&holder.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&holder.CreationTimestamp) && other.Name < holder.Name) {
+			holder = other
+		}
+	}
+	return holder.Name, nil
+}
+
+// findSandboxesSharingNameHash returns a reconcile.Request for every other
+// Sandbox whose name hashes to the same value as the deleted one, so a Sandbox
+// blocked by a name hash collision picks up the hash once its holder is gone.
+func (r *SandboxReconciler) findSandboxesSharingNameHash(ctx context.Context, obj client.Object) []reconcile.Request {
+	sandboxes := &sandboxv1beta1.SandboxList{}
+	if err := r.List(ctx, sandboxes, client.InNamespace(obj.GetNamespace()), client.MatchingFields{sandboxNameHashIndex: naming.NameHash(obj.GetName())}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list sandboxes sharing a name hash", "sandbox", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, sb := range sandboxes.Items {
+		if sb.Name == obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: sb.Name, Namespace: sb.Namespace}})
+	}
+	return requests
+}
+
+// dryRunChildResources validates the child resources reconcileChildResources would
+// create for the Sandbox by sending them to the API server as dry-run requests, so
+// nothing is persisted. Resources that do not exist yet are dry-run created; an
+// existing Service, ConfigMap or Secret, which the controller updates in place, is
+// dry-run updated. Existing
+// Pods and PVCs are left alone, since the controller never updates their specs.
+func (r *SandboxReconciler) dryRunChildResources(ctx context.Context, sandbox *sandboxv1beta1.Sandbox) error {
+	nameHash := naming.NameHash(sandbox.Name)
+	type dryRunObject struct {
+		kind string
+		obj  client.Object
+		// updatable marks kinds the controller updates in place when they exist.
+		updatable bool
+	}
+	var objs []dryRunObject
+	if sandbox.Spec.OperatingMode != sandboxv1beta1.SandboxOperatingModeSuspended {
+		for _, pvcTemplate := range sandbox.Spec.VolumeClaimTemplates {
+			pvc, err := r.buildPVC(sandbox, pvcTemplate, nameHash)
+			if err != nil {
+				return err
+			}
+			objs = append(objs, dryRunObject{kind: "PersistentVolumeClaim", obj: pvc})
+		}
+		for _, cmTemplate := range sandbox.Spec.ConfigMapTemplates {
+			cm, err := r.buildConfigMap(sandbox, cmTemplate, nameHash)
+			if err != nil {
+				return err
+			}
+			objs = append(objs, dryRunObject{kind: "ConfigMap", obj: cm, updatable: true})
+		}
+		for _, secretTemplate := range sandbox.Spec.SecretTemplates {
+			secret, err := r.buildSecret(sandbox, secretTemplate, nameHash)
+			if err != nil {
+				return err
+			}
+			objs = append(objs, dryRunObject{kind: "Secret", obj: secret, updatable: true})
+		}
+		pod, err := r.buildPod(ctx, sandbox, nameHash)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, dryRunObject{kind: "Pod", obj: pod})
+	}
+	if sandbox.Spec.Service != nil && *sandbox.Spec.Service {
+		service, err := r.buildService(sandbox, nameHash)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, dryRunObject{kind: "Service", obj: service, updatable: true})
+	}
 
+	var allErrors error
+	for _, o := range objs {
+		existing := o.obj.DeepCopyObject().(client.Object)
+		err := r.Get(ctx, client.ObjectKeyFromObject(o.obj), existing)
+		switch {
+		case k8serrors.IsNotFound(err):
+			err = r.Create(ctx, o.obj, client.DryRunAll, client.FieldOwner(sandboxControllerFieldOwner))
+		case err == nil && o.updatable:
+			o.obj.SetResourceVersion(existing.GetResourceVersion())
+			err = r.Update(ctx, o.obj, client.DryRunAll, client.FieldOwner(sandboxControllerFieldOwner))
+		}
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("%s %q: %w", o.kind, o.obj.GetName(), err))
+		}
+	}
 	return allErrors
 }
 
+// dryRunCondition returns the DryRunValidated condition for the outcome of
+// dryRunChildResources.
+func dryRunCondition(sandbox *sandboxv1beta1.Sandbox, err error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(sandboxv1beta1.SandboxConditionDryRunValidated),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: sandbox.Generation,
+		Reason:             sandboxv1beta1.SandboxReasonDryRunSucceeded,
+		Message:            "Child resources passed server-side dry run",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = sandboxv1beta1.SandboxReasonDryRunFailed
+		condition.Message = "Server-side dry run failed: " + err.Error()
+	}
+	return condition
+}
+
 func (r *SandboxReconciler) computeConditions(sandbox *sandboxv1beta1.Sandbox, err error, svc *corev1.Service, pod *corev1.Pod) []metav1.Condition {
 	var conditions []metav1.Condition
 
@@ -325,6 +750,10 @@ func (r *SandboxReconciler) computeConditions(sandbox *sandboxv1beta1.Sandbox, e
 		conditions = append(conditions, *finished)
 	}
 
+	if drifted := computePodDriftedCondition(sandbox, pod); drifted != nil {
+		conditions = append(conditions, *drifted)
+	}
+
 	conditions = append(conditions, r.computeReadyCondition(sandbox, err, svc, pod))
 
 	return conditions
@@ -365,6 +794,12 @@ func (r *SandboxReconciler) computeReadyCondition(sandbox *sandboxv1beta1.Sandbo
 
 	if err != nil {
 		readyCondition.Reason = "ReconcilerError"
+		if k8serrors.IsForbidden(err) {
+			readyCondition.Reason = sandboxv1beta1.SandboxReasonQuotaExceeded
+		}
+		if errors.Is(err, errPodCreateThrottled) {
+			readyCondition.Reason = sandboxv1beta1.SandboxReasonPodCreateThrottled
+		}
 		readyCondition.Message = "Error seen: " + err.Error()
 		return readyCondition
 	}
@@ -380,6 +815,19 @@ func (r *SandboxReconciler) computeReadyCondition(sandbox *sandboxv1beta1.Sandbo
 		return readyCondition
 	}
 
+	if pod != nil && podNeedsRecreation(sandbox, pod) {
+		readyCondition.Reason = sandboxv1beta1.SandboxReasonPodRecreating
+		readyCondition.Message = "Pod is being recreated to apply pod template changes"
+		return readyCondition
+	}
+
+	if pod != nil && podRecreateLimitExceeded(sandbox, pod) {
+		restarts, _ := podRestartsInWindow(sandbox, pod)
+		readyCondition.Reason = sandboxv1beta1.SandboxReasonRecreateLimitExceeded
+		readyCondition.Message = fmt.Sprintf("Pod restarted %d times after %d recreations; not recreating it again", restarts, podRecreateAttempts(sandbox))
+		return readyCondition
+	}
+
 	if pod != nil {
 		switch pod.Status.Phase {
 		case corev1.PodSucceeded:
@@ -395,16 +843,28 @@ func (r *SandboxReconciler) computeReadyCondition(sandbox *sandboxv1beta1.Sandbo
 
 	message := ""
 	podReady := false
+	podStarting := false
+	podUnschedulable := false
 	if pod != nil {
 		message = "Pod exists with phase: " + string(pod.Status.Phase)
+		if cond, ok := podUnschedulableCondition(pod); ok {
+			message = fmt.Sprintf("Pod is unschedulable (%s): %s", cond.Reason, cond.Message)
+			podUnschedulable = true
+		}
 		// Check if pod Ready condition is true
 		if pod.Status.Phase == corev1.PodRunning {
 			message = "Pod is Running but not Ready"
+			if name, ok := startingContainer(pod); ok {
+				message = fmt.Sprintf("Pod is Running but container %q has not passed its startup probe yet", name)
+				podStarting = true
+			}
 			for _, condition := range pod.Status.Conditions {
 				if condition.Type == corev1.PodReady {
 					if condition.Status == corev1.ConditionTrue {
 						if len(pod.Status.PodIPs) == 0 {
 							message = "Pod is Ready but has no podIPs yet"
+						} else if msg, ok := primaryContainerReady(sandbox, pod); !ok {
+							message = msg
 						} else {
 							message = "Pod is Ready"
 							podReady = true
@@ -440,6 +900,12 @@ func (r *SandboxReconciler) computeReadyCondition(sandbox *sandboxv1beta1.Sandbo
 	}
 
 	readyCondition.Message = message
+	if podStarting {
+		readyCondition.Reason = sandboxv1beta1.SandboxReasonStarting
+	}
+	if podUnschedulable {
+		readyCondition.Reason = sandboxv1beta1.SandboxReasonUnschedulable
+	}
 	if podReady && svcReady {
 		readyCondition.Status = metav1.ConditionTrue
 		readyCondition.Reason = sandboxv1beta1.SandboxReasonDependenciesReady
@@ -448,6 +914,31 @@ func (r *SandboxReconciler) computeReadyCondition(sandbox *sandboxv1beta1.Sandbo
 	return readyCondition
 }
 
+// podUnschedulableCondition returns the PodScheduled condition of a Pending pod
+// the scheduler could not place.
+func podUnschedulableCondition(pod *corev1.Pod) (corev1.PodCondition, bool) {
+	if pod.Status.Phase != corev1.PodPending {
+		return corev1.PodCondition{}, false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled {
+			return cond, cond.Status == corev1.ConditionFalse
+		}
+	}
+	return corev1.PodCondition{}, false
+}
+
+// startingContainer returns the name of the first container of pod that is
+// running but not yet started, i.e. whose startup probe has not succeeded.
+func startingContainer(pod *corev1.Pod) (string, bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Running != nil && cs.Started != nil && !*cs.Started {
+			return cs.Name, true
+		}
+	}
+	return "", false
+}
+
 func (r *SandboxReconciler) computeFinishedCondition(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) *metav1.Condition {
 	if pod == nil {
 		return nil
@@ -526,28 +1017,17 @@ func nodeNameOnlyChange(oldStatus, newStatus *sandboxv1beta1.SandboxStatus) bool
 	return apiequality.Semantic.DeepEqual(oldStatus, scratch)
 }
 
-// GetNumericHash generates a raw FNV-1a hash value.
+// GetNumericHash generates a raw FNV-1a hash value. It is kept for importers of
+// this package; see naming.NumericHash.
 func GetNumericHash(input string) uint32 {
-	h := fnv.New32a()
-	h.Write([]byte(input))
-	return h.Sum32()
+	return naming.NumericHash(input)
 }
 
 // NameHash generates an FNV-1a hash from a string and returns
-// it as a fixed-length hexadecimal string.
+// it as a fixed-length hexadecimal string. It is kept for importers of
+// this package; see naming.NameHash.
 func NameHash(objectName string) string {
-	h := GetNumericHash(objectName)
-	const hex = "0123456789abcdef"
-	var buf [8]byte
-	buf[0] = hex[(h>>28)&0xf]
-	buf[1] = hex[(h>>24)&0xf]
-	buf[2] = hex[(h>>20)&0xf]
-	buf[3] = hex[(h>>16)&0xf]
-	buf[4] = hex[(h>>12)&0xf]
-	buf[5] = hex[(h>>8)&0xf]
-	buf[6] = hex[(h>>4)&0xf]
-	buf[7] = hex[h&0xf]
-	return string(buf[:])
+	return naming.NameHash(objectName)
 }
 
 // hasSystemReservedPrefix reports whether a key uses a label/annotation prefix
@@ -565,11 +1045,139 @@ func isSystemLabel(key string) bool {
 	return hasSystemReservedPrefix(key)
 }
 
+// MetadataAllowlist selects the labels or annotations of a Sandbox object that are
+// copied onto its Pod and Service. An entry matches a key exactly or, when it ends in
+// "*", every key starting with the rest of the entry. An empty allowlist matches nothing.
+type MetadataAllowlist []string
+
+// ParseMetadataAllowlist parses a comma-separated list of keys and "prefix*" entries,
+// as taken by the --propagate-labels and --propagate-annotations flags.
+func ParseMetadataAllowlist(s string) MetadataAllowlist {
+	var allowlist MetadataAllowlist
+	for entry := range strings.SplitSeq(s, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			allowlist = append(allowlist, entry)
+		}
+	}
+	return allowlist
+}
+
+// Allows reports whether key matches an entry of the allowlist.
+func (a MetadataAllowlist) Allows(key string) bool {
+	for _, entry := range a {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// selectMetadata returns the entries of metadata allowed by allowlist. System-reserved
+// keys are never selected, whatever the allowlist says.
+func selectMetadata(metadata map[string]string, allowlist MetadataAllowlist, isSystem func(string) bool) map[string]string {
+	if len(allowlist) == 0 {
+		return nil
+	}
+	var selected map[string]string
+	for k, v := range metadata {
+		if isSystem(k) || !allowlist.Allows(k) {
+			continue
+		}
+		if selected == nil {
+			selected = make(map[string]string)
+		}
+		selected[k] = v
+	}
+	return selected
+}
+
+// overlayMetadata returns base overlaid with overrides, reusing overrides when base is empty.
+func overlayMetadata(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 {
+		return overrides
+	}
+	maps.Copy(base, overrides)
+	return base
+}
+
+// podLabels returns the user labels the Sandbox's Pod should carry: the Sandbox labels
+// selected by PropagateLabels, overridden by the PodTemplate labels.
+func (r *SandboxReconciler) podLabels(sandbox *sandboxv1beta1.Sandbox) map[string]string {
+	return overlayMetadata(selectMetadata(sandbox.Labels, r.PropagateLabels, isSystemLabel),
+		sandbox.Spec.PodTemplate.ObjectMeta.Labels)
+}
+
+// podAnnotations is the annotation counterpart of podLabels.
+func (r *SandboxReconciler) podAnnotations(sandbox *sandboxv1beta1.Sandbox) map[string]string {
+	return overlayMetadata(selectMetadata(sandbox.Annotations, r.PropagateAnnotations, isSystemAnnotation),
+		sandbox.Spec.PodTemplate.ObjectMeta.Annotations)
+}
+
+// syncServiceMetadata copies the Sandbox labels and annotations selected by
+// PropagateLabels and PropagateAnnotations onto the Service, and removes those it
+// propagated earlier that are no longer selected. It reports whether the Service changed.
+func (r *SandboxReconciler) syncServiceMetadata(service *corev1.Service, sandbox *sandboxv1beta1.Sandbox) bool {
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	if service.Annotations == nil {
+		service.Annotations = make(map[string]string)
+	}
+	labelsChanged := syncPropagatedMetadata(service.Labels, service.Annotations,
+		sandboxv1beta1.SandboxPropagatedLabelsAnnotation,
+		selectMetadata(sandbox.Labels, r.PropagateLabels, isSystemLabel), isSystemLabel)
+	annotationsChanged := syncPropagatedMetadata(service.Annotations, service.Annotations,
+		sandboxv1beta1.SandboxPropagatedAnnotationsAnnotation,
+		selectMetadata(sandbox.Annotations, r.PropagateAnnotations, isSystemAnnotation), isSystemAnnotation)
+	return labelsChanged || annotationsChanged
+}
+
+// syncPropagatedMetadata sets desired on metadata, deletes the keys recorded under
+// trackingKey in tracking that desired no longer holds, and records the keys of
+// desired there. The record is dropped once nothing is propagated.
+func syncPropagatedMetadata(metadata, tracking map[string]string, trackingKey string, desired map[string]string, isSystem func(string) bool) bool {
+	changed := false
+	for k, v := range desired {
+		if metadata[k] != v {
+			metadata[k] = v
+			changed = true
+		}
+	}
+	for k := range strings.SplitSeq(tracking[trackingKey], ",") {
+		if k == "" || isSystem(k) {
+			continue
+		}
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, exists := metadata[k]; exists {
+			delete(metadata, k)
+			changed = true
+		}
+	}
+	keys := strings.Join(slices.Sorted(maps.Keys(desired)), ",")
+	if keys == "" {
+		if _, exists := tracking[trackingKey]; exists {
+			delete(tracking, trackingKey)
+			changed = true
+		}
+	} else if tracking[trackingKey] != keys {
+		tracking[trackingKey] = keys
+		changed = true
+	}
+	return changed
+}
+
 // extensionPodLabelKeys must stay in sync with computeExtensionPodLabels so reconcile
 // removes stale extension labels when they are no longer expected on the Pod.
 var extensionPodLabelKeys = []string{
 	sandboxv1beta1.SandboxWarmPoolLabel,
 	sandboxv1beta1.SandboxTemplateRefHashLabel,
+	sandboxv1beta1.SandboxTemplateRevisionLabel,
 }
 
 // computeExtensionPodLabels returns extension-owned labels that should be propagated
@@ -601,6 +1209,12 @@ func computeExtensionPodLabels(sandbox *sandboxv1beta1.Sandbox) map[string]strin
 		}
 		labels[sandboxv1beta1.SandboxTemplateRefHashLabel] = val
 	}
+	if val, ok := sandbox.Labels[sandboxv1beta1.SandboxTemplateRevisionLabel]; ok && val != "" {
+		if labels == nil {
+			labels = make(map[string]string, 1)
+		}
+		labels[sandboxv1beta1.SandboxTemplateRevisionLabel] = val
+	}
 	return labels
 }
 
@@ -617,7 +1231,8 @@ func isSystemAnnotation(key string) bool {
 func isControllerManagedPodAnnotation(key string) bool {
 	switch key {
 	case sandboxv1beta1.SandboxPropagatedLabelsAnnotation,
-		sandboxv1beta1.SandboxPropagatedAnnotationsAnnotation:
+		sandboxv1beta1.SandboxPropagatedAnnotationsAnnotation,
+		sandboxv1beta1.SandboxPodSpecHashAnnotation:
 		return true
 	default:
 		return false
@@ -727,40 +1342,27 @@ func (r *SandboxReconciler) reconcileService(ctx context.Context, sandbox *sandb
 	desiredPorts := servicePortsForSandbox(sandbox)
 
 	service := &corev1.Service{}
-	if err := r.Get(ctx, types.NamespacedName{Name: sandbox.Name, Namespace: sandbox.Namespace}, service); err != nil {
+	if err := r.Get(ctx, types.NamespacedName{Name: sandboxServiceName(sandbox), Namespace: sandbox.Namespace}, service); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			logger.Error(err, "Failed to get Service")
 			return nil, fmt.Errorf("service get failed: %w", err)
 		}
 		// Service does not exist, and desired is true — create service
 		if desired != nil && *desired {
-			logger.Info("Creating a new Headless Service", "Service.Namespace", sandbox.Namespace, "Service.Name", sandbox.Name)
-			service = &corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      sandbox.Name,
-					Namespace: sandbox.Namespace,
-					Labels: map[string]string{
-						sandboxLabel: nameHash,
-					},
-				},
-				Spec: corev1.ServiceSpec{
-					ClusterIP: "None",
-					Selector: map[string]string{
-						sandboxLabel: nameHash,
-					},
-					Ports: desiredPorts,
-				},
-			}
-			service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
-			if err := ctrl.SetControllerReference(sandbox, service, r.Scheme); err != nil {
+			logger.Info("Creating a new Headless Service", "Service.Namespace", sandbox.Namespace, "Service.Name", sandboxServiceName(sandbox))
+			service, err := r.buildService(sandbox, nameHash)
+			if err != nil {
 				logger.Error(err, "Failed to set controller reference")
-				return nil, fmt.Errorf("SetControllerReference for Service failed: %w", err)
+				return nil, err
 			}
-			err := r.Create(ctx, service, client.FieldOwner(sandboxControllerFieldOwner))
+			err = r.Create(ctx, service, client.FieldOwner(sandboxControllerFieldOwner))
 			if err != nil {
 				logger.Error(err, "Failed to create", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
 				return nil, err
 			}
+			if r.Recorder != nil {
+				r.Recorder.Eventf(sandbox, service, corev1.EventTypeNormal, "ServiceCreated", "Create", "Created Service %q", service.Name)
+			}
 			r.setServiceStatus(sandbox, service)
 			return service, nil
 		}
@@ -822,9 +1424,7 @@ func (r *SandboxReconciler) reconcileService(ctx context.Context, sandbox *sandb
 
 		logger.Info("Adopting unowned service", "Service.Name", service.Name, "Sandbox.Name", sandbox.Name)
 
-		if service.Labels == nil {
-			service.Labels = make(map[string]string)
-		}
+		r.syncServiceMetadata(service, sandbox)
 		service.Labels[sandboxLabel] = nameHash
 		service.Spec.Selector = map[string]string{
 			sandboxLabel: nameHash,
@@ -843,11 +1443,7 @@ func (r *SandboxReconciler) reconcileService(ctx context.Context, sandbox *sandb
 			sandboxLabel: nameHash,
 		}
 		patch := client.MergeFrom(service.DeepCopy())
-		needsUpdate := false
-
-		if service.Labels == nil {
-			service.Labels = make(map[string]string)
-		}
+		needsUpdate := r.syncServiceMetadata(service, sandbox)
 		if service.Labels[sandboxLabel] != nameHash {
 			service.Labels[sandboxLabel] = nameHash
 			needsUpdate = true
@@ -873,6 +1469,33 @@ func (r *SandboxReconciler) reconcileService(ctx context.Context, sandbox *sandb
 	return service, nil
 }
 
+// buildService returns the headless Service the Sandbox should have, as
+// reconcileService creates it.
+func (r *SandboxReconciler) buildService(sandbox *sandboxv1beta1.Sandbox, nameHash string) (*corev1.Service, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sandboxServiceName(sandbox),
+			Namespace: sandbox.Namespace,
+			Labels: map[string]string{
+				sandboxLabel: nameHash,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Selector: map[string]string{
+				sandboxLabel: nameHash,
+			},
+			Ports: servicePortsForSandbox(sandbox),
+		},
+	}
+	r.syncServiceMetadata(service, sandbox)
+	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+	if err := ctrl.SetControllerReference(sandbox, service, r.Scheme); err != nil {
+		return nil, fmt.Errorf("SetControllerReference for Service failed: %w", err)
+	}
+	return service, nil
+}
+
 func servicePortsEqual(a, b []corev1.ServicePort) bool {
 	if len(a) != len(b) {
 		return false
@@ -903,6 +1526,15 @@ func (r *SandboxReconciler) clearPodNameAnnotation(ctx context.Context, sandbox
 	return nil
 }
 
+// sandboxServiceName returns the name of the Sandbox's headless Service:
+// spec.serviceName when set, the Sandbox name otherwise.
+func sandboxServiceName(sandbox *sandboxv1beta1.Sandbox) string {
+	if sandbox.Spec.ServiceName != "" {
+		return sandbox.Spec.ServiceName
+	}
+	return sandbox.Name
+}
+
 // setServiceStatus updates the sandbox status with the service name and FQDN.
 func (r *SandboxReconciler) setServiceStatus(sandbox *sandboxv1beta1.Sandbox, service *corev1.Service) {
 	sandbox.Status.Service = service.Name
@@ -1062,7 +1694,26 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 			needsUpdate = true
 
 		case resourceOwnedBySandbox:
-			// No additional action needed — label applied below.
+			if podNeedsRecreation(sandbox, pod) {
+				if pod.DeletionTimestamp.IsZero() {
+					logger.Info("Deleting Pod to apply pod template changes", "Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
+					if err := r.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !k8serrors.IsNotFound(err) {
+						return nil, fmt.Errorf("failed to delete outdated pod: %w", err)
+					}
+				} else {
+					logger.V(4).Info("Outdated Pod is already being deleted", "Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
+				}
+				return pod, nil
+			}
+			if pod.DeletionTimestamp.IsZero() {
+				recreated, err := r.reconcileRestartPolicy(ctx, sandbox, pod)
+				if err != nil {
+					return nil, err
+				}
+				if recreated {
+					return pod, nil
+				}
+			}
 		}
 
 		metadataUpdated := r.updatePodMetadata(ctx, pod, sandbox, nameHash)
@@ -1076,8 +1727,6 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 			return nil, err
 		}
 
-		// TODO - Do we enforce (change) spec if a pod exists ?
-		// r.Patch(ctx, pod, client.Apply, client.ForceOwnership, client.FieldOwner("sandbox-controller"))
 		return pod, nil
 	}
 
@@ -1088,34 +1737,90 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 
 	// Create new Pod
 	logger.Info("Creating a new Pod", "Pod.Namespace", sandbox.Namespace, "Pod.Name", sandbox.Name)
-	podLabels := make(map[string]string, len(sandbox.Spec.PodTemplate.ObjectMeta.Labels)+1)
-
-	var managedLabelKeys []string
-	for k, v := range sandbox.Spec.PodTemplate.ObjectMeta.Labels {
-		// Never let a user-supplied template set system-reserved labels.
-		if isSystemLabel(k) {
-			logger.V(1).Info("Ignoring system-reserved label in Sandbox PodTemplate", "key", k)
-			continue
+	pod, err = r.buildPod(ctx, sandbox, nameHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.createPod(ctx, pod); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			logger.Info("Pod already exists, fetching existing pod",
+				"Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
+			existingPod := &corev1.Pod{}
+			if getErr := r.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, existingPod); getErr != nil {
+				return nil, fmt.Errorf("pod already exists but failed to fetch: %w", getErr)
+			}
+			return reconcileExistingPod(existingPod)
 		}
-		podLabels[k] = v
-		managedLabelKeys = append(managedLabelKeys, k)
+		if errors.Is(err, errPodCreateThrottled) {
+			return nil, err
+		}
+		logger.Error(err, "Failed to create", "Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
+		return nil, err
 	}
-	// Assign system-owned labels after merging user input so they cannot be overridden.
-	podLabels[sandboxLabel] = nameHash
 
-	// Propagate extension-owned labels from the Sandbox CR to the Pod, provided the Sandbox is
-	// owned by an extensions controller (SandboxClaim or SandboxWarmPool).
-	maps.Copy(podLabels, computeExtensionPodLabels(sandbox))
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sandbox, pod, corev1.EventTypeNormal, "PodCreated", "Create", "Created Pod %q", pod.Name)
+	}
 
-	// Propagate the created-by label from the Sandbox CR labels to the Pod if present,
-	// normalizing it to a known allow-list to prevent invalid values or high cardinality.
-	if val, ok := sandbox.Labels[sandboxv1beta1.CreatedByLabel]; ok && val != "" {
-		podLabels[sandboxv1beta1.CreatedByLabel] = asmetrics.NormalizeCreatedBy(val)
+	if err := ensurePodNameAnnotation(pod.Name); err != nil {
+		return nil, err
 	}
 
-	annotations := map[string]string{}
-	var managedAnnotationKeys []string
-	for k, v := range sandbox.Spec.PodTemplate.ObjectMeta.Annotations {
+	if r.Tracer.IsRecording(ctx) {
+		r.Tracer.AddEvent(ctx, "NewPodStatusObserved", map[string]string{
+			"pod.Name":  pod.Name,
+			"pod.Phase": string(pod.Status.Phase),
+		})
+	}
+
+	return pod, nil
+}
+
+// createPod creates pod, holding a PodCreateLimiter slot for the duration of the
+// call. It returns errPodCreateThrottled without calling the API server when no
+// slot is free.
+func (r *SandboxReconciler) createPod(ctx context.Context, pod *corev1.Pod) error {
+	if r.PodCreateLimiter != nil {
+		if !r.PodCreateLimiter.TryAcquire(1) {
+			return errPodCreateThrottled
+		}
+		defer r.PodCreateLimiter.Release(1)
+	}
+	return r.Create(ctx, pod, client.FieldOwner(sandboxControllerFieldOwner))
+}
+
+// buildPod returns the Pod the Sandbox should run, as reconcilePod creates it.
+func (r *SandboxReconciler) buildPod(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, nameHash string) (*corev1.Pod, error) {
+	logger := log.FromContext(ctx)
+	templateLabels := r.podLabels(sandbox)
+	podLabels := make(map[string]string, len(templateLabels)+1)
+
+	var managedLabelKeys []string
+	for k, v := range templateLabels {
+		// Never let a user-supplied template set system-reserved labels.
+		if isSystemLabel(k) {
+			logger.V(1).Info("Ignoring system-reserved label in Sandbox PodTemplate", "key", k)
+			continue
+		}
+		podLabels[k] = v
+		managedLabelKeys = append(managedLabelKeys, k)
+	}
+	// Assign system-owned labels after merging user input so they cannot be overridden.
+	podLabels[sandboxLabel] = nameHash
+
+	// Propagate extension-owned labels from the Sandbox CR to the Pod, provided the Sandbox is
+	// owned by an extensions controller (SandboxClaim or SandboxWarmPool).
+	maps.Copy(podLabels, computeExtensionPodLabels(sandbox))
+
+	// Propagate the created-by label from the Sandbox CR labels to the Pod if present,
+	// normalizing it to a known allow-list to prevent invalid values or high cardinality.
+	if val, ok := sandbox.Labels[sandboxv1beta1.CreatedByLabel]; ok && val != "" {
+		podLabels[sandboxv1beta1.CreatedByLabel] = asmetrics.NormalizeCreatedBy(val)
+	}
+
+	annotations := map[string]string{}
+	var managedAnnotationKeys []string
+	for k, v := range r.podAnnotations(sandbox) {
 		// Never let a user-supplied template set system-reserved annotations.
 		if isSystemAnnotation(k) {
 			logger.V(1).Info("Ignoring system-reserved annotation in Sandbox PodTemplate", "key", k)
@@ -1132,14 +1837,19 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 	if len(managedAnnotationKeys) > 0 {
 		annotations[sandboxv1beta1.SandboxPropagatedAnnotationsAnnotation] = strings.Join(managedAnnotationKeys, ",")
 	}
+	podSpecHash, err := computePodSpecHash(&sandbox.Spec.PodTemplate)
+	if err != nil {
+		return nil, err
+	}
+	annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation] = podSpecHash
 
 	mutatedSpec := sandbox.Spec.PodTemplate.Spec.DeepCopy()
 
-	// Build PVC volumes from volumeClaimTemplates
-	var pvcVolumes []corev1.Volume
+	// Build PVC, ConfigMap and Secret volumes from the Sandbox's templates
+	var generatedVolumes []corev1.Volume
 	for _, pvcTemplate := range sandbox.Spec.VolumeClaimTemplates {
 		pvcName := pvcTemplate.Name + "-" + sandbox.Name
-		pvcVolumes = append(pvcVolumes, corev1.Volume{
+		generatedVolumes = append(generatedVolumes, corev1.Volume{
 			Name: pvcTemplate.Name,
 			VolumeSource: corev1.VolumeSource{
 				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
@@ -1148,8 +1858,43 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 			},
 		})
 	}
-	mutatedSpec.Volumes = MergeVolumeClaimVolumes(mutatedSpec.Volumes, pvcVolumes)
-	pod = &corev1.Pod{
+	for _, cmTemplate := range sandbox.Spec.ConfigMapTemplates {
+		generatedVolumes = append(generatedVolumes, corev1.Volume{
+			Name: cmTemplate.Name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: sandboxConfigObjectName(sandbox, cmTemplate.Name)},
+				},
+			},
+		})
+	}
+	for _, secretTemplate := range sandbox.Spec.SecretTemplates {
+		generatedVolumes = append(generatedVolumes, corev1.Volume{
+			Name: secretTemplate.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: sandboxConfigObjectName(sandbox, secretTemplate.Name),
+				},
+			},
+		})
+	}
+	mutatedSpec.Volumes = MergeGeneratedVolumes(mutatedSpec.Volumes, generatedVolumes)
+	// Give the pod a stable DNS name, <hostname>.<service>.<namespace>.svc, through
+	// the Sandbox's headless Service, unless the pod template chose its own.
+	if mutatedSpec.Hostname == "" && len(validation.IsDNS1123Label(sandbox.Name)) == 0 {
+		mutatedSpec.Hostname = sandbox.Name
+	}
+	if mutatedSpec.Subdomain == "" {
+		mutatedSpec.Subdomain = sandboxServiceName(sandbox)
+	}
+	if sandbox.Spec.InjectEnv {
+		injectSandboxEnv(mutatedSpec, r.sandboxEnv(sandbox))
+	}
+	applyDefaultResources(mutatedSpec, r.DefaultResources)
+	if r.HardenPods {
+		hardenPodSpec(mutatedSpec)
+	}
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        sandbox.Name,
 			Namespace:   sandbox.Namespace,
@@ -1162,32 +1907,398 @@ func (r *SandboxReconciler) reconcilePod(ctx context.Context, sandbox *sandboxv1
 	if err := ctrl.SetControllerReference(sandbox, pod, r.Scheme); err != nil {
 		return nil, fmt.Errorf("SetControllerReference for Pod failed: %w", err)
 	}
-	if err := r.Create(ctx, pod, client.FieldOwner(sandboxControllerFieldOwner)); err != nil {
-		if k8serrors.IsAlreadyExists(err) {
-			logger.Info("Pod already exists, fetching existing pod",
-				"Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
-			existingPod := &corev1.Pod{}
-			if getErr := r.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, existingPod); getErr != nil {
-				return nil, fmt.Errorf("pod already exists but failed to fetch: %w", getErr)
+	return pod, nil
+}
+
+// sandboxEnv returns the environment variables injected into the Sandbox's
+// containers when spec.injectEnv is set.
+func (r *SandboxReconciler) sandboxEnv(sandbox *sandboxv1beta1.Sandbox) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: sandboxNameEnvVar, Value: sandbox.Name},
+		{Name: sandboxNamespaceEnvVar, Value: sandbox.Namespace},
+		{Name: sandboxFQDNEnvVar, Value: sandboxServiceName(sandbox) + "." + sandbox.Namespace + ".svc." + r.ClusterDomain},
+	}
+}
+
+// injectSandboxEnv adds env to every init and regular container in spec that
+// does not already define a variable of the same name.
+func injectSandboxEnv(spec *corev1.PodSpec, env []corev1.EnvVar) {
+	inject := func(containers []corev1.Container) {
+		for i := range containers {
+			container := &containers[i]
+			for _, e := range env {
+				if !slices.ContainsFunc(container.Env, func(existing corev1.EnvVar) bool { return existing.Name == e.Name }) {
+					container.Env = append(container.Env, e)
+				}
 			}
-			return reconcileExistingPod(existingPod)
 		}
-		logger.Error(err, "Failed to create", "Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
-		return nil, err
 	}
+	inject(spec.InitContainers)
+	inject(spec.Containers)
+}
 
-	if err := ensurePodNameAnnotation(pod.Name); err != nil {
-		return nil, err
+// applyDefaultResources fills defaults into every init and regular container in
+// spec, one resource at a time and only where the container leaves a gap:
+//   - a request is added when the container sets neither a request nor a limit
+//     for the resource, since a limit alone already implies an equal request;
+//   - a limit is added when the container sets none for the resource and does
+//     not request more than the default limit.
+//
+// Values a container declares are never changed, and the result is always a
+// valid request/limit pair.
+func applyDefaultResources(spec *corev1.PodSpec, defaults corev1.ResourceRequirements) {
+	if len(defaults.Requests) == 0 && len(defaults.Limits) == 0 {
+		return
+	}
+	apply := func(containers []corev1.Container) {
+		for i := range containers {
+			res := &containers[i].Resources
+			for name, request := range defaults.Requests {
+				_, hasRequest := res.Requests[name]
+				_, hasLimit := res.Limits[name]
+				if hasRequest || hasLimit {
+					continue
+				}
+				if res.Requests == nil {
+					res.Requests = corev1.ResourceList{}
+				}
+				res.Requests[name] = request.DeepCopy()
+			}
+			for name, limit := range defaults.Limits {
+				if _, ok := res.Limits[name]; ok {
+					continue
+				}
+				if request, ok := res.Requests[name]; ok && request.Cmp(limit) > 0 {
+					continue
+				}
+				if res.Limits == nil {
+					res.Limits = corev1.ResourceList{}
+				}
+				res.Limits[name] = limit.DeepCopy()
+			}
+		}
 	}
+	apply(spec.InitContainers)
+	apply(spec.Containers)
+}
 
-	if r.Tracer.IsRecording(ctx) {
-		r.Tracer.AddEvent(ctx, "NewPodStatusObserved", map[string]string{
-			"pod.Name":  pod.Name,
-			"pod.Phase": string(pod.Status.Phase),
+// hardenPodSpec applies the --harden-pods defaults to spec. Each field is only set
+// when the pod template leaves it unset, so a template can override any of them:
+//   - the pod runs as non-root with the RuntimeDefault seccomp profile;
+//   - every init and regular container drops all capabilities, cannot gain
+//     privileges (unless it is privileged or adds CAP_SYS_ADMIN, where the API
+//     server rejects that) and has a read-only root filesystem.
+//
+// A container whose root filesystem is made read-only here gets a writable
+// emptyDir at /tmp, unless it already mounts something there.
+func hardenPodSpec(spec *corev1.PodSpec) {
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if spec.SecurityContext.RunAsNonRoot == nil {
+		spec.SecurityContext.RunAsNonRoot = new(true)
+	}
+	if spec.SecurityContext.SeccompProfile == nil {
+		spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+
+	needsTmp := false
+	harden := func(containers []corev1.Container) {
+		for i := range containers {
+			container := &containers[i]
+			if container.SecurityContext == nil {
+				container.SecurityContext = &corev1.SecurityContext{}
+			}
+			sc := container.SecurityContext
+			if sc.Capabilities == nil {
+				sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+			}
+			if sc.AllowPrivilegeEscalation == nil && !canEscalatePrivileges(sc) {
+				sc.AllowPrivilegeEscalation = new(false)
+			}
+			if sc.ReadOnlyRootFilesystem == nil {
+				sc.ReadOnlyRootFilesystem = new(true)
+				if !slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return path.Clean(m.MountPath) == "/tmp" }) {
+					container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: hardenedTmpVolume, MountPath: "/tmp"})
+					needsTmp = true
+				}
+			}
+		}
+	}
+	harden(spec.InitContainers)
+	harden(spec.Containers)
+
+	if needsTmp && !slices.ContainsFunc(spec.Volumes, func(v corev1.Volume) bool { return v.Name == hardenedTmpVolume }) {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name:         hardenedTmpVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 		})
 	}
+}
 
-	return pod, nil
+// canEscalatePrivileges reports whether the container is privileged or adds
+// CAP_SYS_ADMIN, which both require allowPrivilegeEscalation to stay unset or true.
+func canEscalatePrivileges(sc *corev1.SecurityContext) bool {
+	if sc.Privileged != nil && *sc.Privileged {
+		return true
+	}
+	return sc.Capabilities != nil && slices.ContainsFunc(sc.Capabilities.Add, func(c corev1.Capability) bool {
+		return c == "SYS_ADMIN" || c == "CAP_SYS_ADMIN"
+	})
+}
+
+// computePodSpecHash returns a hash of the pod template spec. It is recorded on the
+// Pod at creation so later edits to spec.podTemplate.spec can be detected.
+func computePodSpecHash(podTemplate *sandboxv1beta1.PodTemplate) (string, error) {
+	specJSON, err := json.Marshal(podTemplate.Spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pod template spec for hashing: %w", err)
+	}
+	return naming.NameHash(string(specJSON)), nil
+}
+
+// podNeedsRecreation reports whether the pod was created from an outdated pod
+// template spec and must be replaced. Pods without a recorded hash (created by an
+// older controller or adopted from elsewhere) are left as is, as are pods of
+// Sandboxes that opted out via SandboxDisablePodRecreationAnnotation.
+func podNeedsRecreation(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) bool {
+	if sandbox.Annotations[sandboxv1beta1.SandboxDisablePodRecreationAnnotation] == "true" {
+		return false
+	}
+	recordedHash := pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation]
+	if recordedHash == "" {
+		return false
+	}
+	desiredHash, err := computePodSpecHash(&sandbox.Spec.PodTemplate)
+	if err != nil {
+		return false
+	}
+	return recordedHash != desiredHash
+}
+
+// appliedPodTemplateHash returns the pod template hash the controller applied to
+// pod. It keeps the hash already recorded in the Sandbox status, and adopts the
+// pod's annotation when none is recorded yet (pods created before the field
+// existed, or whose status update was lost) or when the annotation matches the
+// current spec, which is only the case for a pod the controller (re)created.
+func appliedPodTemplateHash(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) string {
+	recordedHash := pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation]
+	if sandbox.Status.AppliedPodTemplateHash == "" {
+		return recordedHash
+	}
+	if desiredHash, err := computePodSpecHash(&sandbox.Spec.PodTemplate); err == nil && recordedHash == desiredHash {
+		return recordedHash
+	}
+	return sandbox.Status.AppliedPodTemplateHash
+}
+
+// computePodDriftedCondition returns a PodDrifted condition when pod was mutated
+// by something other than the controller: its pod-spec-hash annotation differs
+// from status.appliedPodTemplateHash, or, for a pod built from the current spec,
+// a container runs an image other than the template's. It returns nil when no
+// drift is detected. Drift is only reported; recreation is left to
+// podNeedsRecreation. PodCacheTransform keeps the container images read here.
+func computePodDriftedCondition(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) *metav1.Condition {
+	appliedHash := sandbox.Status.AppliedPodTemplateHash
+	if pod == nil || appliedHash == "" {
+		return nil
+	}
+	drifted := &metav1.Condition{
+		Type:               string(sandboxv1beta1.SandboxConditionPodDrifted),
+		ObservedGeneration: sandbox.Generation,
+		Status:             metav1.ConditionTrue,
+	}
+	recordedHash := pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation]
+	if recordedHash != appliedHash {
+		drifted.Reason = sandboxv1beta1.SandboxReasonPodTemplateHashMismatch
+		drifted.Message = fmt.Sprintf("Pod %s annotation is %q, but the controller applied pod template hash %q",
+			sandboxv1beta1.SandboxPodSpecHashAnnotation, recordedHash, appliedHash)
+		return drifted
+	}
+	desiredHash, err := computePodSpecHash(&sandbox.Spec.PodTemplate)
+	if err != nil || recordedHash != desiredHash {
+		// The pod was built from an earlier spec, so its images are expected
+		// to differ from the current template.
+		return nil
+	}
+	templateSpec := &sandbox.Spec.PodTemplate.Spec
+	for _, pair := range [][2][]corev1.Container{
+		{templateSpec.InitContainers, pod.Spec.InitContainers},
+		{templateSpec.Containers, pod.Spec.Containers},
+	} {
+		for _, want := range pair[0] {
+			for _, got := range pair[1] {
+				if got.Name == want.Name && got.Image != want.Image {
+					drifted.Reason = sandboxv1beta1.SandboxReasonPodImageMismatch
+					drifted.Message = fmt.Sprintf("Container %q runs image %q, but the pod template specifies %q",
+						got.Name, got.Image, want.Image)
+					return drifted
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Defaults for spec.restartPolicy, mirroring the CRD defaults for objects that
+// were not defaulted by the API server.
+const (
+	defaultRestartThreshold int32 = 5
+	defaultRestartWindow          = 10 * time.Minute
+	defaultMaxRecreates     int32 = 3
+)
+
+// recreatePodPolicy returns the sandbox's restart threshold, window and recreate
+// cap with defaults applied. ok is false unless the policy is RecreatePod.
+func recreatePodPolicy(sandbox *sandboxv1beta1.Sandbox) (threshold int32, window time.Duration, maxRecreates int32, ok bool) {
+	policy := sandbox.Spec.RestartPolicy
+	if policy == nil || policy.Type != sandboxv1beta1.SandboxRestartPolicyRecreatePod {
+		return 0, 0, 0, false
+	}
+	threshold, window, maxRecreates = defaultRestartThreshold, defaultRestartWindow, defaultMaxRecreates
+	if policy.RestartThreshold != nil {
+		threshold = *policy.RestartThreshold
+	}
+	if policy.Window != nil {
+		window = policy.Window.Duration
+	}
+	if policy.MaxRecreates != nil {
+		maxRecreates = *policy.MaxRecreates
+	}
+	return threshold, window, maxRecreates, true
+}
+
+// restartWindow is the value of SandboxRestartWindowAnnotation: the pod it
+// applies to, when it started, and the pod's restart count at that time.
+type restartWindow struct {
+	podUID   types.UID
+	start    time.Time
+	baseline int32
+}
+
+func parseRestartWindow(value string) (restartWindow, bool) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return restartWindow{}, false
+	}
+	start, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return restartWindow{}, false
+	}
+	baseline, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil {
+		return restartWindow{}, false
+	}
+	return restartWindow{podUID: types.UID(parts[0]), start: start, baseline: int32(baseline)}, true
+}
+
+func (w restartWindow) String() string {
+	return fmt.Sprintf("%s,%s,%d", w.podUID, w.start.UTC().Format(time.RFC3339), w.baseline)
+}
+
+// podRecreateAttempts returns the number of pods recreated under spec.restartPolicy.
+func podRecreateAttempts(sandbox *sandboxv1beta1.Sandbox) int32 {
+	attempts, err := strconv.ParseInt(sandbox.Annotations[sandboxv1beta1.SandboxPodRecreateAttemptsAnnotation], 10, 32)
+	if err != nil || attempts < 0 {
+		return 0
+	}
+	return int32(attempts)
+}
+
+// podRestartsInWindow returns how often the pod restarted within its tracked
+// restart window, or false when no window is tracked for this pod.
+func podRestartsInWindow(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) (int32, bool) {
+	w, ok := parseRestartWindow(sandbox.Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation])
+	if !ok || w.podUID != pod.UID {
+		return 0, false
+	}
+	restarts, _ := podRestarts(pod)
+	if restarts < w.baseline {
+		return 0, false
+	}
+	return restarts - w.baseline, true
+}
+
+// podRecreateLimitExceeded reports whether the pod reached the restart threshold
+// after the sandbox already used up its recreations.
+func podRecreateLimitExceeded(sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) bool {
+	threshold, _, maxRecreates, ok := recreatePodPolicy(sandbox)
+	if !ok || podRecreateAttempts(sandbox) < maxRecreates {
+		return false
+	}
+	restarts, ok := podRestartsInWindow(sandbox, pod)
+	return ok && restarts >= threshold
+}
+
+// reconcileRestartPolicy applies spec.restartPolicy to a pod owned by the sandbox.
+// Restarts are counted in fixed windows tracked in SandboxRestartWindowAnnotation;
+// a window that reaches the threshold is kept rather than rolled over, so a pod
+// left in place after the recreate limit keeps reporting it. It returns true when
+// the pod was deleted to be recreated.
+func (r *SandboxReconciler) reconcileRestartPolicy(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) (bool, error) {
+	threshold, window, maxRecreates, ok := recreatePodPolicy(sandbox)
+	if !ok {
+		return false, nil
+	}
+	logger := log.FromContext(ctx)
+	now := time.Now()
+	restarts, _ := podRestarts(pod)
+	current := restartWindow{podUID: pod.UID, start: now, baseline: restarts}
+
+	inWindow, ok := podRestartsInWindow(sandbox, pod)
+	if ok {
+		current, _ = parseRestartWindow(sandbox.Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation])
+	}
+	if inWindow < threshold {
+		if ok && now.Sub(current.start) >= window {
+			current = restartWindow{podUID: pod.UID, start: now, baseline: restarts}
+		}
+		return false, r.setRestartWindow(ctx, sandbox, current)
+	}
+
+	attempts := podRecreateAttempts(sandbox)
+	if attempts >= maxRecreates {
+		logger.V(4).Info("Pod reached the restart threshold but the recreate limit is exhausted",
+			"Pod.Name", pod.Name, "Restarts", inWindow, "Attempts", attempts)
+		return false, nil
+	}
+
+	logger.Info("Deleting Pod to recreate it after repeated container restarts",
+		"Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name, "Restarts", inWindow, "Attempt", attempts+1)
+	if err := r.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete restarting pod: %w", err)
+	}
+	patch := client.MergeFrom(sandbox.DeepCopy())
+	if sandbox.Annotations == nil {
+		sandbox.Annotations = make(map[string]string)
+	}
+	delete(sandbox.Annotations, sandboxv1beta1.SandboxRestartWindowAnnotation)
+	sandbox.Annotations[sandboxv1beta1.SandboxPodRecreateAttemptsAnnotation] = strconv.Itoa(int(attempts + 1))
+	if err := r.Patch(ctx, sandbox, patch); err != nil {
+		return true, fmt.Errorf("failed to record pod recreate attempt: %w", err)
+	}
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sandbox, pod, corev1.EventTypeWarning, "PodRecreated", "Delete",
+			"Deleted Pod %q after %d restarts within %s (recreate %d of %d)", pod.Name, inWindow, window, attempts+1, maxRecreates)
+	}
+	return true, nil
+}
+
+// setRestartWindow records w in SandboxRestartWindowAnnotation if it changed.
+func (r *SandboxReconciler) setRestartWindow(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, w restartWindow) error {
+	value := w.String()
+	if sandbox.Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation] == value {
+		return nil
+	}
+	patch := client.MergeFrom(sandbox.DeepCopy())
+	if sandbox.Annotations == nil {
+		sandbox.Annotations = make(map[string]string)
+	}
+	sandbox.Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation] = value
+	if err := r.Patch(ctx, sandbox, patch); err != nil {
+		return fmt.Errorf("failed to set restart window annotation: %w", err)
+	}
+	return nil
 }
 
 func (r *SandboxReconciler) updatePodMetadata(ctx context.Context, pod *corev1.Pod, sandbox *sandboxv1beta1.Sandbox, nameHash string) bool {
@@ -1202,8 +2313,9 @@ func (r *SandboxReconciler) updatePodMetadata(ctx context.Context, pod *corev1.P
 	}
 	// Propagate pod template labels to the existing pod (e.g., after warm pool adoption),
 	// skipping system-reserved keys so a user-supplied template cannot override them.
+	templateLabels := r.podLabels(sandbox)
 	var managedLabelKeys []string
-	for k, v := range sandbox.Spec.PodTemplate.ObjectMeta.Labels {
+	for k, v := range templateLabels {
 		if isSystemLabel(k) {
 			logger.V(1).Info("Ignoring system-reserved label in Sandbox PodTemplate", "pod", pod.Name, "key", k)
 			continue
@@ -1235,7 +2347,7 @@ func (r *SandboxReconciler) updatePodMetadata(ctx context.Context, pod *corev1.P
 				}
 				continue
 			}
-			if _, ok := sandbox.Spec.PodTemplate.ObjectMeta.Labels[k]; !ok {
+			if _, ok := templateLabels[k]; !ok {
 				delete(pod.Labels, k)
 				updated = true
 			}
@@ -1273,12 +2385,13 @@ func (r *SandboxReconciler) updatePodMetadata(ctx context.Context, pod *corev1.P
 		}
 	}
 	// Propagate pod template annotations to the existing pod
+	templateAnnotations := r.podAnnotations(sandbox)
 	var managedAnnotationKeys []string
-	if sandbox.Spec.PodTemplate.ObjectMeta.Annotations != nil {
+	if templateAnnotations != nil {
 		if pod.Annotations == nil {
 			pod.Annotations = make(map[string]string)
 		}
-		for k, v := range sandbox.Spec.PodTemplate.ObjectMeta.Annotations {
+		for k, v := range templateAnnotations {
 			if isSystemAnnotation(k) {
 				logger.V(1).Info("Ignoring system-reserved annotation in Sandbox PodTemplate", "pod", pod.Name, "key", k)
 				continue
@@ -1310,7 +2423,7 @@ func (r *SandboxReconciler) updatePodMetadata(ctx context.Context, pod *corev1.P
 				}
 				continue
 			}
-			if _, ok := sandbox.Spec.PodTemplate.ObjectMeta.Annotations[k]; !ok {
+			if _, ok := templateAnnotations[k]; !ok {
 				delete(pod.Annotations, k)
 				updated = true
 			}
@@ -1342,6 +2455,12 @@ func (r *SandboxReconciler) reconcilePVCs(ctx context.Context, sandbox *sandboxv
 	ctx, end := r.Tracer.StartSpan(ctx, nil, "reconcilePVCs", nil)
 	defer end()
 
+	if sandbox.Spec.OperatingMode == sandboxv1beta1.SandboxOperatingModeSuspended {
+		if _, whenScaled := pvcRetentionPolicy(sandbox); whenScaled == sandboxv1beta1.DeletePersistentVolumeClaimRetentionPolicyType {
+			return r.cleanupPVCs(ctx, sandbox, false)
+		}
+	}
+
 	for _, pvcTemplate := range sandbox.Spec.VolumeClaimTemplates {
 		pvc := &corev1.PersistentVolumeClaim{}
 		pvcName := pvcTemplate.Name + "-" + sandbox.Name
@@ -1388,33 +2507,197 @@ func (r *SandboxReconciler) reconcilePVCs(ctx context.Context, sandbox *sandboxv
 			return fmt.Errorf("failed to get PVC: %w", err)
 		}
 
-		pvcLabels := maps.Clone(pvcTemplate.Labels)
-		if pvcLabels == nil {
-			pvcLabels = make(map[string]string)
-		}
-		pvcLabels[sandboxLabel] = nameHash
-
 		logger.Info("Creating a new PVC", "PVC.Namespace", sandbox.Namespace, "PVC.Name", pvcName)
-		pvc = &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        pvcName,
-				Namespace:   sandbox.Namespace,
-				Annotations: maps.Clone(pvcTemplate.Annotations),
-				Labels:      pvcLabels,
-			},
-			Spec: pvcTemplate.Spec,
-		}
-		if err := ctrl.SetControllerReference(sandbox, pvc, r.Scheme); err != nil {
-			return fmt.Errorf("SetControllerReference for PVC failed: %w", err)
+		pvc, err = r.buildPVC(sandbox, pvcTemplate, nameHash)
+		if err != nil {
+			return err
 		}
 		if err := r.Create(ctx, pvc, client.FieldOwner(sandboxControllerFieldOwner)); err != nil {
 			logger.Error(err, "Failed to create PVC", "PVC.Namespace", sandbox.Namespace, "PVC.Name", pvcName)
 			return err
 		}
+		if r.Recorder != nil {
+			r.Recorder.Eventf(sandbox, pvc, corev1.EventTypeNormal, "PVCCreated", "Create", "Created PersistentVolumeClaim %q", pvc.Name)
+		}
 	}
 	return nil
 }
 
+// buildPVC returns the PersistentVolumeClaim the Sandbox should have for
+// pvcTemplate, as reconcilePVCs creates it.
+func (r *SandboxReconciler) buildPVC(sandbox *sandboxv1beta1.Sandbox, pvcTemplate sandboxv1beta1.PersistentVolumeClaimTemplate, nameHash string) (*corev1.PersistentVolumeClaim, error) {
+	pvcLabels := maps.Clone(pvcTemplate.Labels)
+	if pvcLabels == nil {
+		pvcLabels = make(map[string]string)
+	}
+	pvcLabels[sandboxLabel] = nameHash
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvcTemplate.Name + "-" + sandbox.Name,
+			Namespace:   sandbox.Namespace,
+			Annotations: maps.Clone(pvcTemplate.Annotations),
+			Labels:      pvcLabels,
+		},
+		Spec: pvcTemplate.Spec,
+	}
+	if err := ctrl.SetControllerReference(sandbox, pvc, r.Scheme); err != nil {
+		return nil, fmt.Errorf("SetControllerReference for PVC failed: %w", err)
+	}
+	return pvc, nil
+}
+
+// sandboxConfigObjectName returns the name of the ConfigMap or Secret created
+// for the Sandbox from the template named templateName.
+func sandboxConfigObjectName(sandbox *sandboxv1beta1.Sandbox, templateName string) string {
+	return sandbox.Name + "-" + templateName
+}
+
+func (r *SandboxReconciler) reconcileConfigMaps(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, nameHash string) error {
+	ctx, end := r.Tracer.StartSpan(ctx, nil, "reconcileConfigMaps", nil)
+	defer end()
+
+	var allErrors error
+	for _, cmTemplate := range sandbox.Spec.ConfigMapTemplates {
+		desired, err := r.buildConfigMap(sandbox, cmTemplate, nameHash)
+		if err != nil {
+			return err
+		}
+		allErrors = errors.Join(allErrors, r.reconcileConfigObject(ctx, sandbox, desired, &corev1.ConfigMap{},
+			func(existing client.Object) bool {
+				cm := existing.(*corev1.ConfigMap)
+				if maps.Equal(cm.Data, desired.Data) && maps.EqualFunc(cm.BinaryData, desired.BinaryData, bytes.Equal) {
+					return false
+				}
+				cm.Data, cm.BinaryData = desired.Data, desired.BinaryData
+				return true
+			}))
+	}
+	return allErrors
+}
+
+func (r *SandboxReconciler) reconcileSecrets(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, nameHash string) error {
+	ctx, end := r.Tracer.StartSpan(ctx, nil, "reconcileSecrets", nil)
+	defer end()
+
+	var allErrors error
+	for _, secretTemplate := range sandbox.Spec.SecretTemplates {
+		desired, err := r.buildSecret(sandbox, secretTemplate, nameHash)
+		if err != nil {
+			return err
+		}
+		allErrors = errors.Join(allErrors, r.reconcileConfigObject(ctx, sandbox, desired, &corev1.Secret{},
+			func(existing client.Object) bool {
+				secret := existing.(*corev1.Secret)
+				if maps.EqualFunc(secret.Data, desired.Data, bytes.Equal) {
+					return false
+				}
+				secret.Data = desired.Data
+				return true
+			}))
+	}
+	return allErrors
+}
+
+// reconcileConfigObject creates desired, a ConfigMap or Secret built from one of
+// the Sandbox's templates, or brings the existing object's data in line with it.
+// existing is an empty object of desired's type to read the live object into, and
+// syncData copies desired's data into it, reporting whether anything changed.
+// Objects the Sandbox does not control are never modified.
+func (r *SandboxReconciler) reconcileConfigObject(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, desired, existing client.Object, syncData func(existing client.Object) bool) error {
+	logger := log.FromContext(ctx)
+	kind := desired.GetObjectKind().GroupVersionKind().Kind
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if k8serrors.IsNotFound(err) {
+		logger.Info("Creating a new "+kind, kind+".Namespace", desired.GetNamespace(), kind+".Name", desired.GetName())
+		if err := r.Create(ctx, desired, client.FieldOwner(sandboxControllerFieldOwner)); err != nil {
+			return fmt.Errorf("failed to create %s %q: %w", kind, desired.GetName(), err)
+		}
+		if r.Recorder != nil {
+			r.Recorder.Eventf(sandbox, desired, corev1.EventTypeNormal, kind+"Created", "Create", "Created %s %q", kind, desired.GetName())
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s %q: %w", kind, desired.GetName(), err)
+	}
+
+	switch ownership, controllerRef := checkOwnership(existing, sandbox); ownership {
+	case resourceOwnedByOther:
+		return fmt.Errorf("%s %q is owned by %s/%s (UID: %s), not by sandbox %q",
+			kind, desired.GetName(), controllerRef.Kind, controllerRef.Name, controllerRef.UID, sandbox.Name)
+	case resourceUnowned:
+		return fmt.Errorf("%s %q already exists and is not controlled by sandbox %q", kind, desired.GetName(), sandbox.Name)
+	case resourceOwnedBySandbox:
+	}
+	if !syncData(existing) {
+		return nil
+	}
+	logger.Info("Updating "+kind+" data from its template", kind+".Name", desired.GetName())
+	if err := r.Update(ctx, existing, client.FieldOwner(sandboxControllerFieldOwner)); err != nil {
+		return fmt.Errorf("failed to update %s %q: %w", kind, desired.GetName(), err)
+	}
+	return nil
+}
+
+// buildConfigMap returns the ConfigMap the Sandbox should have for cmTemplate.
+func (r *SandboxReconciler) buildConfigMap(sandbox *sandboxv1beta1.Sandbox, cmTemplate sandboxv1beta1.ConfigMapTemplate, nameHash string) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: configObjectMeta(sandbox, cmTemplate.EmbeddedObjectMetadata, nameHash),
+		Data:       maps.Clone(cmTemplate.Data),
+		BinaryData: maps.Clone(cmTemplate.BinaryData),
+	}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	if err := ctrl.SetControllerReference(sandbox, cm, r.Scheme); err != nil {
+		return nil, fmt.Errorf("SetControllerReference for ConfigMap failed: %w", err)
+	}
+	return cm, nil
+}
+
+// buildSecret returns the Secret the Sandbox should have for secretTemplate.
+// stringData is folded into data, as the API server would, so the result can be
+// compared with the live Secret.
+func (r *SandboxReconciler) buildSecret(sandbox *sandboxv1beta1.Sandbox, secretTemplate sandboxv1beta1.SecretTemplate, nameHash string) (*corev1.Secret, error) {
+	data := maps.Clone(secretTemplate.Data)
+	if len(secretTemplate.StringData) > 0 && data == nil {
+		data = make(map[string][]byte, len(secretTemplate.StringData))
+	}
+	for k, v := range secretTemplate.StringData {
+		data[k] = []byte(v)
+	}
+	secretType := secretTemplate.Type
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: configObjectMeta(sandbox, secretTemplate.EmbeddedObjectMetadata, nameHash),
+		Type:       secretType,
+		Data:       data,
+	}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	if err := ctrl.SetControllerReference(sandbox, secret, r.Scheme); err != nil {
+		return nil, fmt.Errorf("SetControllerReference for Secret failed: %w", err)
+	}
+	return secret, nil
+}
+
+// configObjectMeta returns the metadata of a ConfigMap or Secret created from a
+// template with the given metadata.
+func configObjectMeta(sandbox *sandboxv1beta1.Sandbox, templateMeta sandboxv1beta1.EmbeddedObjectMetadata, nameHash string) metav1.ObjectMeta {
+	objLabels := maps.Clone(templateMeta.Labels)
+	if objLabels == nil {
+		objLabels = make(map[string]string)
+	}
+	objLabels[sandboxLabel] = nameHash
+	return metav1.ObjectMeta{
+		Name:        sandboxConfigObjectName(sandbox, templateMeta.Name),
+		Namespace:   sandbox.Namespace,
+		Annotations: maps.Clone(templateMeta.Annotations),
+		Labels:      objLabels,
+	}
+}
+
 // handles sandbox expiry by deleting child resources and the sandbox itself if needed.
 func (r *SandboxReconciler) handleSandboxExpiry(ctx context.Context, sandbox *sandboxv1beta1.Sandbox) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -1431,8 +2714,8 @@ func (r *SandboxReconciler) handleSandboxExpiry(ctx context.Context, sandbox *sa
 		ownership, controllerRef := checkOwnership(pod, sandbox)
 		switch ownership {
 		case resourceOwnedBySandbox:
-			if err := r.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
-				allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete pod: %w", err))
+			if err := r.deleteExpiredPod(ctx, sandbox, pod); err != nil {
+				allErrors = errors.Join(allErrors, err)
 			}
 		case resourceUnowned:
 			logger.Info("Skipping pod deletion during expiry: pod has no controllerRef pointing to this sandbox",
@@ -1446,7 +2729,7 @@ func (r *SandboxReconciler) handleSandboxExpiry(ctx context.Context, sandbox *sa
 
 	// Delete service only if owned by this sandbox
 	service := &corev1.Service{}
-	if err := r.Get(ctx, types.NamespacedName{Name: sandbox.Name, Namespace: sandbox.Namespace}, service); err != nil {
+	if err := r.Get(ctx, types.NamespacedName{Name: sandboxServiceName(sandbox), Namespace: sandbox.Namespace}, service); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			allErrors = errors.Join(allErrors, fmt.Errorf("failed to get service: %w", err))
 		}
@@ -1475,6 +2758,11 @@ func (r *SandboxReconciler) handleSandboxExpiry(ctx context.Context, sandbox *sa
 		}
 	}
 
+	// The Sandbox is kept, so its PVCs follow the whenScaled policy.
+	if _, whenScaled := pvcRetentionPolicy(sandbox); whenScaled == sandboxv1beta1.DeletePersistentVolumeClaimRetentionPolicyType {
+		allErrors = errors.Join(allErrors, r.cleanupPVCs(ctx, sandbox, false))
+	}
+
 	// If we reach here, sandbox is not deleted
 	// Only update "expired" status if cleanup was successful
 	if allErrors == nil {
@@ -1494,6 +2782,179 @@ func (r *SandboxReconciler) handleSandboxExpiry(ctx context.Context, sandbox *sa
 	return false, allErrors
 }
 
+// finalizeSandbox runs the ordered teardown for a Sandbox that is being deleted:
+// the Pod is deleted first, and the Service and PVCs only once the Pod is gone,
+// after which the cleanup finalizer is removed. With the force-delete annotation
+// the Pod is deleted with a zero grace period and not waited for. PVCs are deleted or orphaned
+// according to persistentVolumeClaimRetentionPolicy.whenDeleted. Children that are already gone, or that are
+// not controlled by this Sandbox, count as cleaned up.
+func (r *SandboxReconciler) finalizeSandbox(ctx context.Context, sandbox *sandboxv1beta1.Sandbox) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Sandbox is being deleted, cleaning up child resources")
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Name: resolvePodName(sandbox), Namespace: sandbox.Namespace}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+	} else if ownership, _ := checkOwnership(pod, sandbox); ownership == resourceOwnedBySandbox {
+		force := forceDeleteRequested(sandbox)
+		if force {
+			if err := r.forceDeletePod(ctx, sandbox, pod); err != nil {
+				return err
+			}
+		} else if pod.DeletionTimestamp.IsZero() {
+			if r.Recorder != nil {
+				r.Recorder.Eventf(sandbox, pod, corev1.EventTypeNormal, "PodTerminating", "Delete", "Terminating Pod %q for Sandbox deletion", pod.Name)
+			}
+			if err := r.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete pod: %w", err)
+			}
+		}
+		// Wait for the Pod to go away before touching the Service; the Pod
+		// watch requeues the Sandbox once it does. A forced delete doesn't
+		// wait: the kubelet of a lost node never confirms the Pod is gone.
+		if !force {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(pod), pod); !k8serrors.IsNotFound(err) {
+				logger.V(4).Info("Waiting for pod to terminate before removing the Service", "Pod.Name", pod.Name)
+				return err
+			}
+		}
+	}
+
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: sandboxServiceName(sandbox), Namespace: sandbox.Namespace}, service); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get service: %w", err)
+		}
+	} else if ownership, _ := checkOwnership(service, sandbox); ownership == resourceOwnedBySandbox {
+		if err := r.Delete(ctx, service); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete service: %w", err)
+		}
+	}
+
+	whenDeleted, _ := pvcRetentionPolicy(sandbox)
+	if err := r.cleanupPVCs(ctx, sandbox, whenDeleted == sandboxv1beta1.RetainPersistentVolumeClaimRetentionPolicyType); err != nil {
+		return err
+	}
+
+	patch := client.MergeFromWithOptions(sandbox.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(sandbox, sandboxv1beta1.SandboxCleanupFinalizer)
+	if err := r.Patch(ctx, sandbox, patch); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// pvcRetentionPolicy returns the sandbox's whenDeleted and whenScaled PVC
+// retention policies, applying the API defaults when unset.
+func pvcRetentionPolicy(sandbox *sandboxv1beta1.Sandbox) (whenDeleted, whenScaled sandboxv1beta1.PersistentVolumeClaimRetentionPolicyType) {
+	whenDeleted = sandboxv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
+	whenScaled = sandboxv1beta1.RetainPersistentVolumeClaimRetentionPolicyType
+	if policy := sandbox.Spec.PersistentVolumeClaimRetentionPolicy; policy != nil {
+		if policy.WhenDeleted != "" {
+			whenDeleted = policy.WhenDeleted
+		}
+		if policy.WhenScaled != "" {
+			whenScaled = policy.WhenScaled
+		}
+	}
+	return whenDeleted, whenScaled
+}
+
+// cleanupPVCs deletes the PVCs created from the sandbox's volumeClaimTemplates,
+// or, when orphan is set, removes the sandbox's owner reference so they outlive
+// it. The tracking label is kept so a Sandbox recreated with the same name
+// re-adopts the retained PVCs. PVCs not controlled by the sandbox are left alone.
+func (r *SandboxReconciler) cleanupPVCs(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, orphan bool) error {
+	logger := log.FromContext(ctx)
+	var allErrors error
+	for _, pvcTemplate := range sandbox.Spec.VolumeClaimTemplates {
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcName := pvcTemplate.Name + "-" + sandbox.Name
+		if err := r.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: sandbox.Namespace}, pvc); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				allErrors = errors.Join(allErrors, fmt.Errorf("failed to get PVC: %w", err))
+			}
+			continue
+		}
+		if ownership, _ := checkOwnership(pvc, sandbox); ownership != resourceOwnedBySandbox {
+			continue
+		}
+
+		if orphan {
+			logger.Info("Retaining PVC", "PVC.Name", pvcName, "Sandbox.Name", sandbox.Name)
+			patch := client.MergeFrom(pvc.DeepCopy())
+			pvc.OwnerReferences = slices.DeleteFunc(pvc.OwnerReferences, func(ref metav1.OwnerReference) bool {
+				return ref.UID == sandbox.UID
+			})
+			if err := r.Patch(ctx, pvc, patch); err != nil && !k8serrors.IsNotFound(err) {
+				allErrors = errors.Join(allErrors, fmt.Errorf("failed to release PVC %q: %w", pvcName, err))
+			}
+			continue
+		}
+
+		if !pvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		logger.Info("Deleting PVC", "PVC.Name", pvcName, "Sandbox.Name", sandbox.Name)
+		if err := r.Delete(ctx, pvc); err != nil && !k8serrors.IsNotFound(err) {
+			allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete PVC %q: %w", pvcName, err))
+		}
+	}
+	return allErrors
+}
+
+// deleteExpiredPod deletes the sandbox pod on expiry. A PodTerminating Event is
+// recorded first so agents watching the Sandbox get notice before their containers
+// are signalled. No grace period is passed, so the API server applies the pod's own
+// terminationGracePeriodSeconds. A pod that is already terminating is left alone so
+// its grace period is not reset.
+func (r *SandboxReconciler) deleteExpiredPod(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) error {
+	if forceDeleteRequested(sandbox) {
+		return r.forceDeletePod(ctx, sandbox, pod)
+	}
+	if !pod.DeletionTimestamp.IsZero() {
+		log.FromContext(ctx).V(4).Info("Pod is already terminating, not re-issuing delete", "Pod.Name", pod.Name)
+		return nil
+	}
+
+	gracePeriod := sandbox.Spec.PodTemplate.Spec.TerminationGracePeriodSeconds
+	if r.Recorder != nil {
+		if gracePeriod != nil {
+			r.Recorder.Eventf(sandbox, pod, corev1.EventTypeNormal, "PodTerminating", "Delete", "Terminating Pod %q on expiry with a grace period of %ds", pod.Name, *gracePeriod)
+		} else {
+			r.Recorder.Eventf(sandbox, pod, corev1.EventTypeNormal, "PodTerminating", "Delete", "Terminating Pod %q on expiry", pod.Name)
+		}
+	}
+
+	if err := r.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pod: %w", err)
+	}
+	return nil
+}
+
+// forceDeleteRequested reports whether the sandbox carries the force-delete annotation.
+func forceDeleteRequested(sandbox *sandboxv1beta1.Sandbox) bool {
+	return sandbox.Annotations[sandboxv1beta1.SandboxForceDeleteAnnotation] == "true"
+}
+
+// forceDeletePod deletes the sandbox pod with a zero grace period, even when it is
+// already terminating: a pod on a node that is gone stays Terminating until its
+// kubelet confirms the shutdown, which never happens. The containers may still be
+// running if the node comes back, so this is logged and recorded as a warning.
+func (r *SandboxReconciler) forceDeletePod(ctx context.Context, sandbox *sandboxv1beta1.Sandbox, pod *corev1.Pod) error {
+	log.FromContext(ctx).Info("Force-deleting pod without waiting for it to terminate",
+		"Pod.Name", pod.Name, "Sandbox.Name", sandbox.Name, "annotation", sandboxv1beta1.SandboxForceDeleteAnnotation)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sandbox, pod, corev1.EventTypeWarning, "PodForceDeleted", "Delete", "Force-deleting Pod %q with a zero grace period", pod.Name)
+	}
+	if err := r.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to force-delete pod: %w", err)
+	}
+	return nil
+}
+
 // checks if the sandbox has expired
 // returns true if expired, false otherwise
 // if not expired, also returns the duration to requeue after.
@@ -1515,6 +2976,21 @@ func checkSandboxExpiry(sandbox *sandboxv1beta1.Sandbox, now time.Time) (bool, t
 	return false, requeueAfter
 }
 
+// expiresIn returns status.expiresIn for the sandbox, the time left until its
+// shutdownTime formatted like kubectl ages, and how soon it should be recomputed.
+// Both are zero when no shutdownTime is set or it has passed.
+func expiresIn(sandbox *sandboxv1beta1.Sandbox, now time.Time) (string, time.Duration) {
+	if sandbox.Spec.ShutdownTime == nil {
+		return "", 0
+	}
+	remaining := sandbox.Spec.ShutdownTime.Sub(now)
+	if remaining <= 0 {
+		return "", 0
+	}
+	refresh := min(max(remaining/10, expiresInMinRefresh), expiresInMaxRefresh)
+	return duration.HumanDuration(remaining), refresh
+}
+
 func setSandboxExpiredCondition(sandbox *sandboxv1beta1.Sandbox) {
 	meta.SetStatusCondition(&sandbox.Status.Conditions, metav1.Condition{
 		Type:               string(sandboxv1beta1.SandboxConditionReady),
@@ -1525,6 +3001,14 @@ func setSandboxExpiredCondition(sandbox *sandboxv1beta1.Sandbox) {
 	})
 }
 
+// recordExpiredEvent emits an Event when the sandbox is first marked as expired.
+func (r *SandboxReconciler) recordExpiredEvent(sandbox *sandboxv1beta1.Sandbox) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(sandbox, nil, corev1.EventTypeNormal, sandboxv1beta1.SandboxReasonExpired, "Expire", "Sandbox expired at %s", sandbox.Spec.ShutdownTime.UTC().Format(time.RFC3339))
+}
+
 // sandboxMarkedExpired checks if the sandbox is already marked as expired.
 func sandboxMarkedExpired(sandbox *sandboxv1beta1.Sandbox) bool {
 	cond := meta.FindStatusCondition(sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
@@ -1541,12 +3025,23 @@ func podSandboxNameHashIndexer(obj client.Object) []string {
 	return nil
 }
 
+// sandboxNameHashIndexer extracts NameHash of the Sandbox name for the
+// sandboxNameHashIndex cache field index. Shared with tests like
+// podSandboxNameHashIndexer.
+func sandboxNameHashIndexer(obj client.Object) []string {
+	return []string{naming.NameHash(obj.GetName())}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SandboxReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWorkers int) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podSandboxNameHashIndex,
 		podSandboxNameHashIndexer); err != nil {
 		return fmt.Errorf("failed to index pods by sandbox label: %w", err)
 	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &sandboxv1beta1.Sandbox{}, sandboxNameHashIndex,
+		sandboxNameHashIndexer); err != nil {
+		return fmt.Errorf("failed to index sandboxes by name hash: %w", err)
+	}
 
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
@@ -1565,6 +3060,18 @@ func (r *SandboxReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWorkers
 		For(&sandboxv1beta1.Sandbox{}).
 		Owns(&corev1.Pod{}, builder.WithPredicates(labelSelectorPredicate)).
 		Owns(&corev1.Service{}, builder.WithPredicates(labelSelectorPredicate)).
+		Owns(&corev1.ConfigMap{}, builder.WithPredicates(labelSelectorPredicate)).
+		Owns(&corev1.Secret{}, builder.WithPredicates(labelSelectorPredicate)).
+		Watches(
+			&sandboxv1beta1.Sandbox{},
+			handler.EnqueueRequestsFromMapFunc(r.findSandboxesSharingNameHash),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return true },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
 		WithOptions(controller.Options{MaxConcurrentReconciles: concurrentWorkers}).
 		Complete(r)
 }
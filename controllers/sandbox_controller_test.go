@@ -18,8 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand/v2"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -35,10 +37,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
@@ -50,12 +54,17 @@ func newFakeClient(initialObjs ...runtime.Object) client.WithWatch {
 		WithScheme(Scheme).
 		WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
 		WithIndex(&corev1.Pod{}, podSandboxNameHashIndex, podSandboxNameHashIndexer).
+		WithIndex(&sandboxv1beta1.Sandbox{}, sandboxNameHashIndex, sandboxNameHashIndexer).
 		WithRuntimeObjects(initialObjs...).
 		Build()
 }
 
 const sandboxUID = types.UID("test-sandbox-uid")
 
+// testPodSpecHash is the pod spec hash of the single "test-container" pod template
+// used by most test cases.
+const testPodSpecHash = "7e531891"
+
 func sandboxControllerRef(name string) metav1.OwnerReference {
 	return metav1.OwnerReference{
 		APIVersion:         sandboxv1beta1.GroupVersion.String(),
@@ -67,6 +76,17 @@ func sandboxControllerRef(name string) metav1.OwnerReference {
 	}
 }
 
+// newPodSpecSandbox returns a Sandbox whose pod template has a copy of spec, for
+// tests of how reconcilePod builds a new Pod from the template.
+func newPodSpecSandbox(spec corev1.PodSpec) *sandboxv1beta1.Sandbox {
+	sb := &sandboxv1beta1.Sandbox{}
+	sb.Name = "sandbox-name"
+	sb.Namespace = "sandbox-ns"
+	sb.UID = sandboxUID
+	spec.DeepCopyInto(&sb.Spec.PodTemplate.Spec)
+	return sb
+}
+
 func TestComputeConditions(t *testing.T) {
 	r := &SandboxReconciler{}
 
@@ -84,6 +104,24 @@ func TestComputeConditions(t *testing.T) {
 		return sb
 	}
 
+	sbWithPrimaryContainer := func(primary string) *sandboxv1beta1.Sandbox {
+		sb := sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning)
+		sb.Spec.PodTemplate.Spec.Containers = []corev1.Container{{Name: "agent"}, {Name: "sidecar"}}
+		sb.Spec.PrimaryContainer = primary
+		return sb
+	}
+
+	readyPodWithContainers := func(statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				PodIPs:            []corev1.PodIP{{IP: "10.244.0.1"}},
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				ContainerStatuses: statuses,
+			},
+		}
+	}
+
 	testCases := []struct {
 		name               string
 		sandbox            *sandboxv1beta1.Sandbox
@@ -119,6 +157,39 @@ func TestComputeConditions(t *testing.T) {
 				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: "Pod exists with phase: Pending; Service Exists"},
 			},
 		},
+		{
+			name:    "3a. Pod Pending and unschedulable",
+			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
+			svc:     &corev1.Service{},
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					Conditions: []corev1.PodCondition{{
+						Type:    corev1.PodScheduled,
+						Status:  corev1.ConditionFalse,
+						Reason:  corev1.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 Insufficient memory.",
+					}},
+				},
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "Unschedulable", Message: "Pod is unschedulable (Unschedulable): 0/3 nodes are available: 3 Insufficient memory.; Service Exists"},
+			},
+		},
+		{
+			name:    "3b. Pod Pending and scheduled",
+			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
+			svc:     &corev1.Service{},
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodPending,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+				},
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: "Pod exists with phase: Pending; Service Exists"},
+			},
+		},
 		{
 			name:    "4. Pod Running but not Ready",
 			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
@@ -136,6 +207,43 @@ func TestComputeConditions(t *testing.T) {
 				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: "Pod is Running but not Ready; Service Exists"},
 			},
 		},
+		{
+			name:    "4a. Pod Running with a container not started",
+			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
+			svc:     &corev1.Service{},
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					PodIPs:     []corev1.PodIP{{IP: "10.244.0.1"}},
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "sidecar", Started: new(true), State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "agent", Started: new(false), State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+				},
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "Starting", Message: `Pod is Running but container "agent" has not passed its startup probe yet; Service Exists`},
+			},
+		},
+		{
+			name:    "4b. Pod Running with all containers started but not Ready",
+			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
+			svc:     &corev1.Service{},
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					PodIPs:     []corev1.PodIP{{IP: "10.244.0.1"}},
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "agent", Started: new(true), State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+				},
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: "Pod is Running but not Ready; Service Exists"},
+			},
+		},
 		{
 			name:    "5. Pod ready but no IP yet",
 			sandbox: sbWithMode(sandboxv1beta1.SandboxOperatingModeRunning),
@@ -230,6 +338,36 @@ func TestComputeConditions(t *testing.T) {
 				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "ReconcilerError", Message: "Error seen: something went wrong"},
 			},
 		},
+		{
+			name:    "13. Pod Ready but first container not Ready",
+			sandbox: sbWithPrimaryContainer(""),
+			pod: readyPodWithContainers(
+				corev1.ContainerStatus{Name: "agent", Ready: false},
+				corev1.ContainerStatus{Name: "sidecar", Ready: true},
+			),
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: `Pod is Ready but primary container "agent" is not Ready`},
+			},
+		},
+		{
+			name:    "14. Pod Ready and named primary container Ready",
+			sandbox: sbWithPrimaryContainer("sidecar"),
+			pod: readyPodWithContainers(
+				corev1.ContainerStatus{Name: "agent", Ready: false},
+				corev1.ContainerStatus{Name: "sidecar", Ready: true},
+			),
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "True", ObservedGeneration: gen, Reason: "DependenciesReady", Message: "Pod is Ready"},
+			},
+		},
+		{
+			name:    "15. Pod Ready but named primary container has no status",
+			sandbox: sbWithPrimaryContainer("missing"),
+			pod:     readyPodWithContainers(corev1.ContainerStatus{Name: "agent", Ready: true}),
+			expectedConditions: []metav1.Condition{
+				{Type: "Ready", Status: "False", ObservedGeneration: gen, Reason: "DependenciesNotReady", Message: `Pod is Ready but has no status for primary container "missing"`},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -320,7 +458,10 @@ func TestReconcile(t *testing.T) {
 			},
 			// Verify Sandbox status
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				LabelSelector:          "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:                sandboxName,
+				AppliedPodTemplateHash: testPodSpecHash,
+				ObservedGeneration:     1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               "Ready",
@@ -341,9 +482,14 @@ func TestReconcile(t *testing.T) {
 						Labels: map[string]string{
 							"agents.x-k8s.io/sandbox-name-hash": nameHash,
 						},
+						Annotations: map[string]string{
+							sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						},
 						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 					},
 					Spec: corev1.PodSpec{
+						Hostname:  sandboxName,
+						Subdomain: sandboxName,
 						Containers: []corev1.Container{
 							{
 								Name: "test-container",
@@ -369,9 +515,13 @@ func TestReconcile(t *testing.T) {
 			},
 			// Verify Sandbox status
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				Service:       sandboxName,
-				ServiceFQDN:   "sandbox-name.sandbox-ns.svc.cluster.local",
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				Service:                sandboxName,
+				ServiceFQDN:            "sandbox-name.sandbox-ns.svc.cluster.local",
+				LabelSelector:          "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:                sandboxName,
+				PodFQDN:                sandboxName + "." + sandboxName + "." + sandboxNs + ".svc.cluster.local",
+				AppliedPodTemplateHash: testPodSpecHash,
+				ObservedGeneration:     1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               string(sandboxv1beta1.SandboxConditionReady),
@@ -392,9 +542,14 @@ func TestReconcile(t *testing.T) {
 						Labels: map[string]string{
 							"agents.x-k8s.io/sandbox-name-hash": nameHash,
 						},
+						Annotations: map[string]string{
+							sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						},
 						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 					},
 					Spec: corev1.PodSpec{
+						Hostname:  sandboxName,
+						Subdomain: sandboxName,
 						Containers: []corev1.Container{
 							{
 								Name: "test-container",
@@ -463,9 +618,13 @@ func TestReconcile(t *testing.T) {
 			},
 			// Verify Sandbox status
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				Service:       sandboxName,
-				ServiceFQDN:   "sandbox-name.sandbox-ns.svc.cluster.local",
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				Service:                sandboxName,
+				ServiceFQDN:            "sandbox-name.sandbox-ns.svc.cluster.local",
+				LabelSelector:          "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:                sandboxName,
+				PodFQDN:                sandboxName + "." + sandboxName + "." + sandboxNs + ".svc.cluster.local",
+				AppliedPodTemplateHash: testPodSpecHash,
+				ObservedGeneration:     1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               string(sandboxv1beta1.SandboxConditionReady),
@@ -488,13 +647,16 @@ func TestReconcile(t *testing.T) {
 							"custom-label":                      "label-val",
 						},
 						Annotations: map[string]string{
-							"custom-annotation":                      "anno-val",
-							"agents.x-k8s.io/propagated-labels":      "custom-label",
-							"agents.x-k8s.io/propagated-annotations": "custom-annotation",
+							sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+							"custom-annotation":                         "anno-val",
+							"agents.x-k8s.io/propagated-labels":         "custom-label",
+							"agents.x-k8s.io/propagated-annotations":    "custom-annotation",
 						},
 						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 					},
 					Spec: corev1.PodSpec{
+						Hostname:  sandboxName,
+						Subdomain: sandboxName,
 						Containers: []corev1.Container{
 							{
 								Name: "test-container",
@@ -588,11 +750,13 @@ func TestReconcile(t *testing.T) {
 				}},
 			},
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				Service:       sandboxName,
-				ServiceFQDN:   "sandbox-name.sandbox-ns.svc.cluster.local",
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
-				PodIPs:        []string{"10.244.0.5", "fd00::5"},
-				NodeName:      "node-1",
+				Service:            sandboxName,
+				ServiceFQDN:        "sandbox-name.sandbox-ns.svc.cluster.local",
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				PodIPs:             []string{"10.244.0.5", "fd00::5"},
+				NodeName:           "node-1",
+				ObservedGeneration: 1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               "Ready",
@@ -655,10 +819,12 @@ func TestReconcile(t *testing.T) {
 				}},
 			},
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				Service:       sandboxName,
-				ServiceFQDN:   "sandbox-name.sandbox-ns.svc.cluster.local",
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
-				PodIPs:        []string{"10.244.0.5", "fd00::5"},
+				Service:            sandboxName,
+				ServiceFQDN:        "sandbox-name.sandbox-ns.svc.cluster.local",
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				PodIPs:             []string{"10.244.0.5", "fd00::5"},
+				ObservedGeneration: 1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               "Ready",
@@ -721,9 +887,58 @@ func TestReconcile(t *testing.T) {
 			}},
 			},
 			wantStatus: sandboxv1beta1.SandboxStatus{
-				LabelSelector: "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
-				PodIPs:        []string{"10.244.0.5"},
-				NodeName:      "node-2",
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				PodIPs:             []string{"10.244.0.5"},
+				NodeName:           "node-2",
+				ObservedGeneration: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               "Ready",
+						Status:             "True",
+						ObservedGeneration: 1,
+						Reason:             sandboxv1beta1.SandboxReasonDependenciesReady,
+						Message:            "Pod is Ready",
+					},
+				},
+			},
+		},
+		{
+			name: "sandbox with adopted warm pool pod reports the pod name",
+			initialObjs: []runtime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "warmpool-abc-xyz",
+						Namespace:       sandboxNs,
+						Labels:          map[string]string{"agents.x-k8s.io/sandbox-name-hash": nameHash},
+						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "test-container"}},
+					},
+					Status: corev1.PodStatus{
+						PodIPs: []corev1.PodIP{{IP: "10.244.0.7"}},
+						Phase:  corev1.PodRunning,
+						Conditions: []corev1.PodCondition{
+							{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+						},
+					},
+				},
+			},
+			sandboxAnnotations: map[string]string{
+				sandboxv1beta1.SandboxPodNameAnnotation: "warmpool-abc-xyz",
+			},
+			sandboxSpec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+				},
+			}},
+			},
+			wantStatus: sandboxv1beta1.SandboxStatus{
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            "warmpool-abc-xyz",
+				PodIPs:             []string{"10.244.0.7"},
+				ObservedGeneration: 1,
 				Conditions: []metav1.Condition{
 					{
 						Type:               "Ready",
@@ -735,6 +950,144 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sandbox reports container restarts",
+			initialObjs: []runtime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            sandboxName,
+						Namespace:       sandboxNs,
+						Labels:          map[string]string{"agents.x-k8s.io/sandbox-name-hash": nameHash},
+						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name:         "agent",
+								RestartCount: 3,
+								State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{
+									StartedAt: metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)),
+								}},
+							},
+							{
+								Name:         "sidecar",
+								RestartCount: 2,
+								State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+									Reason: "CrashLoopBackOff",
+								}},
+								LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+									FinishedAt: metav1.NewTime(time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC)),
+								}},
+							},
+							{Name: "healthy"},
+						},
+					},
+				},
+			},
+			sandboxSpec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "agent"}, {Name: "sidecar"}, {Name: "healthy"}},
+				},
+			}},
+			},
+			wantStatus: sandboxv1beta1.SandboxStatus{
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				RestartCount:       5,
+				LastRestartTime:    new(metav1.NewTime(time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC))),
+				ObservedGeneration: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               "Ready",
+						Status:             "False",
+						ObservedGeneration: 1,
+						Reason:             sandboxv1beta1.SandboxReasonDependenciesNotReady,
+						Message:            "Pod exists with phase: ",
+					},
+				},
+			},
+		},
+		{
+			name: "sandbox reports the running image of the first container by default",
+			initialObjs: []runtime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            sandboxName,
+						Namespace:       sandboxNs,
+						Labels:          map[string]string{"agents.x-k8s.io/sandbox-name-hash": nameHash},
+						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{Name: "sidecar", ImageID: "docker.io/library/sidecar@sha256:2222"},
+							{Name: "agent", ImageID: "docker.io/library/agent@sha256:1111"},
+						},
+					},
+				},
+			},
+			sandboxSpec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "agent"}, {Name: "sidecar"}},
+				},
+			}},
+			},
+			wantStatus: sandboxv1beta1.SandboxStatus{
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				RunningImage:       "docker.io/library/agent@sha256:1111",
+				ObservedGeneration: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               "Ready",
+						Status:             "False",
+						ObservedGeneration: 1,
+						Reason:             sandboxv1beta1.SandboxReasonDependenciesNotReady,
+						Message:            "Pod exists with phase: ",
+					},
+				},
+			},
+		},
+		{
+			name: "sandbox reports the running image of the primary container",
+			initialObjs: []runtime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            sandboxName,
+						Namespace:       sandboxNs,
+						Labels:          map[string]string{"agents.x-k8s.io/sandbox-name-hash": nameHash},
+						OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{Name: "sidecar", ImageID: "docker.io/library/sidecar@sha256:2222"},
+							{Name: "agent", ImageID: "docker.io/library/agent@sha256:1111"},
+						},
+					},
+				},
+			},
+			sandboxSpec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "agent"}, {Name: "sidecar"}},
+				},
+			}},
+				PrimaryContainer: "sidecar",
+			},
+			wantStatus: sandboxv1beta1.SandboxStatus{
+				LabelSelector:      "agents.x-k8s.io/sandbox-name-hash=" + nameHash,
+				PodName:            sandboxName,
+				RunningImage:       "docker.io/library/sidecar@sha256:2222",
+				ObservedGeneration: 1,
+				Conditions: []metav1.Condition{
+					{
+						Type:               "Ready",
+						Status:             "False",
+						ObservedGeneration: 1,
+						Reason:             sandboxv1beta1.SandboxReasonDependenciesNotReady,
+						Message:            "Pod exists with phase: ",
+					},
+				},
+			},
+		},
 		{
 			name:           "sandbox expired with retain policy",
 			reconcileCount: 2,
@@ -835,8 +1188,10 @@ func TestReconcile(t *testing.T) {
 			},
 		},
 		{
-			name:           "sandbox expired with delete policy",
-			reconcileCount: 2,
+			name: "sandbox expired with delete policy",
+			// Mark expired, delete children and the Sandbox, then run the
+			// cleanup finalizer.
+			reconcileCount: 3,
 			initialObjs: []runtime.Object{
 				&corev1.Pod{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1274,13 +1629,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"custom-annotation":                      "anno-val",
-						"agents.x-k8s.io/propagated-labels":      "custom-label",
-						"agents.x-k8s.io/propagated-annotations": "custom-annotation",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"custom-annotation":                         "anno-val",
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
+						"agents.x-k8s.io/propagated-annotations":    "custom-annotation",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
 				Spec: corev1.PodSpec{
+					Hostname:  sandboxName,
+					Subdomain: sandboxName,
 					Containers: []corev1.Container{
 						{
 							Name: "test-container",
@@ -1332,13 +1690,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"custom-annotation":                      "anno-val",
-						"agents.x-k8s.io/propagated-labels":      "custom-label",
-						"agents.x-k8s.io/propagated-annotations": "custom-annotation",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"custom-annotation":                         "anno-val",
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
+						"agents.x-k8s.io/propagated-annotations":    "custom-annotation",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
 				Spec: corev1.PodSpec{
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
 					Containers: []corev1.Container{{Name: "test-container"}},
 				},
 			},
@@ -1427,11 +1788,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
@@ -1464,11 +1830,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
@@ -1499,11 +1870,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
@@ -1544,11 +1920,16 @@ func TestReconcilePod(t *testing.T) {
 						"custom-label":                      "label-val",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
@@ -1717,27 +2098,81 @@ func TestReconcilePod(t *testing.T) {
 						sandboxv1beta1.SandboxTemplateRefHashLabel: "da1fd924",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
 		{
-			name: "adds template-ref-hash label to existing Pod during reconciliation",
-			initialObjs: []runtime.Object{
-				&corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:            sandboxName,
-						Namespace:       sandboxNs,
-						ResourceVersion: "1",
-						Labels: map[string]string{
-							"agents.x-k8s.io/sandbox-name-hash": nameHash,
-							"custom-label":                      "label-val",
-						},
-						Annotations: map[string]string{
+			name: "propagates template-revision label from warm pool Sandbox to new Pod",
+			sandbox: &sandboxv1beta1.Sandbox{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sandboxName,
+					Namespace: sandboxNs,
+					UID:       sandboxUID,
+					Labels: map[string]string{
+						sandboxv1beta1.SandboxTemplateRefHashLabel:  "da1fd924",
+						sandboxv1beta1.SandboxTemplateRevisionLabel: "my-template-0123abcd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: extensionsv1beta1.GroupVersion.String(),
+							Kind:       extensionsv1beta1.SandboxWarmPoolKind,
+							Name:       "my-pool",
+							UID:        "pool-uid",
+							Controller: new(true),
+						},
+					},
+				},
+				Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				}}, OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning,
+				},
+			},
+			wantPod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            sandboxName,
+					Namespace:       sandboxNs,
+					ResourceVersion: "1",
+					Labels: map[string]string{
+						"agents.x-k8s.io/sandbox-name-hash":         nameHash,
+						sandboxv1beta1.SandboxTemplateRefHashLabel:  "da1fd924",
+						sandboxv1beta1.SandboxTemplateRevisionLabel: "my-template-0123abcd",
+					},
+					Annotations: map[string]string{
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+					},
+					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
+			},
+			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
+		},
+		{
+			name: "adds template-ref-hash label to existing Pod during reconciliation",
+			initialObjs: []runtime.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            sandboxName,
+						Namespace:       sandboxNs,
+						ResourceVersion: "1",
+						Labels: map[string]string{
+							"agents.x-k8s.io/sandbox-name-hash": nameHash,
+							"custom-label":                      "label-val",
+						},
+						Annotations: map[string]string{
 							"agents.x-k8s.io/propagated-labels": "custom-label",
 						},
 					},
@@ -1828,11 +2263,16 @@ func TestReconcilePod(t *testing.T) {
 						sandboxv1beta1.SandboxTemplateRefHashLabel: "da1fd924",
 					},
 					Annotations: map[string]string{
-						"agents.x-k8s.io/propagated-labels": "custom-label",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
-				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test-container"}},
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
+				},
 			},
 			wantSandboxAnnotations: map[string]string{sandboxv1beta1.SandboxPodNameAnnotation: sandboxName},
 		},
@@ -2398,13 +2838,16 @@ func TestReconcilePod(t *testing.T) {
 						sandboxv1beta1.CreatedByLabel:       "go-client",
 					},
 					Annotations: map[string]string{
-						"custom-annotation":                      "anno-val",
-						"agents.x-k8s.io/propagated-labels":      "custom-label",
-						"agents.x-k8s.io/propagated-annotations": "custom-annotation",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"custom-annotation":                         "anno-val",
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
+						"agents.x-k8s.io/propagated-annotations":    "custom-annotation",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
 				Spec: corev1.PodSpec{
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
 					Containers: []corev1.Container{{Name: "test-container"}},
 				},
 			},
@@ -2433,13 +2876,16 @@ func TestReconcilePod(t *testing.T) {
 						sandboxv1beta1.CreatedByLabel:       "unknown",
 					},
 					Annotations: map[string]string{
-						"custom-annotation":                      "anno-val",
-						"agents.x-k8s.io/propagated-labels":      "custom-label",
-						"agents.x-k8s.io/propagated-annotations": "custom-annotation",
+						sandboxv1beta1.SandboxPodSpecHashAnnotation: testPodSpecHash,
+						"custom-annotation":                         "anno-val",
+						"agents.x-k8s.io/propagated-labels":         "custom-label",
+						"agents.x-k8s.io/propagated-annotations":    "custom-annotation",
 					},
 					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
 				},
 				Spec: corev1.PodSpec{
+					Hostname:   sandboxName,
+					Subdomain:  sandboxName,
 					Containers: []corev1.Container{{Name: "test-container"}},
 				},
 			},
@@ -2656,6 +3102,12 @@ func TestReconcileService(t *testing.T) {
 		},
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{Service: new(true)}, OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning},
 	}
+	customServiceName := "stable-dns"
+	sandboxWithServiceName := func() *sandboxv1beta1.Sandbox {
+		sandbox := sandboxObj.DeepCopy()
+		sandbox.Spec.ServiceName = customServiceName
+		return sandbox
+	}
 	sandboxWithPodSpec := func(podSpec corev1.PodSpec) *sandboxv1beta1.Sandbox {
 		return &sandboxv1beta1.Sandbox{
 			ObjectMeta: metav1.ObjectMeta{
@@ -2737,6 +3189,64 @@ func TestReconcileService(t *testing.T) {
 			wantStatusService:     sandboxName,
 			wantStatusServiceFQDN: sandboxName + "." + sandboxNs + ".svc.cluster.local",
 		},
+		{
+			name:    "creates the service under spec.serviceName when set",
+			sandbox: sandboxWithServiceName(),
+			wantService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            customServiceName,
+					Namespace:       sandboxNs,
+					ResourceVersion: "1",
+					Labels: map[string]string{
+						sandboxLabel: nameHash,
+					},
+					OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "None",
+					Selector: map[string]string{
+						sandboxLabel: nameHash,
+					},
+				},
+			},
+			wantStatusService:     customServiceName,
+			wantStatusServiceFQDN: customServiceName + "." + sandboxNs + ".svc.cluster.local",
+		},
+		{
+			name: "refuses a service named spec.serviceName owned by a different controller",
+			initialObjs: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      customServiceName,
+						Namespace: sandboxNs,
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "other-controller",
+							UID:        "other-uid",
+							Controller: new(true),
+						}},
+					},
+				},
+			},
+			sandbox:     sandboxWithServiceName(),
+			expectErr:   true,
+			errContains: "is owned by Deployment/other-controller",
+		},
+		{
+			name: "refuses an unrelated unowned service named spec.serviceName",
+			initialObjs: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      customServiceName,
+						Namespace: sandboxNs,
+					},
+				},
+			},
+			sandbox:     sandboxWithServiceName(),
+			expectErr:   true,
+			errContains: "cannot adopt unowned service",
+		},
 		{
 			name: "creates a new headless service with container ports when service is true",
 			sandbox: sandboxWithPorts(corev1.ContainerPort{
@@ -3495,7 +4005,7 @@ func TestReconcileService(t *testing.T) {
 			if tc.wantService != nil {
 				liveSvc := &corev1.Service{}
 				err = r.Get(t.Context(), types.NamespacedName{
-					Name: sandboxName, Namespace: sandboxNs,
+					Name: tc.wantService.Name, Namespace: sandboxNs,
 				}, liveSvc)
 				require.NoError(t, err)
 				if diff := cmp.Diff(tc.wantService, liveSvc, cmpopts.IgnoreFields(metav1.TypeMeta{}, "APIVersion", "Kind")); diff != "" {
@@ -3793,6 +4303,159 @@ func TestReconcilePVCs(t *testing.T) {
 	}
 }
 
+func TestReconcileConfigMapsAndSecrets(t *testing.T) {
+	sandboxName := "test-sandbox"
+	sandboxNs := "test-ns"
+	cmName := sandboxName + "-config" // "test-sandbox-config"
+	secretName := sandboxName + "-token"
+	nameHash := NameHash(sandboxName)
+
+	sandbox := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sandboxName,
+			Namespace: sandboxNs,
+			UID:       sandboxUID,
+		},
+		Spec: sandboxv1beta1.SandboxSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+				PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent"}}},
+				},
+			},
+			ConfigMapTemplates: []sandboxv1beta1.ConfigMapTemplate{{
+				EmbeddedObjectMetadata: sandboxv1beta1.EmbeddedObjectMetadata{
+					Name:   "config",
+					Labels: map[string]string{"app": "agent"},
+				},
+				Data: map[string]string{"config.yaml": "model: small"},
+			}},
+			SecretTemplates: []sandboxv1beta1.SecretTemplate{{
+				EmbeddedObjectMetadata: sandboxv1beta1.EmbeddedObjectMetadata{Name: "token"},
+				Data:                   map[string][]byte{"ca.crt": []byte("ca")},
+				StringData:             map[string]string{"token": "s3cr3t"},
+			}},
+		},
+	}
+	ownedBySandbox := []metav1.OwnerReference{sandboxControllerRef(sandboxName)}
+
+	testCases := []struct {
+		name        string
+		initialObjs []runtime.Object
+		errContains string
+	}{
+		{
+			name: "creates ConfigMap and Secret when none exist",
+		},
+		{
+			name: "updates data of owned objects that drifted from their templates",
+			initialObjs: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: sandboxNs, OwnerReferences: ownedBySandbox},
+					Data:       map[string]string{"config.yaml": "model: large"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: sandboxNs, OwnerReferences: ownedBySandbox},
+					Data:       map[string][]byte{"token": []byte("old")},
+				},
+			},
+		},
+		{
+			name: "refuses ConfigMap owned by a different controller",
+			initialObjs: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      cmName,
+						Namespace: sandboxNs,
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       "other-controller",
+							UID:        "other-uid-456",
+							Controller: new(true),
+						}},
+					},
+					Data: map[string]string{"config.yaml": "model: large"},
+				},
+			},
+			errContains: "is owned by Deployment/other-controller",
+		},
+		{
+			name: "refuses unowned Secret",
+			initialObjs: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: sandboxNs},
+					Data:       map[string][]byte{"token": []byte("old")},
+				},
+			},
+			errContains: "already exists and is not controlled by sandbox",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := SandboxReconciler{
+				Client: newFakeClient(append(tc.initialObjs, sandbox)...),
+				Scheme: Scheme,
+				Tracer: asmetrics.NewNoOp(),
+			}
+
+			err := errors.Join(
+				r.reconcileConfigMaps(t.Context(), sandbox, nameHash),
+				r.reconcileSecrets(t.Context(), sandbox, nameHash),
+			)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				// Objects the sandbox does not control are left untouched.
+				for _, obj := range tc.initialObjs {
+					switch want := obj.(type) {
+					case *corev1.ConfigMap:
+						live := &corev1.ConfigMap{}
+						require.NoError(t, r.Get(t.Context(), client.ObjectKeyFromObject(want), live))
+						assert.Equal(t, want.Data, live.Data)
+						assert.Equal(t, want.OwnerReferences, live.OwnerReferences)
+					case *corev1.Secret:
+						live := &corev1.Secret{}
+						require.NoError(t, r.Get(t.Context(), client.ObjectKeyFromObject(want), live))
+						assert.Equal(t, want.Data, live.Data)
+						assert.Equal(t, want.OwnerReferences, live.OwnerReferences)
+					}
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			liveCM := &corev1.ConfigMap{}
+			require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: cmName, Namespace: sandboxNs}, liveCM))
+			assert.Equal(t, map[string]string{"config.yaml": "model: small"}, liveCM.Data)
+			liveSecret := &corev1.Secret{}
+			require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: secretName, Namespace: sandboxNs}, liveSecret))
+			assert.Equal(t, map[string][]byte{"ca.crt": []byte("ca"), "token": []byte("s3cr3t")}, liveSecret.Data)
+			for _, obj := range []client.Object{liveCM, liveSecret} {
+				ownerRef := metav1.GetControllerOf(obj)
+				require.NotNil(t, ownerRef, "%s should have a controller owner reference", obj.GetName())
+				assert.Equal(t, sandboxUID, ownerRef.UID)
+			}
+			if len(tc.initialObjs) == 0 {
+				assert.Equal(t, nameHash, liveCM.Labels[sandboxLabel])
+				assert.Equal(t, "agent", liveCM.Labels["app"])
+				assert.Equal(t, corev1.SecretTypeOpaque, liveSecret.Type)
+			}
+		})
+	}
+
+	t.Run("mounts the objects into the pod as volumes", func(t *testing.T) {
+		r := SandboxReconciler{Scheme: Scheme, Tracer: asmetrics.NewNoOp()}
+		pod, err := r.buildPod(t.Context(), sandbox, nameHash)
+		require.NoError(t, err)
+		assert.Equal(t, []corev1.Volume{
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+			}}},
+			{Name: "token", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}},
+		}, pod.Spec.Volumes)
+	})
+}
+
 func TestSandboxExpiry(t *testing.T) {
 	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
 
@@ -3857,6 +4520,53 @@ func TestSandboxExpiry(t *testing.T) {
 	}
 }
 
+func TestExpiresIn(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		shutdownTime *metav1.Time
+		want         string
+		wantRefresh  time.Duration
+	}{
+		{
+			name: "nil shutdown time",
+		},
+		{
+			name:         "shutdown time in past",
+			shutdownTime: new(metav1.NewTime(now.Add(-time.Minute))),
+		},
+		{
+			name:         "refreshes at the minimum interval when close to expiry",
+			shutdownTime: new(metav1.NewTime(now.Add(90 * time.Second))),
+			want:         "90s",
+			wantRefresh:  30 * time.Second,
+		},
+		{
+			name:         "refreshes at a tenth of the time left",
+			shutdownTime: new(metav1.NewTime(now.Add(2 * time.Hour))),
+			want:         "120m",
+			wantRefresh:  12 * time.Minute,
+		},
+		{
+			name:         "refreshes at the maximum interval when far from expiry",
+			shutdownTime: new(metav1.NewTime(now.Add(72 * time.Hour))),
+			want:         "3d",
+			wantRefresh:  time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sandbox := &sandboxv1beta1.Sandbox{}
+			sandbox.Spec.ShutdownTime = tc.shutdownTime
+			got, refresh := expiresIn(sandbox, now)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantRefresh, refresh)
+		})
+	}
+}
+
 func TestSandboxShutdownExpiryUsesTwoPassAndPreservesFinishedCondition(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -4012,7 +4722,7 @@ func TestSetServiceStatusCustomDomain(t *testing.T) {
 	}
 }
 
-func TestMergeVolumeClaimVolumes(t *testing.T) {
+func TestMergeGeneratedVolumes(t *testing.T) {
 	pvcVol := corev1.Volume{
 		Name: "data",
 		VolumeSource: corev1.VolumeSource{
@@ -4028,7 +4738,7 @@ func TestMergeVolumeClaimVolumes(t *testing.T) {
 			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
 		}
 
-		result := MergeVolumeClaimVolumes(existing, []corev1.Volume{pvcVol})
+		result := MergeGeneratedVolumes(existing, []corev1.Volume{pvcVol})
 
 		require.Len(t, result, 2)
 		// config preserved
@@ -4044,19 +4754,19 @@ func TestMergeVolumeClaimVolumes(t *testing.T) {
 			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
 		}
 
-		result := MergeVolumeClaimVolumes(existing, []corev1.Volume{pvcVol})
+		result := MergeGeneratedVolumes(existing, []corev1.Volume{pvcVol})
 
 		require.Len(t, result, 2)
 		require.Equal(t, "config", result[0].Name)
 		require.Equal(t, "data", result[1].Name)
 	})
 
-	t.Run("no-op when pvcVolumes is empty", func(t *testing.T) {
+	t.Run("no-op when no volumes are generated", func(t *testing.T) {
 		existing := []corev1.Volume{
 			{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		}
 
-		result := MergeVolumeClaimVolumes(existing, nil)
+		result := MergeGeneratedVolumes(existing, nil)
 
 		require.Len(t, result, 1)
 		require.Equal(t, "data", result[0].Name)
@@ -4207,65 +4917,6 @@ func TestReconcile_TracingNormalization(t *testing.T) {
 	require.Equal(t, "unknown", mt.capturedAttrs[sandboxv1beta1.CreatedByLabel], "created-by label must be normalized in span attributes")
 }
 
-func TestNameHash_Correctness(t *testing.T) {
-	// Verify the fast hex encoding produces the same output as the
-	// reference implementation (fmt.Sprintf("%08x", ...)).
-	cases := []string{
-		"",
-		"a",
-		"my-sandbox",
-		"test-template-custom",
-		"pool",
-		"sandbox-name-with-a-very-long-label-value",
-	}
-
-	// Supplement with 100 randomized DNS-label-shaped strings so bit
-	// manipulation is exercised across a broader input distribution.
-	// Seeded for reproducibility.
-	rng := rand.New(rand.NewPCG(42, 0))
-	const dnsLabelChars = "abcdefghijklmnopqrstuvwxyz0123456789-"
-	for range 100 {
-		n := rng.IntN(63) + 1 // length in [1, 63]
-		var buf [63]byte
-		for i := range n {
-			buf[i] = dnsLabelChars[rng.IntN(len(dnsLabelChars))]
-		}
-		cases = append(cases, string(buf[:n]))
-	}
-
-	for _, name := range cases {
-		got := NameHash(name)
-		if len(got) != 8 {
-			t.Errorf("NameHash(%q) length = %d, want 8", name, len(got))
-		}
-		// Verify all chars are lowercase hex digits.
-		for i, c := range got {
-			if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
-				t.Errorf("NameHash(%q)[%d] = %c, want hex digit", name, i, c)
-			}
-		}
-		// Cross-check against GetNumericHash.
-		want := fmt.Sprintf("%08x", GetNumericHash(name))
-		if got != want {
-			t.Errorf("NameHash(%q) = %q, want %q", name, got, want)
-		}
-	}
-}
-
-func BenchmarkNameHashNew(b *testing.B) {
-	b.ReportAllocs()
-	for i := 0; i < b.N; i++ {
-		_ = NameHash("my-sandbox-name")
-	}
-}
-
-func BenchmarkNameHashOld(b *testing.B) {
-	b.ReportAllocs()
-	for i := 0; i < b.N; i++ {
-		_ = fmt.Sprintf("%08x", GetNumericHash("my-sandbox-name"))
-	}
-}
-
 // TestReconcileCoalescesNodeNameStatusWrite verifies that a status change
 // consisting only of the scheduled pod's node name is not written in its own
 // API request: the node name rides along with the next status write instead,
@@ -4353,3 +5004,2039 @@ func TestReconcileCoalescesNodeNameStatusWrite(t *testing.T) {
 	require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
 	assert.Equal(t, "node-2", live.Status.NodeName, "node changes on a Ready sandbox must be written immediately")
 }
+
+func TestReconcilePodRecreatesPodOnPodSpecChange(t *testing.T) {
+	sandboxName := "sandbox-name"
+	sandboxNs := "sandbox-ns"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sandboxName, Namespace: sandboxNs}}
+
+	newSandbox := func(image string, annotations map[string]string) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = sandboxName
+		sb.Namespace = sandboxNs
+		sb.UID = sandboxUID
+		sb.Generation = 1
+		sb.Annotations = annotations
+		sb.Spec = sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+			PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: image}}},
+			},
+		}}
+		return sb
+	}
+
+	readyCondition := func(t *testing.T, r *SandboxReconciler) *metav1.Condition {
+		t.Helper()
+		live := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		return meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	}
+
+	updateImage := func(t *testing.T, r *SandboxReconciler, image string) {
+		t.Helper()
+		live := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		live.Spec.PodTemplate.Spec.Containers[0].Image = image
+		require.NoError(t, r.Update(t.Context(), live))
+	}
+
+	t.Run("recreates pod after pod template spec change", func(t *testing.T) {
+		r := &SandboxReconciler{
+			Client: newFakeClient(newSandbox("image:v1", nil)),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		original := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, original))
+		require.NotEmpty(t, original.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation])
+
+		updateImage(t, r, "image:v2")
+
+		// First pass deletes the outdated pod and reports the rollover.
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		err = r.Get(t.Context(), req.NamespacedName, &corev1.Pod{})
+		require.True(t, k8serrors.IsNotFound(err), "expected outdated pod to be deleted, got %v", err)
+		cond := readyCondition(t, r)
+		require.NotNil(t, cond)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonPodRecreating, cond.Reason)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+
+		// Second pass creates the replacement from the new template.
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		replacement := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, replacement))
+		assert.Equal(t, "image:v2", replacement.Spec.Containers[0].Image)
+		assert.NotEqual(t, original.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation],
+			replacement.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation])
+	})
+
+	t.Run("keeps pod when recreation is disabled", func(t *testing.T) {
+		r := &SandboxReconciler{
+			Client: newFakeClient(newSandbox("image:v1", map[string]string{
+				sandboxv1beta1.SandboxDisablePodRecreationAnnotation: "true",
+			})),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		updateImage(t, r, "image:v2")
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, pod))
+		assert.Equal(t, "image:v1", pod.Spec.Containers[0].Image)
+		cond := readyCondition(t, r)
+		require.NotNil(t, cond)
+		assert.NotEqual(t, sandboxv1beta1.SandboxReasonPodRecreating, cond.Reason)
+	})
+
+	t.Run("keeps pod without a recorded pod spec hash", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            sandboxName,
+				Namespace:       sandboxNs,
+				Labels:          map[string]string{sandboxLabel: NameHash(sandboxName)},
+				OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "image:v1"}}},
+		}
+		r := &SandboxReconciler{
+			Client: newFakeClient(newSandbox("image:v2", nil), pod),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		live := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		assert.Equal(t, "image:v1", live.Spec.Containers[0].Image)
+	})
+
+	t.Run("does not delete a pod that is already terminating", func(t *testing.T) {
+		sb := newSandbox("image:v2", nil)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              sandboxName,
+				Namespace:         sandboxNs,
+				Labels:            map[string]string{sandboxLabel: NameHash(sandboxName)},
+				Annotations:       map[string]string{sandboxv1beta1.SandboxPodSpecHashAnnotation: "outdated"},
+				OwnerReferences:   []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				Finalizers:        []string{"test/finalizer"},
+			},
+		}
+		r := &SandboxReconciler{
+			Client: newFakeClient(sb, pod),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		got, err := r.reconcilePod(t.Context(), sb, NameHash(sandboxName))
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		live := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		assert.Equal(t, "outdated", live.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation])
+		assert.Equal(t, sandboxv1beta1.SandboxReasonPodRecreating, r.computeReadyCondition(sb, nil, nil, got).Reason)
+	})
+}
+
+func TestSandboxPodDriftedCondition(t *testing.T) {
+	sandboxName := "sandbox-name"
+	sandboxNs := "sandbox-ns"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sandboxName, Namespace: sandboxNs}}
+
+	newReconciler := func() *SandboxReconciler {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = sandboxName
+		sb.Namespace = sandboxNs
+		sb.UID = sandboxUID
+		sb.Generation = 1
+		sb.Annotations = map[string]string{sandboxv1beta1.SandboxDisablePodRecreationAnnotation: "true"}
+		sb.Spec = sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+			PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "image:v1"}}},
+			},
+		}}
+		return &SandboxReconciler{
+			Client: newFakeClient(sb),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+	}
+
+	// reconcile runs a pass and returns the live Sandbox.
+	reconcile := func(t *testing.T, r *SandboxReconciler) *sandboxv1beta1.Sandbox {
+		t.Helper()
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		live := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		return live
+	}
+
+	patchPod := func(t *testing.T, r *SandboxReconciler, mutate func(*corev1.Pod)) {
+		t.Helper()
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, pod))
+		mutate(pod)
+		require.NoError(t, r.Update(t.Context(), pod))
+	}
+
+	t.Run("reports an externally patched image", func(t *testing.T) {
+		r := newReconciler()
+		live := reconcile(t, r)
+		applied := live.Status.AppliedPodTemplateHash
+		require.NotEmpty(t, applied)
+		assert.Nil(t, meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted)))
+
+		patchPod(t, r, func(pod *corev1.Pod) { pod.Spec.Containers[0].Image = "image:patched" })
+		live = reconcile(t, r)
+		cond := meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted))
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonPodImageMismatch, cond.Reason)
+		assert.Contains(t, cond.Message, "image:patched")
+		assert.Equal(t, applied, live.Status.AppliedPodTemplateHash)
+
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, pod))
+		assert.Equal(t, "image:patched", pod.Spec.Containers[0].Image, "drift detection must not modify the pod")
+
+		patchPod(t, r, func(pod *corev1.Pod) { pod.Spec.Containers[0].Image = "image:v1" })
+		live = reconcile(t, r)
+		assert.Nil(t, meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted)))
+	})
+
+	t.Run("reports an externally patched pod-spec-hash annotation", func(t *testing.T) {
+		r := newReconciler()
+		applied := reconcile(t, r).Status.AppliedPodTemplateHash
+
+		patchPod(t, r, func(pod *corev1.Pod) {
+			pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation] = "tampered"
+		})
+		live := reconcile(t, r)
+		cond := meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted))
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonPodTemplateHashMismatch, cond.Reason)
+		assert.Equal(t, applied, live.Status.AppliedPodTemplateHash)
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, &corev1.Pod{}))
+	})
+
+	t.Run("does not report a pod built from an earlier spec", func(t *testing.T) {
+		r := newReconciler()
+		live := reconcile(t, r)
+		live.Spec.PodTemplate.Spec.Containers[0].Image = "image:v2"
+		require.NoError(t, r.Update(t.Context(), live))
+
+		live = reconcile(t, r)
+		assert.Nil(t, meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionPodDrifted)))
+	})
+}
+
+func TestReconcilePodInjectsSandboxEnv(t *testing.T) {
+	newSandbox := func(injectEnv bool) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = "sandbox-name"
+		sb.Namespace = "sandbox-ns"
+		sb.UID = sandboxUID
+		sb.Spec = sandboxv1beta1.SandboxSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+				PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{Name: "setup"}},
+						Containers: []corev1.Container{
+							{Name: "agent", Env: []corev1.EnvVar{{Name: "SANDBOX_FQDN", Value: "agent.example.com"}}},
+							{Name: "sidecar"},
+						},
+					},
+				},
+			},
+			InjectEnv: injectEnv,
+		}
+		return sb
+	}
+
+	t.Run("injects env without overriding existing variables", func(t *testing.T) {
+		sb := newSandbox(true)
+		r := &SandboxReconciler{
+			Client:        newFakeClient(sb),
+			Scheme:        Scheme,
+			Tracer:        asmetrics.NewNoOp(),
+			ClusterDomain: "example.internal",
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+
+		injected := []corev1.EnvVar{
+			{Name: "SANDBOX_NAME", Value: "sandbox-name"},
+			{Name: "SANDBOX_NAMESPACE", Value: "sandbox-ns"},
+			{Name: "SANDBOX_FQDN", Value: "sandbox-name.sandbox-ns.svc.example.internal"},
+		}
+		assert.Equal(t, injected, pod.Spec.InitContainers[0].Env)
+		assert.Equal(t, injected, pod.Spec.Containers[1].Env)
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "SANDBOX_FQDN", Value: "agent.example.com"},
+			{Name: "SANDBOX_NAME", Value: "sandbox-name"},
+			{Name: "SANDBOX_NAMESPACE", Value: "sandbox-ns"},
+		}, pod.Spec.Containers[0].Env)
+
+		// The Sandbox's own pod template is not modified.
+		assert.Len(t, sb.Spec.PodTemplate.Spec.Containers[0].Env, 1)
+		assert.Empty(t, sb.Spec.PodTemplate.Spec.Containers[1].Env)
+	})
+
+	t.Run("does not inject env by default", func(t *testing.T) {
+		sb := newSandbox(false)
+		r := &SandboxReconciler{
+			Client:        newFakeClient(sb),
+			Scheme:        Scheme,
+			Tracer:        asmetrics.NewNoOp(),
+			ClusterDomain: "example.internal",
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+		assert.Empty(t, pod.Spec.InitContainers[0].Env)
+		assert.Equal(t, []corev1.EnvVar{{Name: "SANDBOX_FQDN", Value: "agent.example.com"}}, pod.Spec.Containers[0].Env)
+		assert.Empty(t, pod.Spec.Containers[1].Env)
+	})
+}
+
+func TestReconcilePodAppliesDefaultResources(t *testing.T) {
+	defaults := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "setup"}},
+		Containers: []corev1.Container{
+			{Name: "bare"},
+			{Name: "partial", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			}},
+			{Name: "above-default-limit", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}},
+			{Name: "limit-only", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+			}},
+		},
+	}
+
+	t.Run("fills only the gaps", func(t *testing.T) {
+		sb := newPodSpecSandbox(podSpec)
+		r := &SandboxReconciler{
+			Client:           newFakeClient(sb),
+			Scheme:           Scheme,
+			Tracer:           asmetrics.NewNoOp(),
+			DefaultResources: defaults,
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+
+		assert.Equal(t, defaults, pod.Spec.InitContainers[0].Resources)
+		assert.Equal(t, defaults, pod.Spec.Containers[0].Resources)
+		// The declared CPU request and memory limit are kept; the memory limit
+		// already implies a memory request.
+		assert.Equal(t, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		}, pod.Spec.Containers[1].Resources)
+		// A CPU limit below the container's own request would be rejected.
+		assert.Equal(t, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}, pod.Spec.Containers[2].Resources)
+		assert.Equal(t, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}, pod.Spec.Containers[3].Resources)
+
+		// The Sandbox's own pod template is not modified, so the defaults don't
+		// count as drift from it.
+		assert.Equal(t, podSpec, sb.Spec.PodTemplate.Spec)
+		wantHash, err := computePodSpecHash(&sb.Spec.PodTemplate)
+		require.NoError(t, err)
+		assert.Equal(t, wantHash, pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation])
+	})
+
+	t.Run("leaves resources alone without defaults", func(t *testing.T) {
+		sb := newPodSpecSandbox(podSpec)
+		r := &SandboxReconciler{
+			Client: newFakeClient(sb),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+		assert.Equal(t, sb.Spec.PodTemplate.Spec.InitContainers[0].Resources, pod.Spec.InitContainers[0].Resources)
+		for i, c := range sb.Spec.PodTemplate.Spec.Containers {
+			assert.Equal(t, c.Resources, pod.Spec.Containers[i].Resources, "container %s", c.Name)
+		}
+	})
+}
+
+func TestReconcilePodHardensSecurityContext(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "setup"}},
+		Containers: []corev1.Container{
+			{Name: "bare"},
+			{Name: "overridden", SecurityContext: &corev1.SecurityContext{
+				Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+				AllowPrivilegeEscalation: new(true),
+				ReadOnlyRootFilesystem:   new(false),
+			}},
+			{Name: "privileged", SecurityContext: &corev1.SecurityContext{Privileged: new(true)}},
+			{Name: "own-tmp", VolumeMounts: []corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp/"}}},
+		},
+		Volumes: []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+	}
+	hardened := &corev1.SecurityContext{
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		AllowPrivilegeEscalation: new(false),
+		ReadOnlyRootFilesystem:   new(true),
+	}
+	tmpMount := corev1.VolumeMount{Name: hardenedTmpVolume, MountPath: "/tmp"}
+
+	t.Run("injects defaults", func(t *testing.T) {
+		sb := newPodSpecSandbox(podSpec)
+		r := &SandboxReconciler{
+			Client:     newFakeClient(sb),
+			Scheme:     Scheme,
+			Tracer:     asmetrics.NewNoOp(),
+			HardenPods: true,
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+
+		assert.Equal(t, &corev1.PodSecurityContext{
+			RunAsNonRoot:   new(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}, pod.Spec.SecurityContext)
+		for _, c := range []corev1.Container{pod.Spec.InitContainers[0], pod.Spec.Containers[0]} {
+			assert.Equal(t, hardened, c.SecurityContext, "container %s", c.Name)
+			assert.Contains(t, c.VolumeMounts, tmpMount, "container %s", c.Name)
+		}
+
+		// Fields the template sets are kept, and a writable root filesystem
+		// needs no /tmp volume.
+		assert.Equal(t, sb.Spec.PodTemplate.Spec.Containers[1].SecurityContext, pod.Spec.Containers[1].SecurityContext)
+		assert.Empty(t, pod.Spec.Containers[1].VolumeMounts)
+
+		// A privileged container can't disallow privilege escalation.
+		privileged := pod.Spec.Containers[2].SecurityContext
+		assert.Nil(t, privileged.AllowPrivilegeEscalation)
+		assert.Equal(t, hardened.Capabilities, privileged.Capabilities)
+		assert.Equal(t, new(true), privileged.ReadOnlyRootFilesystem)
+
+		// A container that already mounts /tmp keeps its own volume.
+		assert.Equal(t, new(true), pod.Spec.Containers[3].SecurityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, sb.Spec.PodTemplate.Spec.Containers[3].VolumeMounts, pod.Spec.Containers[3].VolumeMounts)
+
+		tmpVolumes := 0
+		for _, v := range pod.Spec.Volumes {
+			if v.Name == hardenedTmpVolume {
+				tmpVolumes++
+				assert.NotNil(t, v.EmptyDir)
+			}
+		}
+		assert.Equal(t, 1, tmpVolumes)
+
+		// The Sandbox's own pod template is not modified, so the hardening
+		// doesn't count as drift from it.
+		assert.Equal(t, podSpec, sb.Spec.PodTemplate.Spec)
+		wantHash, err := computePodSpecHash(&sb.Spec.PodTemplate)
+		require.NoError(t, err)
+		assert.Equal(t, wantHash, pod.Annotations[sandboxv1beta1.SandboxPodSpecHashAnnotation])
+	})
+
+	t.Run("pod template overrides win", func(t *testing.T) {
+		sb := newPodSpecSandbox(podSpec)
+		localhost := &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: new("profiles/agent.json")}
+		sb.Spec.PodTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{
+			RunAsNonRoot:   new(false),
+			SeccompProfile: localhost,
+		}
+		r := &SandboxReconciler{
+			Client:     newFakeClient(sb),
+			Scheme:     Scheme,
+			Tracer:     asmetrics.NewNoOp(),
+			HardenPods: true,
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+		assert.Equal(t, &corev1.PodSecurityContext{RunAsNonRoot: new(false), SeccompProfile: localhost}, pod.Spec.SecurityContext)
+	})
+
+	t.Run("leaves pods alone when disabled", func(t *testing.T) {
+		sb := newPodSpecSandbox(podSpec)
+		r := &SandboxReconciler{
+			Client: newFakeClient(sb),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		pod, err := r.reconcilePod(t.Context(), sb, NameHash(sb.Name))
+		require.NoError(t, err)
+		require.NotNil(t, pod)
+		assert.Nil(t, pod.Spec.SecurityContext)
+		assert.Equal(t, sb.Spec.PodTemplate.Spec.Volumes, pod.Spec.Volumes)
+		for i, c := range sb.Spec.PodTemplate.Spec.Containers {
+			assert.Equal(t, c.SecurityContext, pod.Spec.Containers[i].SecurityContext, "container %s", c.Name)
+		}
+	})
+}
+
+func TestReconcilePodSetsHostnameAndSubdomain(t *testing.T) {
+	newSandbox := func(name string, mutate func(*sandboxv1beta1.Sandbox)) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = name
+		sb.Namespace = "sandbox-ns"
+		sb.UID = sandboxUID
+		sb.Generation = 1
+		sb.Spec.PodTemplate.Spec.Containers = []corev1.Container{{Name: "agent"}}
+		if mutate != nil {
+			mutate(sb)
+		}
+		return sb
+	}
+
+	testCases := []struct {
+		name          string
+		sandbox       *sandboxv1beta1.Sandbox
+		wantHostname  string
+		wantSubdomain string
+	}{
+		{
+			name:          "defaults to the sandbox and service names",
+			sandbox:       newSandbox("sandbox-name", nil),
+			wantHostname:  "sandbox-name",
+			wantSubdomain: "sandbox-name",
+		},
+		{
+			name: "uses spec.serviceName as the subdomain",
+			sandbox: newSandbox("sandbox-name", func(sb *sandboxv1beta1.Sandbox) {
+				sb.Spec.ServiceName = "stable-dns"
+			}),
+			wantHostname:  "sandbox-name",
+			wantSubdomain: "stable-dns",
+		},
+		{
+			name: "keeps a hostname and subdomain set in the pod template",
+			sandbox: newSandbox("sandbox-name", func(sb *sandboxv1beta1.Sandbox) {
+				sb.Spec.PodTemplate.Spec.Hostname = "agent"
+				sb.Spec.PodTemplate.Spec.Subdomain = "agents"
+			}),
+			wantHostname:  "agent",
+			wantSubdomain: "agents",
+		},
+		{
+			name:          "leaves the hostname unset when the sandbox name is not a DNS label",
+			sandbox:       newSandbox("sandbox.name", nil),
+			wantHostname:  "",
+			wantSubdomain: "sandbox.name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &SandboxReconciler{
+				Client: newFakeClient(tc.sandbox),
+				Scheme: Scheme,
+				Tracer: asmetrics.NewNoOp(),
+			}
+			pod, err := r.reconcilePod(t.Context(), tc.sandbox, NameHash(tc.sandbox.Name))
+			require.NoError(t, err)
+			require.NotNil(t, pod)
+			assert.Equal(t, tc.wantHostname, pod.Spec.Hostname)
+			assert.Equal(t, tc.wantSubdomain, pod.Spec.Subdomain)
+		})
+	}
+
+	t.Run("reports the pod FQDN while the service exists", func(t *testing.T) {
+		sb := newSandbox("sandbox-name", func(sb *sandboxv1beta1.Sandbox) {
+			sb.Spec.Service = new(true)
+			sb.Spec.ServiceName = "stable-dns"
+		})
+		r := &SandboxReconciler{
+			Client:        newFakeClient(sb),
+			Scheme:        Scheme,
+			Tracer:        asmetrics.NewNoOp(),
+			ClusterDomain: "cluster.local",
+		}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sb.Name, Namespace: sb.Namespace}}
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		live := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		assert.Equal(t, "sandbox-name.stable-dns.sandbox-ns.svc.cluster.local", live.Status.PodFQDN)
+
+		live.Spec.Service = new(false)
+		require.NoError(t, r.Update(t.Context(), live))
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		assert.Empty(t, live.Status.PodFQDN)
+	})
+}
+
+func TestMetadataPropagation(t *testing.T) {
+	newSandbox := func() *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = "sandbox-name"
+		sb.Namespace = "sandbox-ns"
+		sb.UID = sandboxUID
+		sb.Labels = map[string]string{
+			"team":                   "platform",
+			"example.com/tier":       "gold",
+			"unlisted":               "value",
+			"agents.x-k8s.io/custom": "reserved",
+		}
+		sb.Annotations = map[string]string{
+			"owner":    "alice",
+			"unlisted": "value",
+		}
+		sb.Spec = sandboxv1beta1.SandboxSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+				PodTemplate: sandboxv1beta1.PodTemplate{
+					ObjectMeta: sandboxv1beta1.PodMetadata{
+						Labels: map[string]string{"team": "from-template"},
+					},
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent"}}},
+				},
+				Service: new(true),
+			},
+		}
+		return sb
+	}
+	reconcileChildren := func(t *testing.T, r *SandboxReconciler, sb *sandboxv1beta1.Sandbox) (*corev1.Pod, *corev1.Service) {
+		t.Helper()
+		nameHash := NameHash(sb.Name)
+		_, err := r.reconcilePod(t.Context(), sb, nameHash)
+		require.NoError(t, err)
+		_, err = r.reconcileService(t.Context(), sb, nameHash)
+		require.NoError(t, err)
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: sb.Name, Namespace: sb.Namespace}, pod))
+		service := &corev1.Service{}
+		require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: sb.Name, Namespace: sb.Namespace}, service))
+		return pod, service
+	}
+
+	t.Run("copies no sandbox metadata by default", func(t *testing.T) {
+		sb := newSandbox()
+		r := &SandboxReconciler{Client: newFakeClient(sb), Scheme: Scheme, Tracer: asmetrics.NewNoOp()}
+
+		pod, service := reconcileChildren(t, r, sb)
+		assert.Equal(t, "from-template", pod.Labels["team"])
+		assert.NotContains(t, pod.Labels, "example.com/tier")
+		assert.NotContains(t, pod.Annotations, "owner")
+		assert.Equal(t, map[string]string{sandboxLabel: NameHash(sb.Name)}, service.Labels)
+		assert.Empty(t, service.Annotations)
+	})
+
+	t.Run("copies allowlisted metadata onto the pod and service", func(t *testing.T) {
+		sb := newSandbox()
+		r := &SandboxReconciler{
+			Client:               newFakeClient(sb),
+			Scheme:               Scheme,
+			Tracer:               asmetrics.NewNoOp(),
+			PropagateLabels:      ParseMetadataAllowlist(" team, example.com/* ,agents.x-k8s.io/*"),
+			PropagateAnnotations: ParseMetadataAllowlist("owner"),
+		}
+
+		pod, service := reconcileChildren(t, r, sb)
+		// The pod template wins over the Sandbox's own labels.
+		assert.Equal(t, "from-template", pod.Labels["team"])
+		assert.Equal(t, "gold", pod.Labels["example.com/tier"])
+		assert.Equal(t, "alice", pod.Annotations["owner"])
+		for _, metadata := range []map[string]string{pod.Labels, pod.Annotations} {
+			assert.NotContains(t, metadata, "unlisted")
+			assert.NotContains(t, metadata, "agents.x-k8s.io/custom")
+		}
+
+		assert.Equal(t, map[string]string{
+			sandboxLabel:       NameHash(sb.Name),
+			"team":             "platform",
+			"example.com/tier": "gold",
+		}, service.Labels)
+		assert.Equal(t, map[string]string{
+			"owner": "alice",
+			sandboxv1beta1.SandboxPropagatedLabelsAnnotation:      "example.com/tier,team",
+			sandboxv1beta1.SandboxPropagatedAnnotationsAnnotation: "owner",
+		}, service.Annotations)
+
+		// Keys removed from the Sandbox are removed from its children.
+		delete(sb.Labels, "example.com/tier")
+		delete(sb.Annotations, "owner")
+		pod, service = reconcileChildren(t, r, sb)
+		assert.NotContains(t, pod.Labels, "example.com/tier")
+		assert.NotContains(t, pod.Annotations, "owner")
+		assert.Equal(t, map[string]string{
+			sandboxLabel: NameHash(sb.Name),
+			"team":       "platform",
+		}, service.Labels)
+		assert.Equal(t, map[string]string{
+			sandboxv1beta1.SandboxPropagatedLabelsAnnotation: "team",
+		}, service.Annotations)
+	})
+}
+
+func TestMetadataAllowlist(t *testing.T) {
+	allowlist := ParseMetadataAllowlist("team,example.com/*")
+	assert.Equal(t, MetadataAllowlist{"team", "example.com/*"}, allowlist)
+	assert.True(t, allowlist.Allows("team"))
+	assert.True(t, allowlist.Allows("example.com/tier"))
+	assert.False(t, allowlist.Allows("teams"))
+	assert.False(t, allowlist.Allows("example.org/tier"))
+	assert.Empty(t, ParseMetadataAllowlist(" , "))
+	assert.False(t, ParseMetadataAllowlist("").Allows("team"))
+}
+
+func TestReconcilePodRestartPolicy(t *testing.T) {
+	sandboxName := "sandbox-name"
+	sandboxNs := "sandbox-ns"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sandboxName, Namespace: sandboxNs}}
+
+	newSandbox := func(policy *sandboxv1beta1.SandboxRestartPolicy, annotations map[string]string) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{}
+		sb.Name = sandboxName
+		sb.Namespace = sandboxNs
+		sb.UID = sandboxUID
+		sb.Generation = 1
+		sb.Annotations = annotations
+		sb.Spec = sandboxv1beta1.SandboxSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+				PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+				},
+			},
+			RestartPolicy: policy,
+		}
+		return sb
+	}
+
+	newPod := func(uid types.UID, restarts int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            sandboxName,
+				Namespace:       sandboxNs,
+				UID:             uid,
+				Labels:          map[string]string{sandboxLabel: NameHash(sandboxName)},
+				OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sandboxName)},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "test-container", RestartCount: restarts}},
+			},
+		}
+	}
+
+	setRestarts := func(t *testing.T, r *SandboxReconciler, restarts int32) {
+		t.Helper()
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, pod))
+		pod.Status.ContainerStatuses[0].RestartCount = restarts
+		require.NoError(t, r.Status().Update(t.Context(), pod))
+	}
+
+	liveSandbox := func(t *testing.T, r *SandboxReconciler) *sandboxv1beta1.Sandbox {
+		t.Helper()
+		live := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, live))
+		return live
+	}
+
+	recreatePod := &sandboxv1beta1.SandboxRestartPolicy{
+		Type:             sandboxv1beta1.SandboxRestartPolicyRecreatePod,
+		RestartThreshold: new(int32(2)),
+		MaxRecreates:     new(int32(1)),
+	}
+
+	t.Run("recreates a restarting pod until the limit is reached", func(t *testing.T) {
+		recorder := events.NewFakeRecorder(10)
+		r := &SandboxReconciler{
+			Client:   newFakeClient(newSandbox(recreatePod, nil), newPod("pod-1", 0)),
+			Scheme:   Scheme,
+			Tracer:   asmetrics.NewNoOp(),
+			Recorder: recorder,
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(liveSandbox(t, r).Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation], "pod-1,"))
+
+		setRestarts(t, r, 2)
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		err = r.Get(t.Context(), req.NamespacedName, &corev1.Pod{})
+		require.True(t, k8serrors.IsNotFound(err), "expected restarting pod to be deleted, got %v", err)
+		live := liveSandbox(t, r)
+		assert.Equal(t, "1", live.Annotations[sandboxv1beta1.SandboxPodRecreateAttemptsAnnotation])
+		assert.NotContains(t, live.Annotations, sandboxv1beta1.SandboxRestartWindowAnnotation)
+		assert.Contains(t, drainEvents(recorder), "Warning PodRecreated Deleted Pod \"sandbox-name\" after 2 restarts within 10m0s (recreate 1 of 1)")
+
+		// The replacement keeps crashing; with the limit used up it is left in place.
+		require.NoError(t, r.Create(t.Context(), newPod("pod-2", 0)))
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		setRestarts(t, r, 3)
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+
+		pod := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, pod))
+		assert.Equal(t, types.UID("pod-2"), pod.UID)
+		cond := meta.FindStatusCondition(liveSandbox(t, r).Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonRecreateLimitExceeded, cond.Reason)
+		assert.Equal(t, "Pod restarted 3 times after 1 recreations; not recreating it again", cond.Message)
+	})
+
+	t.Run("starts a new window once the current one expires", func(t *testing.T) {
+		start := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		r := &SandboxReconciler{
+			Client: newFakeClient(newSandbox(recreatePod, map[string]string{
+				sandboxv1beta1.SandboxRestartWindowAnnotation: "pod-1," + start + ",0",
+			}), newPod("pod-1", 1)),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		w, ok := parseRestartWindow(liveSandbox(t, r).Annotations[sandboxv1beta1.SandboxRestartWindowAnnotation])
+		require.True(t, ok)
+		assert.Equal(t, int32(1), w.baseline)
+		assert.WithinDuration(t, time.Now(), w.start, time.Minute)
+
+		// A restart in the new window stays below the threshold.
+		setRestarts(t, r, 2)
+		_, err = r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, &corev1.Pod{}))
+	})
+
+	t.Run("leaves restarting pods alone by default", func(t *testing.T) {
+		r := &SandboxReconciler{
+			Client: newFakeClient(newSandbox(nil, nil), newPod("pod-1", 50)),
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(t.Context(), req.NamespacedName, &corev1.Pod{}))
+		live := liveSandbox(t, r)
+		assert.NotContains(t, live.Annotations, sandboxv1beta1.SandboxRestartWindowAnnotation)
+		assert.NotContains(t, live.Annotations, sandboxv1beta1.SandboxPodRecreateAttemptsAnnotation)
+	})
+}
+
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var got []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func TestReconcileEmitsEvents(t *testing.T) {
+	sbName := "events-sandbox"
+	sbNs := "default"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	t.Run("child resources created", func(t *testing.T) {
+		sandbox := &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+					VolumeClaimTemplates: []sandboxv1beta1.PersistentVolumeClaimTemplate{{
+						EmbeddedObjectMetadata: sandboxv1beta1.EmbeddedObjectMetadata{Name: "data"},
+					}},
+					Service: ptr.To(true),
+				},
+				OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning,
+			},
+		}
+		recorder := events.NewFakeRecorder(10)
+		r := &SandboxReconciler{
+			Client:   newFakeClient(sandbox),
+			Scheme:   Scheme,
+			Tracer:   asmetrics.NewNoOp(),
+			Recorder: recorder,
+		}
+
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+
+		got := drainEvents(recorder)
+		assert.Contains(t, got, fmt.Sprintf("Normal PVCCreated Created PersistentVolumeClaim %q", "data-"+sbName))
+		assert.Contains(t, got, fmt.Sprintf("Normal PodCreated Created Pod %q", sbName))
+		assert.Contains(t, got, fmt.Sprintf("Normal ServiceCreated Created Service %q", sbName))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		shutdownTime := metav1.NewTime(time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC))
+		sandbox := &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+				Lifecycle: sandboxv1beta1.Lifecycle{
+					ShutdownTime:   &shutdownTime,
+					ShutdownPolicy: ptr.To(sandboxv1beta1.ShutdownPolicyRetain),
+				},
+				OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning,
+			},
+		}
+		recorder := events.NewFakeRecorder(10)
+		r := &SandboxReconciler{
+			Client:   newFakeClient(sandbox),
+			Scheme:   Scheme,
+			Tracer:   asmetrics.NewNoOp(),
+			Recorder: recorder,
+		}
+
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		_, err = r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+
+		got := drainEvents(recorder)
+		require.Equal(t, []string{"Normal SandboxExpired Sandbox expired at 2026-01-02T03:04:05Z"}, got,
+			"Expired event must be emitted exactly once")
+	})
+}
+
+func TestHandleSandboxExpiryPodGracePeriod(t *testing.T) {
+	sbName := "expiring-sandbox"
+	sbNs := "default"
+
+	newSandbox := func(gracePeriod *int64) *sandboxv1beta1.Sandbox {
+		return &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{
+							Containers:                    []corev1.Container{{Name: "c", Image: "img"}},
+							TerminationGracePeriodSeconds: gracePeriod,
+						},
+					},
+				},
+				Lifecycle: sandboxv1beta1.Lifecycle{
+					ShutdownTime: new(metav1.NewTime(time.Now().Add(-time.Minute))),
+				},
+			},
+		}
+	}
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sbName, Namespace: sbNs,
+				OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sbName)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		gracePeriod *int64
+		terminating bool
+		wantDelete  bool
+		wantEvents  []string
+	}{
+		{
+			name:        "reports pod template grace period",
+			gracePeriod: ptr.To(int64(60)),
+			wantDelete:  true,
+			wantEvents:  []string{fmt.Sprintf("Normal PodTerminating Terminating Pod %q on expiry with a grace period of 60s", sbName)},
+		},
+		{
+			name:       "default grace period when unset",
+			wantDelete: true,
+			wantEvents: []string{fmt.Sprintf("Normal PodTerminating Terminating Pod %q on expiry", sbName)},
+		},
+		{
+			name:        "terminating pod is not deleted again",
+			gracePeriod: ptr.To(int64(60)),
+			terminating: true,
+			wantDelete:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sandbox := newSandbox(tc.gracePeriod)
+			pod := newPod()
+			if tc.terminating {
+				pod.Finalizers = []string{"test/finalizer"}
+				pod.DeletionTimestamp = new(metav1.Now())
+			}
+
+			recorder := events.NewFakeRecorder(10)
+			var deleteOpts *client.DeleteOptions
+			var eventsBeforeDelete int
+			fc := fake.NewClientBuilder().
+				WithScheme(Scheme).
+				WithRuntimeObjects(sandbox, pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*corev1.Pod); ok {
+							deleteOpts = (&client.DeleteOptions{}).ApplyOptions(opts)
+							eventsBeforeDelete = len(recorder.Events)
+						}
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			r := &SandboxReconciler{
+				Client:   fc,
+				Scheme:   Scheme,
+				Tracer:   asmetrics.NewNoOp(),
+				Recorder: recorder,
+			}
+
+			deleted, err := r.handleSandboxExpiry(context.Background(), sandbox)
+			require.NoError(t, err)
+			require.False(t, deleted)
+
+			if !tc.wantDelete {
+				require.Nil(t, deleteOpts, "terminating pod must not be deleted again")
+			} else {
+				require.NotNil(t, deleteOpts)
+				// The pod's own terminationGracePeriodSeconds applies; an explicit
+				// value would override what the pod was created with.
+				require.Nil(t, deleteOpts.GracePeriodSeconds)
+				require.Equal(t, 1, eventsBeforeDelete, "the PodTerminating event must be recorded before the pod is deleted")
+			}
+			require.Equal(t, tc.wantEvents, drainEvents(recorder))
+		})
+	}
+}
+
+func TestSandboxForceDelete(t *testing.T) {
+	sbName := "stuck-sandbox"
+	sbNs := "default"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+	// lostNodeFinalizer stands in for the kubelet of a node that is gone: a
+	// graceful delete only marks the pod terminating, and it stays that way.
+	const lostNodeFinalizer = "test/lost-node"
+
+	newSandbox := func(force, deleting bool) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{
+							Containers:                    []corev1.Container{{Name: "c", Image: "img"}},
+							TerminationGracePeriodSeconds: ptr.To(int64(3600)),
+						},
+					},
+				},
+				Lifecycle: sandboxv1beta1.Lifecycle{
+					ShutdownTime: new(metav1.NewTime(time.Now().Add(-time.Minute))),
+				},
+			},
+		}
+		if force {
+			sb.Annotations = map[string]string{sandboxv1beta1.SandboxForceDeleteAnnotation: "true"}
+		}
+		if deleting {
+			sb.DeletionTimestamp = new(metav1.Now())
+			sb.Finalizers = []string{sandboxv1beta1.SandboxCleanupFinalizer}
+		}
+		return sb
+	}
+	newPod := func(terminating bool) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: sbName, Namespace: sbNs,
+			OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sbName)},
+			Finalizers:      []string{lostNodeFinalizer},
+		}}
+		if terminating {
+			pod.DeletionTimestamp = new(metav1.Now())
+		}
+		return pod
+	}
+	forceDeletedEvent := fmt.Sprintf("Warning PodForceDeleted Force-deleting Pod %q with a zero grace period", sbName)
+
+	testCases := []struct {
+		name        string
+		force       bool
+		deleting    bool
+		terminating bool
+		wantDeletes []*int64
+		wantPod     bool
+		wantSandbox bool
+		wantEvents  []string
+	}{
+		{
+			name:        "expiry force-deletes a pod with a long grace period",
+			force:       true,
+			wantDeletes: []*int64{ptr.To(int64(0))},
+			wantSandbox: true,
+			wantEvents:  []string{forceDeletedEvent},
+		},
+		{
+			name:        "expiry force-deletes a pod stuck terminating",
+			force:       true,
+			terminating: true,
+			wantDeletes: []*int64{ptr.To(int64(0))},
+			wantSandbox: true,
+			wantEvents:  []string{forceDeletedEvent},
+		},
+		{
+			name:        "expiry leaves a terminating pod alone without the annotation",
+			terminating: true,
+			wantPod:     true,
+			wantSandbox: true,
+		},
+		{
+			name:        "deletion force-deletes a pod stuck terminating and removes the finalizer",
+			force:       true,
+			deleting:    true,
+			terminating: true,
+			wantDeletes: []*int64{ptr.To(int64(0))},
+			wantEvents:  []string{forceDeletedEvent},
+		},
+		{
+			name:        "deletion waits for a terminating pod without the annotation",
+			deleting:    true,
+			terminating: true,
+			wantPod:     true,
+			wantSandbox: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sandbox := newSandbox(tc.force, tc.deleting)
+			var deletes []*int64
+			fc := fake.NewClientBuilder().
+				WithScheme(Scheme).
+				WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
+				WithRuntimeObjects(sandbox, newPod(tc.terminating)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*corev1.Pod); !ok {
+							return c.Delete(ctx, obj, opts...)
+						}
+						gracePeriod := (&client.DeleteOptions{}).ApplyOptions(opts).GracePeriodSeconds
+						deletes = append(deletes, gracePeriod)
+						if err := c.Delete(ctx, obj, opts...); err != nil {
+							return err
+						}
+						if gracePeriod == nil || *gracePeriod != 0 {
+							return nil
+						}
+						// A zero grace period removes the pod without waiting for its kubelet.
+						pod := &corev1.Pod{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), pod); err != nil {
+							return client.IgnoreNotFound(err)
+						}
+						pod.Finalizers = slices.DeleteFunc(pod.Finalizers, func(f string) bool { return f == lostNodeFinalizer })
+						return c.Update(ctx, pod)
+					},
+				}).
+				Build()
+			recorder := events.NewFakeRecorder(10)
+			r := &SandboxReconciler{
+				Client:   fc,
+				Scheme:   Scheme,
+				Tracer:   asmetrics.NewNoOp(),
+				Recorder: recorder,
+			}
+
+			if tc.deleting {
+				_, err := r.Reconcile(t.Context(), req)
+				require.NoError(t, err)
+			} else {
+				deleted, err := r.handleSandboxExpiry(t.Context(), sandbox)
+				require.NoError(t, err)
+				require.False(t, deleted)
+			}
+
+			require.Equal(t, tc.wantDeletes, deletes)
+			err := fc.Get(t.Context(), req.NamespacedName, &corev1.Pod{})
+			require.Equal(t, tc.wantPod, err == nil, "pod presence: %v", err)
+			err = fc.Get(t.Context(), req.NamespacedName, &sandboxv1beta1.Sandbox{})
+			require.Equal(t, tc.wantSandbox, err == nil, "sandbox presence: %v", err)
+			require.Equal(t, tc.wantEvents, drainEvents(recorder))
+		})
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	sbName := "dry-run-sandbox"
+	sbNs := "default"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	newSandbox := func() *sandboxv1beta1.Sandbox {
+		return &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sbName, Namespace: sbNs, UID: sandboxUID, Generation: 1,
+				Annotations: map[string]string{sandboxv1beta1.SandboxDryRunAnnotation: "true"},
+			},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+					VolumeClaimTemplates: []sandboxv1beta1.PersistentVolumeClaimTemplate{{
+						EmbeddedObjectMetadata: sandboxv1beta1.EmbeddedObjectMetadata{Name: "data"},
+					}},
+					Service: new(true),
+				},
+			},
+		}
+	}
+	// newClient records the kind of every dry-run create and update, and fails the
+	// dry run of any kind in reject.
+	newClient := func(dryRuns *[]string, reject map[string]error, objs ...runtime.Object) client.WithWatch {
+		record := func(obj client.Object, dryRun []string) error {
+			if !slices.Contains(dryRun, metav1.DryRunAll) {
+				return nil
+			}
+			var kind string
+			switch obj.(type) {
+			case *corev1.Pod:
+				kind = "Pod"
+			case *corev1.Service:
+				kind = "Service"
+			case *corev1.PersistentVolumeClaim:
+				kind = "PersistentVolumeClaim"
+			}
+			*dryRuns = append(*dryRuns, kind)
+			return reject[kind]
+		}
+		return fake.NewClientBuilder().
+			WithScheme(Scheme).
+			WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
+			WithIndex(&corev1.Pod{}, podSandboxNameHashIndex, podSandboxNameHashIndexer).
+			WithIndex(&sandboxv1beta1.Sandbox{}, sandboxNameHashIndex, sandboxNameHashIndexer).
+			WithRuntimeObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if err := record(obj, (&client.CreateOptions{}).ApplyOptions(opts).DryRun); err != nil {
+						return err
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if err := record(obj, (&client.UpdateOptions{}).ApplyOptions(opts).DryRun); err != nil {
+						return err
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+	}
+	reconcile := func(t *testing.T, c client.Client) *sandboxv1beta1.Sandbox {
+		t.Helper()
+		r := &SandboxReconciler{Client: c, Scheme: Scheme, Tracer: asmetrics.NewNoOp()}
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		sb := &sandboxv1beta1.Sandbox{}
+		require.NoError(t, c.Get(t.Context(), req.NamespacedName, sb))
+		return sb
+	}
+	requireNoChildren := func(t *testing.T, c client.Client) {
+		t.Helper()
+		pods := &corev1.PodList{}
+		require.NoError(t, c.List(t.Context(), pods))
+		require.Empty(t, pods.Items)
+		services := &corev1.ServiceList{}
+		require.NoError(t, c.List(t.Context(), services))
+		require.Empty(t, services.Items)
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		require.NoError(t, c.List(t.Context(), pvcs))
+		require.Empty(t, pvcs.Items)
+	}
+
+	t.Run("validates child resources without creating them", func(t *testing.T) {
+		var dryRuns []string
+		c := newClient(&dryRuns, nil, newSandbox())
+
+		sb := reconcile(t, c)
+		assert.Equal(t, []string{"PersistentVolumeClaim", "Pod", "Service"}, dryRuns)
+		requireNoChildren(t, c)
+		cond := meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionDryRunValidated))
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonDryRunSucceeded, cond.Reason)
+		assert.Equal(t, int64(1), cond.ObservedGeneration)
+		assert.Nil(t, meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady)))
+	})
+
+	t.Run("reports resources the API server rejects", func(t *testing.T) {
+		var dryRuns []string
+		reject := map[string]error{
+			"Pod": k8serrors.NewInvalid(corev1.SchemeGroupVersion.WithKind("Pod").GroupKind(), sbName, nil),
+		}
+		c := newClient(&dryRuns, reject, newSandbox())
+
+		sb := reconcile(t, c)
+		requireNoChildren(t, c)
+		cond := meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionDryRunValidated))
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, sandboxv1beta1.SandboxReasonDryRunFailed, cond.Reason)
+		assert.Contains(t, cond.Message, `Pod "dry-run-sandbox"`)
+	})
+
+	t.Run("dry-run updates an existing service", func(t *testing.T) {
+		var dryRuns []string
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sbName, Namespace: sbNs,
+				Labels:          map[string]string{sandboxLabel: NameHash(sbName)},
+				OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sbName)},
+			},
+			Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+		}
+		c := newClient(&dryRuns, nil, newSandbox(), service)
+
+		reconcile(t, c)
+		assert.Equal(t, []string{"PersistentVolumeClaim", "Pod", "Service"}, dryRuns)
+	})
+
+	t.Run("removing the annotation creates the resources", func(t *testing.T) {
+		var dryRuns []string
+		sb := newSandbox()
+		c := newClient(&dryRuns, nil, sb)
+		sb = reconcile(t, c)
+
+		delete(sb.Annotations, sandboxv1beta1.SandboxDryRunAnnotation)
+		require.NoError(t, c.Update(t.Context(), sb))
+		sb = reconcile(t, c)
+		assert.Nil(t, meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionDryRunValidated)))
+		require.NoError(t, c.Get(t.Context(), req.NamespacedName, &corev1.Pod{}))
+		require.NoError(t, c.Get(t.Context(), req.NamespacedName, &corev1.Service{}))
+	})
+}
+
+func TestReconcilePaused(t *testing.T) {
+	sbName := "paused-sandbox"
+	sbNs := "default"
+	nameHash := NameHash(sbName)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	newSandbox := func(shutdownTime *metav1.Time) *sandboxv1beta1.Sandbox {
+		return &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID, Generation: 1},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+				Lifecycle: sandboxv1beta1.Lifecycle{
+					ShutdownTime:   shutdownTime,
+					ShutdownPolicy: ptr.To(sandboxv1beta1.ShutdownPolicyDelete),
+				},
+				OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning,
+				Paused:        true,
+			},
+		}
+	}
+	// A pod whose recorded spec hash no longer matches the sandbox, which would
+	// normally cause it to be recreated.
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sbName, Namespace: sbNs,
+				Labels:          map[string]string{sandboxLabel: nameHash},
+				Annotations:     map[string]string{sandboxv1beta1.SandboxPodSpecHashAnnotation: "stale"},
+				OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sbName)},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "old-img"}}},
+		}
+	}
+	wantPausedCondition := metav1.Condition{
+		Type:               string(sandboxv1beta1.SandboxConditionPaused),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 1,
+		Reason:             sandboxv1beta1.SandboxReasonPaused,
+		Message:            "Reconciliation is paused",
+	}
+
+	testCases := []struct {
+		name         string
+		shutdownTime *metav1.Time
+		withPod      bool
+	}{
+		{
+			name:    "existing pod is not recreated",
+			withPod: true,
+		},
+		{
+			name:         "expired sandbox is not deleted",
+			shutdownTime: new(metav1.NewTime(time.Now().Add(-time.Minute))),
+			withPod:      true,
+		},
+		{
+			name:         "pod is not created and ttl is not requeued",
+			shutdownTime: new(metav1.NewTime(time.Now().Add(time.Hour))),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			objs := []runtime.Object{newSandbox(tc.shutdownTime)}
+			if tc.withPod {
+				objs = append(objs, newPod())
+			}
+			fc := newFakeClient(objs...)
+			r := &SandboxReconciler{
+				Client: fc,
+				Scheme: Scheme,
+				Tracer: asmetrics.NewNoOp(),
+			}
+
+			result, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, ctrl.Result{}, result)
+
+			var sandbox sandboxv1beta1.Sandbox
+			require.NoError(t, fc.Get(ctx, req.NamespacedName, &sandbox))
+			cond := meta.FindStatusCondition(sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPaused))
+			require.NotNil(t, cond)
+			require.Empty(t, cmp.Diff(wantPausedCondition, *cond, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")))
+			require.Nil(t, meta.FindStatusCondition(sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady)))
+
+			var pods corev1.PodList
+			require.NoError(t, fc.List(ctx, &pods, client.InNamespace(sbNs)))
+			if !tc.withPod {
+				require.Empty(t, pods.Items)
+				return
+			}
+			require.Len(t, pods.Items, 1)
+			wantPod := newPod()
+			require.Equal(t, wantPod.Spec, pods.Items[0].Spec)
+			require.Equal(t, wantPod.Annotations, pods.Items[0].Annotations)
+			require.True(t, pods.Items[0].DeletionTimestamp.IsZero())
+		})
+	}
+
+	t.Run("unpause resumes reconciliation", func(t *testing.T) {
+		ctx := context.Background()
+		fc := newFakeClient(newSandbox(nil))
+		r := &SandboxReconciler{
+			Client: fc,
+			Scheme: Scheme,
+			Tracer: asmetrics.NewNoOp(),
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		var sandbox sandboxv1beta1.Sandbox
+		require.NoError(t, fc.Get(ctx, req.NamespacedName, &sandbox))
+		sandbox.Spec.Paused = false
+		require.NoError(t, fc.Update(ctx, &sandbox))
+
+		_, err = r.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		require.NoError(t, fc.Get(ctx, req.NamespacedName, &sandbox))
+		require.Nil(t, meta.FindStatusCondition(sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionPaused)))
+		require.NotNil(t, meta.FindStatusCondition(sandbox.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady)))
+
+		var pod corev1.Pod
+		require.NoError(t, fc.Get(ctx, req.NamespacedName, &pod))
+	})
+}
+
+func TestReconcileCleanupFinalizer(t *testing.T) {
+	sbName := "finalized-sandbox"
+	sbNs := "default"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	newSandbox := func(deleting bool) *sandboxv1beta1.Sandbox {
+		sb := &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID, Generation: 1},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+			},
+		}
+		if deleting {
+			sb.DeletionTimestamp = new(metav1.Now())
+			sb.Finalizers = []string{sandboxv1beta1.SandboxCleanupFinalizer}
+		}
+		return sb
+	}
+	newPod := func(ownerRef metav1.OwnerReference, finalizers ...string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: sbName, Namespace: sbNs,
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+			Finalizers:      finalizers,
+		}}
+	}
+	newService := func() *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name: sbName, Namespace: sbNs,
+			OwnerReferences: []metav1.OwnerReference{sandboxControllerRef(sbName)},
+		}}
+	}
+	otherRef := metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "other", UID: "other-uid", Controller: new(true),
+	}
+
+	testCases := []struct {
+		name               string
+		sandbox            *sandboxv1beta1.Sandbox
+		initialObjs        []runtime.Object
+		wantFinalizer      bool
+		wantSandboxDeleted bool
+		wantPod            bool
+		wantService        bool
+	}{
+		{
+			name:          "finalizer is added to a live sandbox",
+			sandbox:       newSandbox(false),
+			wantFinalizer: true,
+			wantPod:       true,
+		},
+		{
+			name:               "owned pod and service are deleted before the finalizer is removed",
+			sandbox:            newSandbox(true),
+			initialObjs:        []runtime.Object{newPod(sandboxControllerRef(sbName)), newService()},
+			wantSandboxDeleted: true,
+		},
+		{
+			name:               "children already gone",
+			sandbox:            newSandbox(true),
+			wantSandboxDeleted: true,
+		},
+		{
+			name:               "pod owned by another controller is left alone",
+			sandbox:            newSandbox(true),
+			initialObjs:        []runtime.Object{newPod(otherRef), newService()},
+			wantSandboxDeleted: true,
+			wantPod:            true,
+		},
+		{
+			name:    "service is kept until the terminating pod is gone",
+			sandbox: newSandbox(true),
+			// The pod finalizer keeps the pod around after delete, like a
+			// kubelet that has not finished the grace period yet.
+			initialObjs:   []runtime.Object{newPod(sandboxControllerRef(sbName), "example.com/block"), newService()},
+			wantFinalizer: true,
+			wantPod:       true,
+			wantService:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := SandboxReconciler{
+				Client:        newFakeClient(append(tc.initialObjs, tc.sandbox)...),
+				Scheme:        Scheme,
+				Tracer:        asmetrics.NewNoOp(),
+				ClusterDomain: "cluster.local",
+			}
+			_, err := r.Reconcile(t.Context(), req)
+			require.NoError(t, err)
+
+			live := &sandboxv1beta1.Sandbox{}
+			err = r.Get(t.Context(), req.NamespacedName, live)
+			if tc.wantSandboxDeleted {
+				require.True(t, k8serrors.IsNotFound(err), "expected sandbox to be deleted, got %v", err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantFinalizer, slices.Contains(live.Finalizers, sandboxv1beta1.SandboxCleanupFinalizer))
+			}
+
+			err = r.Get(t.Context(), req.NamespacedName, &corev1.Pod{})
+			require.Equal(t, tc.wantPod, err == nil, "pod presence: %v", err)
+			err = r.Get(t.Context(), req.NamespacedName, &corev1.Service{})
+			require.Equal(t, tc.wantService, err == nil, "service presence: %v", err)
+		})
+	}
+}
+
+func TestPVCRetentionPolicy(t *testing.T) {
+	sbName := "pvc-sandbox"
+	sbNs := "default"
+	pvcName := "data-" + sbName
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	newPVC := func(ownerRef metav1.OwnerReference) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name: pvcName, Namespace: sbNs,
+			Labels:          map[string]string{sandboxLabel: NameHash(sbName)},
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		}}
+	}
+	otherRef := metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "StatefulSet", Name: "other", UID: "other-uid", Controller: new(true),
+	}
+
+	testCases := []struct {
+		name           string
+		policy         *sandboxv1beta1.SandboxPersistentVolumeClaimRetentionPolicy
+		deleting       bool
+		suspended      bool
+		expired        bool
+		pvcOwner       metav1.OwnerReference
+		reconcileCount int
+		wantPVC        bool
+		wantPVCOwners  int
+	}{
+		{
+			name:     "deleted sandbox deletes PVCs by default",
+			deleting: true,
+			pvcOwner: sandboxControllerRef(sbName),
+		},
+		{
+			name:          "deleted sandbox orphans PVCs with whenDeleted Retain",
+			policy:        &sandboxv1beta1.SandboxPersistentVolumeClaimRetentionPolicy{WhenDeleted: sandboxv1beta1.RetainPersistentVolumeClaimRetentionPolicyType},
+			deleting:      true,
+			pvcOwner:      sandboxControllerRef(sbName),
+			wantPVC:       true,
+			wantPVCOwners: 0,
+		},
+		{
+			name:          "deleted sandbox leaves PVCs owned by others alone",
+			deleting:      true,
+			pvcOwner:      otherRef,
+			wantPVC:       true,
+			wantPVCOwners: 1,
+		},
+		{
+			name:          "suspended sandbox keeps PVCs by default",
+			suspended:     true,
+			pvcOwner:      sandboxControllerRef(sbName),
+			wantPVC:       true,
+			wantPVCOwners: 1,
+		},
+		{
+			name:      "suspended sandbox deletes PVCs with whenScaled Delete",
+			policy:    &sandboxv1beta1.SandboxPersistentVolumeClaimRetentionPolicy{WhenScaled: sandboxv1beta1.DeletePersistentVolumeClaimRetentionPolicyType},
+			suspended: true,
+			pvcOwner:  sandboxControllerRef(sbName),
+		},
+		{
+			name:           "expired sandbox deletes PVCs with whenScaled Delete",
+			policy:         &sandboxv1beta1.SandboxPersistentVolumeClaimRetentionPolicy{WhenScaled: sandboxv1beta1.DeletePersistentVolumeClaimRetentionPolicyType},
+			expired:        true,
+			pvcOwner:       sandboxControllerRef(sbName),
+			reconcileCount: 2,
+		},
+		{
+			name:           "expired sandbox keeps PVCs by default",
+			expired:        true,
+			pvcOwner:       sandboxControllerRef(sbName),
+			reconcileCount: 2,
+			wantPVC:        true,
+			wantPVCOwners:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sb := &sandboxv1beta1.Sandbox{
+				ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID, Generation: 1},
+				Spec: sandboxv1beta1.SandboxSpec{
+					SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+						PodTemplate: sandboxv1beta1.PodTemplate{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+						},
+						VolumeClaimTemplates: []sandboxv1beta1.PersistentVolumeClaimTemplate{
+							{EmbeddedObjectMetadata: sandboxv1beta1.EmbeddedObjectMetadata{Name: "data"}},
+						},
+					},
+					OperatingMode:                        sandboxv1beta1.SandboxOperatingModeRunning,
+					PersistentVolumeClaimRetentionPolicy: tc.policy,
+				},
+			}
+			if tc.deleting {
+				sb.DeletionTimestamp = new(metav1.Now())
+				sb.Finalizers = []string{sandboxv1beta1.SandboxCleanupFinalizer}
+			}
+			if tc.suspended {
+				sb.Spec.OperatingMode = sandboxv1beta1.SandboxOperatingModeSuspended
+			}
+			if tc.expired {
+				sb.Spec.ShutdownTime = new(metav1.NewTime(time.Now().Add(-time.Minute)))
+				sb.Spec.ShutdownPolicy = ptr.To(sandboxv1beta1.ShutdownPolicyRetain)
+			}
+			r := SandboxReconciler{
+				Client:        newFakeClient(newPVC(tc.pvcOwner), sb),
+				Scheme:        Scheme,
+				Tracer:        asmetrics.NewNoOp(),
+				ClusterDomain: "cluster.local",
+			}
+
+			reconcileCount := max(tc.reconcileCount, 1)
+			for range reconcileCount {
+				_, err := r.Reconcile(t.Context(), req)
+				require.NoError(t, err)
+			}
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := r.Get(t.Context(), types.NamespacedName{Name: pvcName, Namespace: sbNs}, pvc)
+			if !tc.wantPVC {
+				require.True(t, k8serrors.IsNotFound(err), "expected PVC to be deleted, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, pvc.OwnerReferences, tc.wantPVCOwners)
+			require.Equal(t, NameHash(sbName), pvc.Labels[sandboxLabel], "tracking label must be kept")
+		})
+	}
+}
+
+func TestReconcilePodCreationForbidden(t *testing.T) {
+	sbName := "quota-sandbox"
+	sbNs := "default"
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: sbName, Namespace: sbNs}}
+
+	sandbox := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{Name: sbName, Namespace: sbNs, UID: sandboxUID, Generation: 1},
+		Spec: sandboxv1beta1.SandboxSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+				PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+				},
+			},
+		},
+	}
+
+	var podCreates int
+	fc := fake.NewClientBuilder().
+		WithScheme(Scheme).
+		WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
+		WithIndex(&corev1.Pod{}, podSandboxNameHashIndex, podSandboxNameHashIndexer).
+		WithIndex(&sandboxv1beta1.Sandbox{}, sandboxNameHashIndex, sandboxNameHashIndexer).
+		WithRuntimeObjects(sandbox).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Pod); ok {
+					podCreates++
+					return k8serrors.NewForbidden(corev1.Resource("pods"), obj.GetName(),
+						errors.New("exceeded quota: compute-resources, requested: pods=1, used: pods=10, limited: pods=10"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SandboxReconciler{
+		Client: fc,
+		Scheme: Scheme,
+		Tracer: asmetrics.NewNoOp(),
+	}
+
+	result, err := r.Reconcile(t.Context(), req)
+	require.NoError(t, err, "forbidden errors must not be retried with backoff")
+	require.Equal(t, forbiddenRequeueDelay, result.RequeueAfter)
+	require.Equal(t, 1, podCreates)
+
+	got := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fc.Get(t.Context(), req.NamespacedName, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, sandboxv1beta1.SandboxReasonQuotaExceeded, cond.Reason)
+	require.Contains(t, cond.Message, "exceeded quota")
+}
+
+func TestReconcileNameHashCollision(t *testing.T) {
+	sbNs := "default"
+	// Both names hash to 46b7d43b.
+	older, newer := "sandbox-621119", "sandbox-1409112"
+	require.Equal(t, NameHash(older), NameHash(newer))
+
+	newSandbox := func(name string, created time.Time) *sandboxv1beta1.Sandbox {
+		return &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: sbNs, UID: types.UID(name), Generation: 1,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+			},
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+	// Status left from before the collision must not survive it.
+	stale := newSandbox(newer, now)
+	stale.Status = sandboxv1beta1.SandboxStatus{
+		LabelSelector: sandboxLabel + "=" + NameHash(newer),
+		PodName:       newer,
+		PodIPs:        []string{"10.0.0.7"},
+		Service:       newer,
+		ServiceFQDN:   newer + "." + sbNs + ".svc.cluster.local",
+		PodFQDN:       newer + "." + newer + "." + sbNs + ".svc.cluster.local",
+	}
+	fc := newFakeClient(stale, newSandbox(older, now.Add(-time.Minute)))
+	r := &SandboxReconciler{
+		Client: fc,
+		Scheme: Scheme,
+		Tracer: asmetrics.NewNoOp(),
+	}
+
+	for _, name := range []string{newer, older} {
+		_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: sbNs}})
+		require.NoError(t, err)
+	}
+
+	// The older Sandbox keeps the hash and gets its pod.
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: older, Namespace: sbNs}, &corev1.Pod{}))
+
+	// The newer one gets nothing and reports why.
+	err := fc.Get(t.Context(), types.NamespacedName{Name: newer, Namespace: sbNs}, &corev1.Pod{})
+	require.True(t, k8serrors.IsNotFound(err), "expected no pod for the colliding sandbox, got %v", err)
+	err = fc.Get(t.Context(), types.NamespacedName{Name: newer, Namespace: sbNs}, &corev1.Service{})
+	require.True(t, k8serrors.IsNotFound(err), "expected no service for the colliding sandbox, got %v", err)
+
+	got := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: newer, Namespace: sbNs}, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, sandboxv1beta1.SandboxReasonNameHashCollision, cond.Reason)
+	require.Contains(t, cond.Message, older)
+	require.Empty(t, got.Status.LabelSelector)
+	require.Empty(t, got.Status.PodName)
+	require.Empty(t, got.Status.PodIPs)
+	require.Empty(t, got.Status.Service)
+	require.Empty(t, got.Status.ServiceFQDN)
+	require.Empty(t, got.Status.PodFQDN)
+
+	// Deleting the holder enqueues the colliding Sandbox, which then takes over
+	// the hash.
+	holder := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: older, Namespace: sbNs}, holder))
+	require.NoError(t, fc.Delete(t.Context(), holder))
+	_, err = r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: older, Namespace: sbNs}})
+	require.NoError(t, err)
+	err = fc.Get(t.Context(), types.NamespacedName{Name: older, Namespace: sbNs}, &sandboxv1beta1.Sandbox{})
+	require.True(t, k8serrors.IsNotFound(err), "expected the holder to be gone, got %v", err)
+
+	requests := r.findSandboxesSharingNameHash(t.Context(), holder)
+	require.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Name: newer, Namespace: sbNs}}}, requests)
+	_, err = r.Reconcile(t.Context(), requests[0])
+	require.NoError(t, err)
+
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: newer, Namespace: sbNs}, &corev1.Pod{}))
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: newer, Namespace: sbNs}, got))
+	cond = meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.NotEqual(t, sandboxv1beta1.SandboxReasonNameHashCollision, cond.Reason)
+	require.Equal(t, newer, got.Status.PodName)
+}
+
+func TestReconcilePodCreateLimiter(t *testing.T) {
+	const (
+		limit     = 2
+		sandboxes = 5
+	)
+	sbNs := "default"
+
+	var objs []runtime.Object
+	for i := range sandboxes {
+		objs = append(objs, &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("sb-%d", i), Namespace: sbNs, UID: types.UID(fmt.Sprintf("uid-%d", i)), Generation: 1},
+			Spec: sandboxv1beta1.SandboxSpec{
+				SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{
+					PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}},
+					},
+				},
+			},
+		})
+	}
+
+	// Pod creates block until release is closed, so the creates that got a slot
+	// stay in flight while the remaining reconciles run.
+	var mu sync.Mutex
+	inFlight, maxInFlight, podCreates := 0, 0, 0
+	started := make(chan struct{}, sandboxes)
+	release := make(chan struct{})
+	fc := fake.NewClientBuilder().
+		WithScheme(Scheme).
+		WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
+		WithIndex(&corev1.Pod{}, podSandboxNameHashIndex, podSandboxNameHashIndexer).
+		WithIndex(&sandboxv1beta1.Sandbox{}, sandboxNameHashIndex, sandboxNameHashIndexer).
+		WithRuntimeObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Pod); ok {
+					mu.Lock()
+					podCreates++
+					inFlight++
+					maxInFlight = max(maxInFlight, inFlight)
+					mu.Unlock()
+					started <- struct{}{}
+					<-release
+					mu.Lock()
+					inFlight--
+					mu.Unlock()
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SandboxReconciler{
+		Client:           fc,
+		Scheme:           Scheme,
+		Tracer:           asmetrics.NewNoOp(),
+		PodCreateLimiter: semaphore.NewWeighted(limit),
+	}
+
+	type outcome struct {
+		name   string
+		result ctrl.Result
+		err    error
+	}
+	outcomes := make(chan outcome, sandboxes)
+	for i := range sandboxes {
+		go func() {
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("sb-%d", i), Namespace: sbNs}}
+			result, err := r.Reconcile(t.Context(), req)
+			outcomes <- outcome{name: req.Name, result: result, err: err}
+		}()
+	}
+
+	// Every reconcile without a slot returns while the others hold theirs.
+	var throttled []string
+	for range sandboxes - limit {
+		o := <-outcomes
+		require.NoError(t, o.err, "throttled creates must not be retried with backoff")
+		require.GreaterOrEqual(t, o.result.RequeueAfter, podCreateThrottledRequeueDelay)
+		require.LessOrEqual(t, o.result.RequeueAfter, 2*podCreateThrottledRequeueDelay)
+		throttled = append(throttled, o.name)
+	}
+	for range limit {
+		<-started
+	}
+	close(release)
+	for range limit {
+		require.NoError(t, (<-outcomes).err)
+	}
+	require.Equal(t, limit, maxInFlight)
+	require.Equal(t, limit, podCreates)
+
+	got := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fc.Get(t.Context(), types.NamespacedName{Name: throttled[0], Namespace: sbNs}, got))
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, sandboxv1beta1.SandboxReasonPodCreateThrottled, cond.Reason)
+
+	// Once the slots are released, the throttled Sandboxes get their pods.
+	for _, name := range throttled {
+		_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: sbNs}})
+		require.NoError(t, err)
+	}
+	require.Equal(t, sandboxes, podCreates)
+	require.Equal(t, limit, maxInFlight)
+}
+
+// TestReconcileReturnsOnTimeout checks that a reconcile stuck on an API call
+// returns once its context expires, which is what --reconcile-timeout relies on
+// to free the worker and requeue the Sandbox.
+func TestReconcileReturnsOnTimeout(t *testing.T) {
+	sb := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{Name: "sandbox-name", Namespace: "sandbox-ns", UID: sandboxUID},
+		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container"}}},
+		}}},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(Scheme).
+		WithStatusSubresource(&sandboxv1beta1.Sandbox{}).
+		WithIndex(&corev1.Pod{}, podSandboxNameHashIndex, podSandboxNameHashIndexer).
+		WithIndex(&sandboxv1beta1.Sandbox{}, sandboxNameHashIndex, sandboxNameHashIndexer).
+		WithRuntimeObjects(sb).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// A wedged API server: pod lookups hang until the caller gives up.
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Pod); ok {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SandboxReconciler{Client: c, Scheme: Scheme, Tracer: asmetrics.NewNoOp(), ClusterDomain: "cluster.local"}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: sb.Name, Namespace: sb.Namespace}})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Reconcile did not return after its context expired")
+	}
+}
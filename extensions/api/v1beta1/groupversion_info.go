@@ -25,6 +25,7 @@ import (
 
 const (
 	SandboxClaimKind    = "SandboxClaim"
+	SandboxClaimSetKind = "SandboxClaimSet"
 	SandboxTemplateKind = "SandboxTemplate"
 	SandboxWarmPoolKind = "SandboxWarmPool"
 )
@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
@@ -27,6 +28,14 @@ const (
 	// ClaimExpiredReason is the reason used in conditions/events when a claim expires.
 	ClaimExpiredReason = "ClaimExpired"
 
+	// ClaimReadinessTimeoutReason is the reason used in conditions/events when a claim's
+	// Sandbox did not become Ready within spec.readinessTimeoutSeconds.
+	ClaimReadinessTimeoutReason = "ReadinessTimeout"
+
+	// ClaimRecyclingReason is the reason used in conditions/events while a claim under the
+	// Recycle shutdown policy waits for its old Sandbox to be deleted.
+	ClaimRecyclingReason = "Recycling"
+
 	// DeprecatedAssignedSandboxNameLabel is the legacy label key applied to the claim to identify the adopted Sandbox name.
 	// Deprecated: Use AssignedSandboxNameAnnotation instead.
 	DeprecatedAssignedSandboxNameLabel = "agents.x-k8s.io/sandbox-name"
@@ -34,12 +43,21 @@ const (
 	// AssignedSandboxNameAnnotation is the annotation key applied to the claim to identify the adopted Sandbox Name.
 	AssignedSandboxNameAnnotation = "agents.x-k8s.io/sandbox-name"
 
+	// LastActivityAnnotation records, as an RFC 3339 timestamp, when the claim's Sandbox was
+	// last used. It is read from both the claim and its Sandbox, where the sandbox-router
+	// records proxied requests; clients may also refresh it on the claim directly.
+	LastActivityAnnotation = sandboxv1beta1.SandboxLastActivityAnnotation
+
+	// SandboxClaimTemplateHashLabel is the label key applied to a claim's Sandbox to record
+	// the hash of the SandboxTemplate it was built from.
+	SandboxClaimTemplateHashLabel = "agents.x-k8s.io/claim-template-hash"
+
 	// WarmPoolRefField is the field used for indexing SandboxClaims by their warm pool reference name.
 	WarmPoolRefField = ".spec.warmPoolRef.name"
 )
 
 // ShutdownPolicy describes the policy for shutting down the underlying Sandbox when the SandboxClaim expires.
-// +kubebuilder:validation:Enum=Delete;DeleteForeground;Retain
+// +kubebuilder:validation:Enum=Delete;DeleteForeground;Retain;Recycle
 type ShutdownPolicy string
 
 const (
@@ -56,6 +74,25 @@ const (
 	// The underlying SandboxClaim resources (Sandbox, Pod, Service) are deleted to save resources,
 	// but the SandboxClaim object itself remains.
 	ShutdownPolicyRetain ShutdownPolicy = "Retain"
+
+	// ShutdownPolicyRecycle keeps the SandboxClaim and replaces its Sandbox with a fresh one
+	// when the claim goes idle or its Sandbox finishes. The old Sandbox is deleted and the
+	// claim acquires a new one from its warm pool, restarting the idle and
+	// ttlSecondsAfterFinished timers. Reaching shutdownTime is final and behaves like Retain.
+	ShutdownPolicyRecycle ShutdownPolicy = "Recycle"
+)
+
+// SandboxClaimUpdatePolicy describes how a SandboxClaim reacts to changes of its SandboxTemplate.
+// +kubebuilder:validation:Enum=None;Recreate
+type SandboxClaimUpdatePolicy string
+
+const (
+	// SandboxClaimUpdatePolicyNone keeps the claim's Sandbox as it is when the template changes.
+	SandboxClaimUpdatePolicyNone SandboxClaimUpdatePolicy = "None"
+
+	// SandboxClaimUpdatePolicyRecreate deletes the claim's Sandbox (and its Pod) when the
+	// template changes, so that it is recreated from the updated template.
+	SandboxClaimUpdatePolicyRecreate SandboxClaimUpdatePolicy = "Recreate"
 )
 
 // Lifecycle defines the lifecycle management for the SandboxClaim.
@@ -76,6 +113,12 @@ type Lifecycle struct {
 	// +optional
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 
+	// idleTimeout expires the claim once this long has passed since its last recorded
+	// activity: the latest agents.x-k8s.io/last-activity annotation on the claim or its
+	// Sandbox, or the claim's creation when neither is set or both are older.
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
 	// shutdownPolicy determines the behavior when the SandboxClaim expires.
 	// +kubebuilder:default=Retain
 	// +optional
@@ -105,6 +148,22 @@ type EnvVar struct {
 	ContainerName string `json:"containerName,omitempty"`
 }
 
+// ContainerResources overrides the compute resources of a container defined in the template.
+type ContainerResources struct {
+	// containerName specifies the target container.
+	// If not specified, it defaults to the first container defined in the template.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// requests overrides the container's resource requests. Resources not listed keep the template's values.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// limits overrides the container's resource limits. Resources not listed keep the template's values.
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
+
 // SandboxClaimSpec defines the desired state of Sandbox.
 type SandboxClaimSpec struct {
 	// warmPoolRef targets the specific pre-warmed infrastructure pool to check out from.
@@ -115,9 +174,29 @@ type SandboxClaimSpec struct {
 	// +optional
 	Lifecycle *Lifecycle `json:"lifecycle,omitempty"`
 
+	// readinessTimeoutSeconds bounds how long after its creation the claim may wait
+	// for its Sandbox to become Ready. Once exceeded, the claim's Ready condition is
+	// set to False with reason ReadinessTimeout and the claim stops acquiring a
+	// Sandbox. The failed Sandbox is deleted when lifecycle.shutdownPolicy is Delete
+	// or DeleteForeground and kept for debugging otherwise. A claim that has been
+	// Ready once never times out. If omitted, the claim waits indefinitely.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReadinessTimeoutSeconds *int32 `json:"readinessTimeoutSeconds,omitempty"`
+
+	// updatePolicy determines what happens to the claim's Sandbox when the SandboxTemplate
+	// of its warm pool changes. None keeps the Sandbox running on the template it was built
+	// from. Recreate deletes the Sandbox, losing its state, and replaces it with one built
+	// from the updated template.
+	// +kubebuilder:default=None
+	// +optional
+	UpdatePolicy SandboxClaimUpdatePolicy `json:"updatePolicy,omitempty"`
+
 	// additionalPodMetadata defines the labels and annotations to be propagated to the Sandbox Pod.
 	// Label values are limited to 63 characters and must match Kubernetes label value patterns.
 	// Annotations in restricted system domains are rejected, except cluster-autoscaler.kubernetes.io/safe-to-evict.
+	// Setting a label or annotation the template already defines to a different value requires the
+	// template's podMetadataOverridePolicy to be Overrides.
 	// +optional
 	AdditionalPodMetadata sandboxv1beta1.PodMetadata `json:"additionalPodMetadata,omitempty"`
 
@@ -128,6 +207,14 @@ type SandboxClaimSpec struct {
 	// +optional
 	Env []EnvVar `json:"env,omitempty"`
 
+	// resources overrides the requests and limits of containers defined in the template.
+	// The template's resourcesOverridePolicy must be Allowed.
+	// Please note adding this field means the Sandbox will always be cold-started from the
+	// template of the warmpool.
+	// +listType=atomic
+	// +optional
+	Resources []ContainerResources `json:"resources,omitempty"`
+
 	// volumeClaimTemplates is a list of persistent volume claims to be created for the sandbox.
 	// Specifying this field forces a cold start because warm pool pods will not have these volumes.
 	// +optional
@@ -151,6 +238,16 @@ type SandboxStatus struct {
 	// +optional
 	Name string `json:"name,omitempty"`
 
+	// serviceFQDN is the Sandbox's status.serviceFQDN, copied here so clients can
+	// route to the Sandbox without reading it.
+	// +optional
+	ServiceFQDN string `json:"serviceFQDN,omitempty"`
+
+	// podName is the name of the Sandbox's underlying pod. It differs from the
+	// Sandbox name when the pod was adopted from a SandboxWarmPool.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
 	// podIPs are the IP addresses of the underlying pod.
 	// A pod may have multiple IPs in dual-stack clusters.
 	// +optional
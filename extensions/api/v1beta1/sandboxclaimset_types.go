@@ -0,0 +1,120 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NOTE: json tags are required. Any new fields you add must have json tags for the fields to be serialized.
+// Important: Run "make" to regenerate code after modifying this file
+
+const (
+	// SandboxClaimSetLabel is the label key applied to the claims of a SandboxClaimSet.
+	// Its value is the hash of the set's name.
+	SandboxClaimSetLabel = "agents.x-k8s.io/claim-set"
+)
+
+// SandboxClaimSet condition types, alongside Ready.
+const (
+	// SandboxClaimSetConditionInProgress is True while the set is being scaled toward
+	// spec.replicas or some of its claims are not Ready yet.
+	SandboxClaimSetConditionInProgress = "InProgress"
+	// SandboxClaimSetConditionDegraded is True while some of the set's claims gave up
+	// waiting for their Sandbox because spec.readinessTimeoutSeconds passed.
+	SandboxClaimSetConditionDegraded = "Degraded"
+)
+
+// SandboxClaimSetSpec defines the desired state of SandboxClaimSet.
+type SandboxClaimSetSpec struct {
+	// replicas is the desired number of SandboxClaims in the set.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// warmPoolRef is the pool every claim of the set checks out its Sandbox from.
+	// Claims fall back to a cold start from the pool's SandboxTemplate while the
+	// pool is empty. Changes apply to newly created claims only.
+	// +required
+	WarmPoolRef SandboxWarmPoolRef `json:"warmPoolRef"`
+
+	// readinessTimeoutSeconds is copied to spec.readinessTimeoutSeconds of each
+	// claim. A claim that times out stays in the set and is reported through the
+	// Degraded condition rather than replaced.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReadinessTimeoutSeconds *int32 `json:"readinessTimeoutSeconds,omitempty"`
+}
+
+// SandboxClaimSetStatus defines the observed state of SandboxClaimSet.
+type SandboxClaimSetStatus struct {
+	// replicas is the number of claims in the set, ready or not.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// readyClaims is the number of claims in the set whose Ready condition is True.
+	// +optional
+	ReadyClaims int32 `json:"readyClaims,omitempty"`
+
+	// conditions represent the latest available observations of the set's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:resource:scope=Namespaced,shortName=scs
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyClaims"
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="WarmPool",type="string",JSONPath=".spec.warmPoolRef.name",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// SandboxClaimSet is the Schema for the sandboxclaimsets API. It keeps
+// spec.replicas SandboxClaims against a warm pool.
+type SandboxClaimSet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of SandboxClaimSet
+	// +required
+	Spec SandboxClaimSetSpec `json:"spec"`
+
+	// status defines the observed state of SandboxClaimSet
+	// +optional
+	Status SandboxClaimSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// SandboxClaimSetList contains a list of SandboxClaimSet.
+type SandboxClaimSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SandboxClaimSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &SandboxClaimSet{}, &SandboxClaimSetList{})
+		return nil
+	})
+}
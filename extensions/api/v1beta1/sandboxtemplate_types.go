@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -70,6 +71,28 @@ const (
 	VolumeClaimTemplatesPolicyOverrides VolumeClaimTemplatesPolicy = "Overrides"
 )
 
+// ResourcesOverridePolicy defines whether a SandboxClaim is allowed to override container compute resources.
+type ResourcesOverridePolicy string
+
+const (
+	// ResourcesOverridePolicyAllowed allows a SandboxClaim to override the requests and limits of template containers.
+	ResourcesOverridePolicyAllowed ResourcesOverridePolicy = "Allowed"
+
+	// ResourcesOverridePolicyDisallowed prevents a SandboxClaim from overriding any container resources.
+	ResourcesOverridePolicyDisallowed ResourcesOverridePolicy = "Disallowed"
+)
+
+// PodMetadataOverridePolicy defines whether a SandboxClaim is allowed to override pod labels and annotations set by the template.
+type PodMetadataOverridePolicy string
+
+const (
+	// PodMetadataOverridePolicyDisallowed rejects a SandboxClaim that sets a template pod label or annotation to a different value.
+	PodMetadataOverridePolicyDisallowed PodMetadataOverridePolicy = "Disallowed"
+
+	// PodMetadataOverridePolicyOverrides allows a SandboxClaim to replace the values of template pod labels and annotations.
+	PodMetadataOverridePolicyOverrides PodMetadataOverridePolicy = "Overrides"
+)
+
 // NetworkPolicySpec defines the desired state of the NetworkPolicy.
 type NetworkPolicySpec struct {
 	// ingress is a list of ingress rules to be applied to the sandbox.
@@ -138,6 +161,29 @@ type SandboxTemplateSpec struct {
 	// +kubebuilder:default=Disallowed
 	// +optional
 	VolumeClaimTemplatesPolicy VolumeClaimTemplatesPolicy `json:"volumeClaimTemplatesPolicy,omitempty"`
+
+	// resourcesOverridePolicy allows a SandboxClaim to override the compute resources of containers defined in the template.
+	// If set to Disallowed, the SandboxClaim will be rejected if it specifies any resources.
+	// +kubebuilder:validation:Enum=Allowed;Disallowed
+	// +kubebuilder:default=Disallowed
+	// +optional
+	ResourcesOverridePolicy ResourcesOverridePolicy `json:"resourcesOverridePolicy,omitempty"`
+
+	// podMetadataOverridePolicy allows a SandboxClaim's additionalPodMetadata to override the values of
+	// labels and annotations defined in the template's podTemplate metadata.
+	// If set to Disallowed, the SandboxClaim will be rejected if it sets any of them to a different value.
+	// Labels and annotations in restricted system domains, other than
+	// cluster-autoscaler.kubernetes.io/safe-to-evict, can never be overridden.
+	// +kubebuilder:validation:Enum=Disallowed;Overrides
+	// +kubebuilder:default=Disallowed
+	// +optional
+	PodMetadataOverridePolicy PodMetadataOverridePolicy `json:"podMetadataOverridePolicy,omitempty"`
+
+	// defaultReadinessProbe is injected into the first container of warm pool
+	// pods that do not define a readiness probe of their own. Without one, a pod
+	// counts as ready as soon as it is running, before the sandbox has booted.
+	// +optional
+	DefaultReadinessProbe *corev1.Probe `json:"defaultReadinessProbe,omitempty"`
 }
 
 // +genclient
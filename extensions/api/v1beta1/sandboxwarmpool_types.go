@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -27,6 +28,24 @@ const (
 	// Warning: This path must exactly match the JSON tag path of SandboxWarmPoolSpec.TemplateRef.Name.
 	// If the JSON tags are changed, this constant must be updated to avoid indexer failures.
 	TemplateRefField = ".spec.sandboxTemplateRef.name"
+
+	// WarmPoolDrainAnnotation marks a warm pool sandbox's pod as drained when set to
+	// "true". The pool stops counting the sandbox, deletes it and creates a
+	// replacement. Sandboxes already adopted by a claim are left alone.
+	WarmPoolDrainAnnotation = "agents.x-k8s.io/drain"
+)
+
+// SandboxWarmPool condition types, alongside Ready.
+const (
+	// SandboxWarmPoolConditionInProgress is True while the pool is being scaled toward
+	// spec.replicas or some of its sandboxes are not Ready yet.
+	SandboxWarmPoolConditionInProgress = "InProgress"
+	// SandboxWarmPoolConditionCurrent is True once the pool holds exactly spec.replicas
+	// sandboxes, all of them Ready.
+	SandboxWarmPoolConditionCurrent = "Current"
+	// SandboxWarmPoolConditionDegraded is True while some of the pool's sandboxes are
+	// not Ready because their pods crashed or failed.
+	SandboxWarmPoolConditionDegraded = "Degraded"
 )
 
 // SandboxTemplateRef references a SandboxTemplate.
@@ -50,9 +69,63 @@ type SandboxWarmPoolSpec struct {
 	// +required
 	TemplateRef SandboxTemplateRef `json:"sandboxTemplateRef,omitempty"`
 
+	// minReadySeconds is the minimum number of seconds a pool sandbox must have been
+	// continuously Ready before it counts as available. Claims prefer available
+	// sandboxes, so one whose pod flaps right after starting is not handed out while
+	// others have proven stable. Defaults to 0: a sandbox is available once Ready.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// minAvailable is the number of spare sandboxes the pool keeps on top of the
+	// ones claimed from it. When set, replicas also counts the sandboxes held by
+	// claims referencing the pool, and the pool is sized to
+	// max(replicas, claimed + minAvailable) so a buffer of spares survives steady
+	// claim load. When unset, the pool keeps replicas spares.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinAvailable *int32 `json:"minAvailable,omitempty"`
+
+	// maxPodAge is how long a sandbox may stay in the pool before it is deleted and
+	// replaced, so long-lived warm pods do not accumulate state. Only available
+	// sandboxes are rotated; sandboxes already adopted by a claim are never touched.
+	// Expired sandboxes are rotated one at a time, after a Ready replacement keeps
+	// the pool at its desired spares. Unset keeps pool sandboxes until they are claimed.
+	// +optional
+	MaxPodAge *metav1.Duration `json:"maxPodAge,omitempty"`
+
 	// updateStrategy - strategy for updating the SandboxWarmPool pods based on sandboxTemplateRef name change or underlying template changes
 	// +optional
 	UpdateStrategy *SandboxWarmPoolUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// topologySpreadConstraints are injected into the pod spec of each pool sandbox
+	// whose template does not define its own. A constraint without a labelSelector
+	// is scoped to this pool's pods. Changes apply to newly created pool sandboxes
+	// only and do not mark existing ones as stale.
+	// +optional
+	// +listType=atomic
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// antiAffinity adds a preferred pod anti-affinity on the node hostname to each
+	// pool sandbox whose template defines no pod anti-affinity of its own, so a
+	// single node failure does not take out the whole pool. Being preferred rather
+	// than required, it never keeps pool pods from scheduling on small clusters.
+	// Changes apply to newly created pool sandboxes only and do not mark existing
+	// ones as stale.
+	//nolint:kubeapilinter // A plain opt-in switch; false keeps the pod template unchanged.
+	// +optional
+	AntiAffinity bool `json:"antiAffinity,omitempty"`
+
+	// prePull runs a DaemonSet owned by the pool that pulls the images of the
+	// pool's SandboxTemplate onto every node the template's nodeSelector and
+	// tolerations allow, so scale-ups and cold starts land on nodes that already
+	// have them. Images are pulled as image volumes, so nothing from them runs;
+	// this needs the ImageVolume feature enabled in the cluster. The DaemonSet
+	// pods use the template's runtimeClassName and pod securityContext.
+	// The DaemonSet is deleted when prePull is turned off or the pool is deleted.
+	//nolint:kubeapilinter // A plain opt-in switch; false runs no DaemonSet.
+	// +optional
+	PrePull bool `json:"prePull,omitempty"`
 }
 
 // SandboxWarmPoolUpdateStrategyType is a string enumeration type that enumerates
@@ -79,7 +152,9 @@ type SandboxWarmPoolUpdateStrategy struct {
 
 // SandboxWarmPoolStatus defines the observed state of SandboxWarmPool.
 type SandboxWarmPoolStatus struct {
-	// replicas is the total number of sandboxes in the pool.
+	// replicas is the total number of sandboxes in the pool, ready or not.
+	// This is the value the scale subresource reports, so HPAs and kubectl scale
+	// see the pool size rather than the lagging ready count during cold starts.
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
 
@@ -87,9 +162,20 @@ type SandboxWarmPoolStatus struct {
 	// +optional
 	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 
+	// availableReplicas is the number of sandboxes in the pool that have been ready
+	// for at least spec.minReadySeconds.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
 	// selector is the label selector used to find the pods in the pool.
 	// +optional
 	Selector string `json:"selector,omitempty"`
+
+	// conditions represent the latest available observations of the pool's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +genclient
@@ -98,6 +184,7 @@ type SandboxWarmPoolStatus struct {
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +kubebuilder:resource:scope=Namespaced,shortName=swp
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas",priority=1
 // +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
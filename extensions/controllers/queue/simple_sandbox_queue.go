@@ -16,6 +16,7 @@ package queue
 
 import (
 	"sync"
+	"time"
 )
 
 // SandboxKey uniquely identifies a sandbox in the queue.
@@ -23,6 +24,8 @@ type SandboxKey struct {
 	Namespace string
 	Name      string
 	NodeName  string
+	// ReadySince is when the sandbox last became Ready, or zero while it is not Ready.
+	ReadySince time.Time
 }
 
 // SandboxQueue defines the interface for managing a thread-safe,
@@ -127,10 +130,12 @@ func (q *synchronizedQueue) Push(key SandboxKey) {
 		q.set[uniqueID] = struct{}{}
 		q.items = append(q.items, key)
 	} else {
-		// Key already exists. Always update the NodeName to reflect latest placement state.
+		// Key already exists. Always update the NodeName and ReadySince to reflect
+		// the latest placement and readiness state.
 		for i := range q.items {
 			if q.items[i].Namespace == key.Namespace && q.items[i].Name == key.Name {
 				q.items[i].NodeName = key.NodeName
+				q.items[i].ReadySince = key.ReadySince
 				break
 			}
 		}
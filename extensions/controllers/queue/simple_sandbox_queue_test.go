@@ -15,6 +15,7 @@ package queue
 
 import (
 	"testing"
+	"time"
 )
 
 func TestSimpleSandboxQueue_BasicOperations(t *testing.T) {
@@ -113,6 +114,13 @@ func TestSynchronizedQueue_Deduplication(t *testing.T) {
 	if len(q.set) != 1 {
 		t.Errorf("Expected set length 1, got %d", len(q.set))
 	}
+
+	// Pushing it again refreshes its placement and readiness in place
+	readySince := time.Now()
+	q.Push(SandboxKey{Namespace: "default", Name: "duplicate-sb", NodeName: "node-1", ReadySince: readySince})
+	if len(q.items) != 1 || q.items[0].NodeName != "node-1" || !q.items[0].ReadySince.Equal(readySince) {
+		t.Errorf("Expected the queued key to be updated in place, got %+v", q.items)
+	}
 }
 
 func TestSimpleSandboxQueue_RemoveQueue_MemoryLeakFix(t *testing.T) {
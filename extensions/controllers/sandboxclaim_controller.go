@@ -37,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -79,6 +80,9 @@ var ErrCrossNamespaceAdoption = errors.New("cross-namespace adoption forbidden")
 // ErrEnvVarsInjectionRejected is a sentinel error indicating environment variable injection was rejected.
 var ErrEnvVarsInjectionRejected = errors.New("environment variable injection rejected")
 
+// ErrResourcesOverrideRejected is a sentinel error indicating a container resources override was rejected.
+var ErrResourcesOverrideRejected = errors.New("resources override rejected")
+
 // ErrVolumeClaimTemplatesDisallowed is a sentinel error indicating that volumeClaimTemplates are disallowed by the template.
 var ErrVolumeClaimTemplatesDisallowed = errors.New("volume claim templates are disallowed by the template")
 
@@ -92,6 +96,7 @@ var suppressErrors = []error{
 	ErrInvalidMetadata,
 	ErrSandboxNotOwned,
 	ErrEnvVarsInjectionRejected,
+	ErrResourcesOverrideRejected,
 	ErrVolumeClaimTemplatesDisallowed,
 	ErrVolumeClaimTemplatesOverrideForbidden,
 	ErrVolumeClaimTemplatesInvalid,
@@ -141,6 +146,15 @@ type SandboxClaimReconciler struct {
 	MaxConcurrentReconciles int
 	observedTimes           observedTimeMap
 	AllowedLabelDomains     []string
+	// Clock is used to evaluate readiness timeouts and expiry. Defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+func (r *SandboxClaimReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
 }
 
 //+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaims,verbs=get;list;watch;create;update;patch;delete
@@ -164,6 +178,7 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		if k8errors.IsNotFound(err) {
 			// Fallback cleanup to prevent memory leaks if the delete predicate was missed or a stale request is processed.
 			r.observedTimes.Delete(req.NamespacedName)
+			asmetrics.SetClaimPending(req.Namespace, req.Name, false)
 			logger.V(1).Info("SandboxClaim not found, ignoring", "request", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
@@ -190,6 +205,7 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	defer end()
 
 	if !claim.DeletionTimestamp.IsZero() {
+		asmetrics.SetClaimPending(claim.Namespace, claim.Name, false)
 		return ctrl.Result{}, nil
 	}
 
@@ -202,7 +218,33 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Check Expiration
 	// We calculate this upfront to decide the flow.
-	claimExpired, timeLeft := r.checkExpiration(claim)
+	claimExpired, timeLeft := r.checkExpiration(ctx, claim)
+
+	// Handle "Recycle" before the expiry is recorded: the claim is kept and, once its old
+	// Sandbox is gone, goes on below to acquire a fresh one as if it had not expired.
+	if hasClaimRecyclingCondition(claim.Status.Conditions) || (claimExpired && r.shouldRecycle(claim)) {
+		recycled, err := r.recycleSandbox(ctx, claim)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !recycled {
+			meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+				Type:               string(v1beta1.SandboxConditionReady),
+				Status:             metav1.ConditionFalse,
+				Reason:             extensionsv1beta1.ClaimRecyclingReason,
+				Message:            "Waiting for the old Sandbox to be deleted before acquiring a fresh one.",
+				ObservedGeneration: claim.Generation,
+			})
+			if updateErr := r.updateStatus(ctx, originalClaimStatus, claim); updateErr != nil {
+				logger.V(1).Info("Sandboxclaim UpdateStatus error encountered", "errors", updateErr, "request", req.NamespacedName)
+				return ctrl.Result{}, updateErr
+			}
+			// The Owns(&Sandbox{}) watch wakes the claim once the old Sandbox is gone.
+			return ctrl.Result{}, nil
+		}
+		claimExpired, timeLeft = r.checkExpiration(ctx, claim)
+	}
+
 	if claimExpired && !hasClaimExpiredCondition(claim.Status.Conditions) {
 		meta.SetStatusCondition(&claim.Status.Conditions, r.computeReadyCondition(claim, nil, nil, true))
 		if updateErr := r.updateStatus(ctx, originalClaimStatus, claim); updateErr != nil {
@@ -244,10 +286,14 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	var sandbox *v1beta1.Sandbox
 	var reconcileErr error
 
+	readinessTimedOut, readinessTimeLeft := r.checkReadinessTimeout(claim)
 	if claimExpired {
-		// Policy=Retain (since Delete handled above)
+		// Policy=Retain, or Recycle past shutdownTime (since Delete handled above)
 		// Ensure Sandbox is deleted, but keep the Claim.
 		sandbox, reconcileErr = r.reconcileExpired(ctx, claim)
+	} else if readinessTimedOut {
+		// Terminal: stop acquiring a Sandbox and clean up the failed one.
+		sandbox, reconcileErr = r.reconcileReadinessTimeout(ctx, claim)
 	} else {
 		// Ensure Sandbox exists and is configured.
 		sandbox, reconcileErr = r.reconcileActive(ctx, claim)
@@ -255,7 +301,21 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Update Status & Events
 	r.computeAndSetStatus(claim, sandbox, reconcileErr, claimExpired)
-	postExpiration, postTimeLeft := r.checkExpiration(claim)
+	if readinessTimedOut && !claimExpired {
+		if !hasClaimReadinessTimeoutCondition(originalClaimStatus.Conditions) && r.Recorder != nil {
+			r.Recorder.Eventf(claim, sandbox, corev1.EventTypeWarning, extensionsv1beta1.ClaimReadinessTimeoutReason, "Timeout",
+				"Sandbox did not become ready within %ds", *claim.Spec.ReadinessTimeoutSeconds)
+		}
+		meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+			Type:               string(v1beta1.SandboxConditionReady),
+			Status:             metav1.ConditionFalse,
+			Reason:             extensionsv1beta1.ClaimReadinessTimeoutReason,
+			Message:            fmt.Sprintf("Sandbox did not become ready within %ds of the claim's creation", *claim.Spec.ReadinessTimeoutSeconds),
+			ObservedGeneration: claim.Generation,
+		})
+	}
+	recordClaimBindMetrics(claim, originalClaimStatus, reconcileErr)
+	postExpiration, postTimeLeft := r.checkExpiration(ctx, claim)
 	if postExpiration && !hasClaimExpiredCondition(claim.Status.Conditions) {
 		meta.SetStatusCondition(&claim.Status.Conditions, r.computeReadyCondition(claim, sandbox, reconcileErr, true))
 		if updateErr := r.updateStatus(ctx, originalClaimStatus, claim); updateErr != nil {
@@ -287,6 +347,10 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		} else if postTimeLeft > 0 {
 			result = ctrl.Result{RequeueAfter: postTimeLeft}
 		}
+		// Check back when the readiness timeout fires unless the claim got Ready by then.
+		if readinessTimeLeft > 0 && !isClaimReady(claim) && (result.RequeueAfter == 0 || readinessTimeLeft < result.RequeueAfter) {
+			result.RequeueAfter = readinessTimeLeft
+		}
 	}
 
 	// Requeue if dependency is missing, but don't return error to avoid log spam
@@ -297,8 +361,8 @@ func (r *SandboxClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			logger.V(1).Info("SandboxTemplate of the warmpool not found yet, will retry", "warmPool", claim.Spec.WarmPoolRef.Name, "error", reconcileErr)
 		}
 
-		// TODO: This 1-minute requeue creates a latency regression vs an immediate watch trigger.
-		// Consider adding a lightweight SandboxTemplate -> claims map watch to reconcile promptly.
+		// The SandboxWarmPool and SandboxTemplate watches wake these claims once the
+		// dependency shows up; the 1-minute requeue is only a fallback.
 		requeueDelay := 1 * time.Minute
 		if result.RequeueAfter > 0 && result.RequeueAfter < requeueDelay {
 			requeueDelay = result.RequeueAfter
@@ -342,13 +406,102 @@ func (r *SandboxClaimReconciler) initializeAnnotations(ctx context.Context, clai
 }
 
 // checkExpiration calculates if the claim is expired and how much time is left.
-func (r *SandboxClaimReconciler) checkExpiration(claim *extensionsv1beta1.SandboxClaim) (bool, time.Duration) {
+func (r *SandboxClaimReconciler) checkExpiration(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) (bool, time.Duration) {
 	if claim.Spec.Lifecycle == nil {
 		return false, 0
 	}
 
 	finishedCondition := lifecycle.FinishedCondition(claim.Status.Conditions, string(v1beta1.SandboxConditionFinished))
-	return lifecycle.TimeLeft(time.Now(), claim.Spec.Lifecycle.ShutdownTime, claim.Spec.Lifecycle.TTLSecondsAfterFinished, finishedCondition)
+	expired, timeLeft := lifecycle.TimeLeft(r.now(), claim.Spec.Lifecycle.ShutdownTime, claim.Spec.Lifecycle.TTLSecondsAfterFinished, finishedCondition)
+	if expired || claim.Spec.Lifecycle.IdleTimeout == nil {
+		return expired, timeLeft
+	}
+
+	idleLeft := r.lastActivity(ctx, claim).Add(claim.Spec.Lifecycle.IdleTimeout.Duration).Sub(r.now())
+	if idleLeft <= 0 {
+		return true, 0
+	}
+	if timeLeft == 0 || idleLeft < timeLeft {
+		timeLeft = idleLeft
+	}
+	return false, timeLeft
+}
+
+// lastActivity returns when the claim was last active: the latest LastActivityAnnotation
+// on the claim or on the Sandbox it is bound to, where the sandbox-router records proxied
+// requests. The claim's creation is the floor, and annotations that do not parse are ignored.
+func (r *SandboxClaimReconciler) lastActivity(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) time.Time {
+	stamps := []string{claim.Annotations[extensionsv1beta1.LastActivityAnnotation]}
+	if name := claim.Status.SandboxStatus.Name; name != "" {
+		sandbox := &v1beta1.Sandbox{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: name}, sandbox); err == nil && metav1.IsControlledBy(sandbox, claim) {
+			stamps = append(stamps, sandbox.Annotations[v1beta1.SandboxLastActivityAnnotation])
+		}
+	}
+
+	last := claim.CreationTimestamp.Time
+	for _, stamp := range stamps {
+		if t, err := time.Parse(time.RFC3339, stamp); err == nil && t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// shouldRecycle reports whether an expired claim replaces its Sandbox instead of shutting
+// down. Reaching shutdownTime is final, so such a claim is retained even under Recycle.
+func (r *SandboxClaimReconciler) shouldRecycle(claim *extensionsv1beta1.SandboxClaim) bool {
+	lc := claim.Spec.Lifecycle
+	if lc == nil || lc.ShutdownPolicy != extensionsv1beta1.ShutdownPolicyRecycle {
+		return false
+	}
+	return lc.ShutdownTime == nil || r.now().Before(lc.ShutdownTime.Time)
+}
+
+// recycleSandbox deletes the claim's Sandbox under the Recycle policy and reports whether
+// it is gone. Once it is, the claim is unbound from it, its Finished condition is dropped
+// and its idle timer restarts, so the rest of the reconcile acquires a fresh Sandbox.
+func (r *SandboxClaimReconciler) recycleSandbox(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	// Fall back to claim.Name when status is unset.
+	statusName := claim.Name
+	if claim.Status.SandboxStatus.Name != "" {
+		statusName = claim.Status.SandboxStatus.Name
+	}
+
+	sandbox := &v1beta1.Sandbox{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: statusName}, sandbox)
+	if err != nil && !k8errors.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil && metav1.IsControlledBy(sandbox, claim) {
+		if sandbox.DeletionTimestamp.IsZero() {
+			logger.Info("Deleting Sandbox to recycle Claim (Policy=Recycle)", "sandbox", sandbox.Name, "claim", claim.Name)
+			if err := r.Delete(ctx, sandbox); err != nil && !k8errors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to delete sandbox %q for recycling: %w", sandbox.Name, err)
+			}
+			if r.Recorder != nil {
+				r.Recorder.Eventf(claim, sandbox, corev1.EventTypeNormal, extensionsv1beta1.ClaimRecyclingReason, "Recycle",
+					"Deleting Sandbox %q to replace it with a fresh one", sandbox.Name)
+			}
+		}
+		return false, nil
+	}
+
+	patch := client.MergeFrom(claim.DeepCopy())
+	if claim.Annotations == nil {
+		claim.Annotations = make(map[string]string)
+	}
+	delete(claim.Annotations, extensionsv1beta1.AssignedSandboxNameAnnotation)
+	delete(claim.Labels, extensionsv1beta1.DeprecatedAssignedSandboxNameLabel)
+	claim.Annotations[extensionsv1beta1.LastActivityAnnotation] = r.now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, claim, patch); err != nil {
+		return false, fmt.Errorf("failed to reset claim %q for recycling: %w", claim.Name, err)
+	}
+	claim.Status.SandboxStatus = extensionsv1beta1.SandboxStatus{}
+	meta.RemoveStatusCondition(&claim.Status.Conditions, string(v1beta1.SandboxConditionFinished))
+	return true, nil
 }
 
 // reconcileActive handles the creation and updates of running sandboxes.
@@ -382,6 +535,14 @@ func (r *SandboxClaimReconciler) reconcileActive(ctx context.Context, claim *ext
 		}
 
 		if template != nil {
+			recreating, err := r.reconcileTemplateUpdate(ctx, claim, sandbox, template)
+			if err != nil {
+				return sandbox, err
+			}
+			if recreating {
+				return nil, nil
+			}
+
 			patch := client.MergeFrom(sandbox.DeepCopy())
 			// Check if metadata needs update
 			var mergedMeta v1beta1.PodMetadata
@@ -402,7 +563,7 @@ func (r *SandboxClaimReconciler) reconcileActive(ctx context.Context, claim *ext
 				delete(mergedMeta.Labels, v1beta1.CreatedByLabel)
 			}
 
-			if err := r.mergePodMetadata(&mergedMeta, &claim.Spec.AdditionalPodMetadata); err != nil {
+			if err := r.mergePodMetadata(&mergedMeta, &claim.Spec.AdditionalPodMetadata, template.Spec.PodMetadataOverridePolicy); err != nil {
 				return nil, err
 			}
 
@@ -451,6 +612,100 @@ func (r *SandboxClaimReconciler) reconcileActive(ctx context.Context, claim *ext
 	return r.createSandbox(ctx, claim, template)
 }
 
+// reconcileTemplateUpdate applies the claim's updatePolicy to a Sandbox that was
+// built from an older version of template. Under Recreate, the Sandbox is deleted
+// so that a later reconcile replaces it; recreating reports whether that is in
+// progress. A Sandbox without the applied template hash label predates it and is
+// assumed to match the current template.
+func (r *SandboxClaimReconciler) reconcileTemplateUpdate(ctx context.Context, claim *extensionsv1beta1.SandboxClaim, sandbox *v1beta1.Sandbox, template *extensionsv1beta1.SandboxTemplate) (recreating bool, err error) {
+	if claim.Spec.UpdatePolicy != extensionsv1beta1.SandboxClaimUpdatePolicyRecreate {
+		return false, nil
+	}
+	currentHash, err := computeSandboxBlueprintHash(template)
+	if err != nil {
+		return false, err
+	}
+	appliedHash := sandbox.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel]
+	if appliedHash == "" {
+		patch := client.MergeFrom(sandbox.DeepCopy())
+		if sandbox.Labels == nil {
+			sandbox.Labels = make(map[string]string)
+		}
+		sandbox.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel] = currentHash
+		if err := r.Patch(ctx, sandbox, patch); err != nil {
+			return false, fmt.Errorf("failed to record template hash on sandbox %q: %w", sandbox.Name, err)
+		}
+		return false, nil
+	}
+	if appliedHash == currentHash {
+		return false, nil
+	}
+	if !sandbox.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	log.FromContext(ctx).Info("Recreating sandbox because its template changed", "claim", claim.Name, "sandbox", sandbox.Name, "template", template.Name)
+	if err := r.Delete(ctx, sandbox); err != nil && !k8errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete sandbox %q for recreation: %w", sandbox.Name, err)
+	}
+	if r.Recorder != nil {
+		r.Recorder.Eventf(claim, sandbox, corev1.EventTypeNormal, "SandboxRecreating", "Recreate",
+			"Deleting Sandbox %q to apply changes to SandboxTemplate %q", sandbox.Name, template.Name)
+	}
+	return true, nil
+}
+
+// checkReadinessTimeout reports whether the claim has waited longer than
+// spec.readinessTimeoutSeconds for its Sandbox to become Ready and, if not, how long
+// it has left. A claim that has been Ready once is never timed out.
+func (r *SandboxClaimReconciler) checkReadinessTimeout(claim *extensionsv1beta1.SandboxClaim) (bool, time.Duration) {
+	if claim.Spec.ReadinessTimeoutSeconds == nil || isClaimReady(claim) ||
+		claim.Annotations[asmetrics.CreationLatencyRecordedAnnotation] == "true" {
+		return false, 0
+	}
+	deadline := claim.CreationTimestamp.Add(time.Duration(*claim.Spec.ReadinessTimeoutSeconds) * time.Second)
+	timeLeft := deadline.Sub(r.now())
+	if timeLeft <= 0 {
+		return true, 0
+	}
+	return false, timeLeft
+}
+
+// reconcileReadinessTimeout handles a claim whose Sandbox never became Ready: no new
+// Sandbox is acquired, and the failed one is deleted if the shutdown policy deletes.
+func (r *SandboxClaimReconciler) reconcileReadinessTimeout(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) (*v1beta1.Sandbox, error) {
+	logger := log.FromContext(ctx)
+	if claim.Status.SandboxStatus.Name == "" {
+		return nil, nil
+	}
+
+	sandbox := &v1beta1.Sandbox{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Status.SandboxStatus.Name}, sandbox); err != nil {
+		if k8errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !metav1.IsControlledBy(sandbox, claim) {
+		return nil, nil
+	}
+
+	deleteSandbox := claim.Spec.Lifecycle != nil &&
+		(claim.Spec.Lifecycle.ShutdownPolicy == extensionsv1beta1.ShutdownPolicyDelete ||
+			claim.Spec.Lifecycle.ShutdownPolicy == extensionsv1beta1.ShutdownPolicyDeleteForeground)
+	if deleteSandbox && sandbox.DeletionTimestamp.IsZero() {
+		logger.Info("Deleting Sandbox because it did not become ready in time", "sandbox", sandbox.Name, "claim", claim.Name)
+		if err := r.Delete(ctx, sandbox); err != nil {
+			return sandbox, client.IgnoreNotFound(err)
+		}
+	}
+	return sandbox, nil
+}
+
+func isClaimReady(claim *extensionsv1beta1.SandboxClaim) bool {
+	return meta.IsStatusConditionTrue(claim.Status.Conditions, string(v1beta1.SandboxConditionReady))
+}
+
 // reconcileExpired ensures the Sandbox is deleted for Retained claims.
 func (r *SandboxClaimReconciler) reconcileExpired(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) (*v1beta1.Sandbox, error) {
 	logger := log.FromContext(ctx)
@@ -566,6 +821,15 @@ func (r *SandboxClaimReconciler) computeReadyCondition(claim *extensionsv1beta1.
 				ObservedGeneration: claim.Generation,
 			}
 		}
+		if errors.Is(err, ErrResourcesOverrideRejected) {
+			return metav1.Condition{
+				Type:               string(v1beta1.SandboxConditionReady),
+				Status:             metav1.ConditionFalse,
+				Reason:             "ResourcesOverrideRejected",
+				Message:            err.Error(),
+				ObservedGeneration: claim.Generation,
+			}
+		}
 		if errors.Is(err, ErrSandboxNotOwned) {
 			return metav1.Condition{
 				Type:               string(v1beta1.SandboxConditionReady),
@@ -650,16 +914,40 @@ func (r *SandboxClaimReconciler) computeAndSetStatus(claim *extensionsv1beta1.Sa
 
 	if sandbox != nil {
 		claim.Status.SandboxStatus.Name = sandbox.Name
+		claim.Status.SandboxStatus.ServiceFQDN = sandbox.Status.ServiceFQDN
+		claim.Status.SandboxStatus.PodName = sandbox.Status.PodName
 		claim.Status.SandboxStatus.PodIPs = sandbox.Status.PodIPs
 	} else if err == nil || errors.Is(err, ErrSandboxNotOwned) {
 		// Only clear bound sandbox identity when there is no error (sandbox legitimately deleted or unbound)
 		// or when ownership verification fails. Never clear on transient lookup or patch errors, as wiping
 		// status.sandbox.name forces a fallback to cold-start on the next reconcile retry.
-		claim.Status.SandboxStatus.Name = ""
-		claim.Status.SandboxStatus.PodIPs = nil
+		claim.Status.SandboxStatus = extensionsv1beta1.SandboxStatus{}
 	}
 }
 
+// recordClaimBindMetrics counts a claim getting its first Sandbox, or failing to,
+// and tracks whether it is pending: bound to a Sandbox that is not ready yet.
+func recordClaimBindMetrics(claim *extensionsv1beta1.SandboxClaim, oldStatus *extensionsv1beta1.SandboxClaimStatus, reconcileErr error) {
+	if oldStatus.SandboxStatus.Name == "" {
+		switch {
+		case claim.Status.SandboxStatus.Name != "":
+			asmetrics.RecordClaimBind(asmetrics.ClaimBindResultSuccess)
+		case reconcileErr != nil:
+			asmetrics.RecordClaimBind(asmetrics.ClaimBindResultError)
+		}
+	}
+	asmetrics.SetClaimPending(claim.Namespace, claim.Name, isClaimPending(claim))
+}
+
+// isClaimPending reports whether the claim's Ready condition says it is waiting on
+// its Sandbox, either not reporting readiness yet or still provisioning.
+func isClaimPending(claim *extensionsv1beta1.SandboxClaim) bool {
+	cond := meta.FindStatusCondition(claim.Status.Conditions, string(v1beta1.SandboxConditionReady))
+	return cond != nil && cond.Status == metav1.ConditionFalse &&
+		(cond.Reason == "SandboxNotReady" || cond.Reason == v1beta1.SandboxReasonDependenciesNotReady ||
+			cond.Reason == v1beta1.SandboxReasonUnschedulable)
+}
+
 func (r *SandboxClaimReconciler) syncFinishedCondition(claim *extensionsv1beta1.SandboxClaim, sandbox *v1beta1.Sandbox, isClaimExpired bool) {
 	if sandbox != nil {
 		finishedCondition := meta.FindStatusCondition(sandbox.Status.Conditions, string(v1beta1.SandboxConditionFinished))
@@ -701,6 +989,30 @@ func (r *SandboxClaimReconciler) getCandidate(ctx context.Context, claim *extens
 
 	namespacedWarmPoolName := queue.GetNamespacedWarmPoolName(claim.Namespace, claim.Spec.WarmPoolRef.Name)
 
+	// Sandboxes Ready for less than the pool's minReadySeconds are only adopted
+	// when no available one is left.
+	var minReadySeconds int32
+	warmPool := &extensionsv1beta1.SandboxWarmPool{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Spec.WarmPoolRef.Name}, warmPool); err == nil {
+		minReadySeconds = warmPool.Spec.MinReadySeconds
+	} else if !k8errors.IsNotFound(err) {
+		return nil, queue.SandboxKey{}, fmt.Errorf("failed to get sandbox warm pool %q: %w", claim.Spec.WarmPoolRef.Name, err)
+	}
+	// A claim that recreates its Sandbox on template changes would immediately
+	// recreate a stale one, so those are left for other claims.
+	var currentTemplateHash string
+	if claim.Spec.UpdatePolicy == extensionsv1beta1.SandboxClaimUpdatePolicyRecreate && warmPool.Spec.TemplateRef.Name != "" {
+		template := &extensionsv1beta1.SandboxTemplate{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: warmPool.Spec.TemplateRef.Name}, template); err == nil {
+			if hash, err := computeSandboxBlueprintHash(template); err == nil {
+				currentTemplateHash = hash
+			}
+		} else if !k8errors.IsNotFound(err) {
+			return nil, queue.SandboxKey{}, fmt.Errorf("failed to get sandbox template %q: %w", warmPool.Spec.TemplateRef.Name, err)
+		}
+	}
+	now := time.Now()
+
 	var skipped []queue.SandboxKey
 	var fallbackSandbox *v1beta1.Sandbox
 	var fallbackKey queue.SandboxKey
@@ -717,15 +1029,27 @@ func (r *SandboxClaimReconciler) getCandidate(ctx context.Context, claim *extens
 		}
 	}()
 
-	// Strategy helper to pick candidate using in-memory NodeSpread and FIFO tie-breaking
+	// Strategy helper to pick candidate using in-memory NodeSpread, preferring
+	// available sandboxes and, among equals, the one that has been Ready longest.
 	pickSmart := func(keys []queue.SandboxKey) (queue.SandboxKey, bool) {
-		namespaceKeys := keys
-
-		if len(namespaceKeys) == 0 {
+		if len(keys) == 0 {
 			return queue.SandboxKey{}, false
 		}
-		if len(namespaceKeys) == 1 {
-			return namespaceKeys[0], true
+		if len(keys) == 1 {
+			return keys[0], true
+		}
+
+		// Only consider the sandboxes the queue knows to be available, unless
+		// there are none; the loop below then settles for the best fallback.
+		namespaceKeys := keys
+		var availableKeys []queue.SandboxKey
+		for _, key := range keys {
+			if !key.ReadySince.IsZero() && !key.ReadySince.Add(time.Duration(minReadySeconds)*time.Second).After(now) {
+				availableKeys = append(availableKeys, key)
+			}
+		}
+		if len(availableKeys) > 0 {
+			namespaceKeys = availableKeys
 		}
 
 		// Group candidates into scheduled vs unscheduled
@@ -762,12 +1086,11 @@ func (r *SandboxClaimReconciler) getCandidate(ctx context.Context, claim *extens
 				}
 			}
 
-			// Ties (equal counts) are resolved using oldest first (first in the slice)
-			return bestCandidates[0], true
+			// Ties (equal counts) are resolved by the longest Ready sandbox
+			return longestReadyKey(bestCandidates), true
 		}
 
-		// Fall back to oldest first (FIFO) for unscheduled keys
-		return unscheduledKeys[0], true
+		return longestReadyKey(unscheduledKeys), true
 	}
 
 	for {
@@ -804,24 +1127,62 @@ func (r *SandboxClaimReconciler) getCandidate(ctx context.Context, claim *extens
 			continue
 		}
 
-		// Candidate is valid! Now check if it is Ready
-		if isSandboxReady(adopted) {
-			// Found a Ready sandbox! Adopt it immediately.
+		if hash := adopted.Labels[v1beta1.SandboxTemplateHashLabel]; currentTemplateHash != "" && hash != "" && hash != currentTemplateHash {
+			logger.V(1).Info("sandbox candidate was built from an older template", "sandbox", adopted.Name, "warmPool", claim.Spec.WarmPoolRef.Name)
+			skipped = append(skipped, adoptedKey)
+			continue
+		}
+
+		// Candidate is valid! Now check if it is available
+		if isSandboxAvailable(adopted, minReadySeconds, now) {
+			// Found an available sandbox! Adopt it immediately.
 			return adopted, adoptedKey, nil
 		}
 
-		// Sandbox is valid but NOT Ready.
-		// Keep the first unready sandbox we found as fallback.
-		if fallbackSandbox == nil {
+		// Sandbox is valid but NOT available.
+		// Keep the first unready sandbox we found as fallback, unless a Ready one
+		// still within minReadySeconds comes along.
+		switch {
+		case fallbackSandbox == nil:
 			fallbackSandbox = adopted
 			fallbackKey = adoptedKey
-		} else {
+		case isSandboxReady(adopted) && !isSandboxReady(fallbackSandbox):
+			skipped = append(skipped, fallbackKey)
+			fallbackSandbox = adopted
+			fallbackKey = adoptedKey
+		default:
 			// Push subsequent unready sandboxes to skipped so they go back to the queue
 			skipped = append(skipped, adoptedKey)
 		}
 	}
 }
 
+// longestReadyKey returns the key of the sandbox that has been Ready the longest,
+// so that a pod that just restarted is not handed out while steadier ones wait.
+// Keys without a ready time come last, and ties keep queue (FIFO) order.
+func longestReadyKey(keys []queue.SandboxKey) queue.SandboxKey {
+	best := keys[0]
+	for _, key := range keys[1:] {
+		if key.ReadySince.IsZero() {
+			continue
+		}
+		if best.ReadySince.IsZero() || key.ReadySince.Before(best.ReadySince) {
+			best = key
+		}
+	}
+	return best
+}
+
+// sandboxReadySince returns when the sandbox last became Ready, which follows the
+// readiness of its pod, or the zero time while it is not Ready.
+func sandboxReadySince(sb *v1beta1.Sandbox) time.Time {
+	cond := meta.FindStatusCondition(sb.Status.Conditions, string(v1beta1.SandboxConditionReady))
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return time.Time{}
+	}
+	return cond.LastTransitionTime.Time
+}
+
 func (r *SandboxClaimReconciler) adoptSandboxFromCandidates(ctx context.Context, claim *extensionsv1beta1.SandboxClaim) (*v1beta1.Sandbox, error) {
 	logger := log.FromContext(ctx)
 	namespacedWarmPoolNameForQueue := queue.GetNamespacedWarmPoolName(claim.Namespace, claim.Spec.WarmPoolRef.Name)
@@ -909,6 +1270,15 @@ func (r *SandboxClaimReconciler) completeAdoption(ctx context.Context, claim *ex
 
 	templateHash := adopted.Labels[sandboxTemplateRefHash]
 
+	// The warm pool built the sandbox from the template hashed into its blueprint
+	// hash label; keep that as the template applied for the claim.
+	if blueprintHash := adopted.Labels[v1beta1.SandboxTemplateHashLabel]; blueprintHash != "" {
+		if adopted.Labels == nil {
+			adopted.Labels = make(map[string]string)
+		}
+		adopted.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel] = blueprintHash
+	}
+
 	// Remove warm pool labels so the sandbox no longer appears in warm pool queries
 	delete(adopted.Labels, warmPoolSandboxLabel)
 	delete(adopted.Labels, v1beta1.DeprecatedSandboxPodTemplateHashLabel)
@@ -981,7 +1351,7 @@ func (r *SandboxClaimReconciler) completeAdoption(ctx context.Context, claim *ex
 			delete(mergedMeta.Labels, v1beta1.CreatedByLabel)
 		}
 
-		if err := r.mergePodMetadata(&mergedMeta, &claim.Spec.AdditionalPodMetadata); err != nil {
+		if err := r.mergePodMetadata(&mergedMeta, &claim.Spec.AdditionalPodMetadata, template.Spec.PodMetadataOverridePolicy); err != nil {
 			return err
 		}
 
@@ -993,7 +1363,7 @@ func (r *SandboxClaimReconciler) completeAdoption(ctx context.Context, claim *ex
 			adopted.Spec.PodTemplate.ObjectMeta.Labels[sandboxTemplateRefHash] = templateHash
 		}
 
-		if err := r.mergePodMetadata(&adopted.Spec.PodTemplate.ObjectMeta, &claim.Spec.AdditionalPodMetadata); err != nil {
+		if err := r.mergePodMetadata(&adopted.Spec.PodTemplate.ObjectMeta, &claim.Spec.AdditionalPodMetadata, extensionsv1beta1.PodMetadataOverridePolicyDisallowed); err != nil {
 			return err
 		}
 	}
@@ -1015,6 +1385,16 @@ func isSandboxReady(sb *v1beta1.Sandbox) bool {
 	return false
 }
 
+// isSandboxAvailable checks if a sandbox has been Ready for at least minReadySeconds.
+func isSandboxAvailable(sb *v1beta1.Sandbox, minReadySeconds int32, now time.Time) bool {
+	cond := meta.FindStatusCondition(sb.Status.Conditions, string(v1beta1.SandboxConditionReady))
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return false
+	}
+	minReady := time.Duration(minReadySeconds) * time.Second
+	return minReady == 0 || !cond.LastTransitionTime.Add(minReady).After(now)
+}
+
 func isRestrictedDomain(domain string) bool {
 	for _, d := range restrictedDomains {
 		if domain == d || strings.HasSuffix(domain, "."+d) {
@@ -1102,23 +1482,33 @@ func (r *SandboxClaimReconciler) validateAdditionalPodMetadata(claimMeta *v1beta
 	return nil
 }
 
-// mergePodMetadata merges labels and annotations from claimMeta into templateMeta,
-// rejecting overrides with different values.
-func (r *SandboxClaimReconciler) mergePodMetadata(templateMeta *v1beta1.PodMetadata, claimMeta *v1beta1.PodMetadata) error {
+// mergePodMetadata merges labels and annotations from claimMeta into templateMeta.
+// A claim value that differs from the template's is rejected unless policy is
+// Overrides, and is always rejected for keys in restricted system domains other than
+// exemptedMetadataKeys, which covers the labels the controller itself sets.
+func (r *SandboxClaimReconciler) mergePodMetadata(templateMeta *v1beta1.PodMetadata, claimMeta *v1beta1.PodMetadata, policy extensionsv1beta1.PodMetadataOverridePolicy) error {
 	if err := r.validateAdditionalPodMetadata(claimMeta); err != nil {
 		return err
 	}
 
+	overridable := func(key string) bool {
+		if policy != extensionsv1beta1.PodMetadataOverridePolicyOverrides {
+			return false
+		}
+		domain, _, found := strings.Cut(key, "/")
+		return !found || !isRestrictedDomain(strings.ToLower(domain)) || slices.Contains(exemptedMetadataKeys, key)
+	}
+
 	// Check for overrides in labels
 	for k, v := range claimMeta.Labels {
-		if tv, ok := templateMeta.Labels[k]; ok && tv != v {
+		if tv, ok := templateMeta.Labels[k]; ok && tv != v && !overridable(k) {
 			return fmt.Errorf("metadata override conflict: label %q is defined in template with value %q, but claim requests %q", k, tv, v)
 		}
 	}
 
 	// Check for overrides in annotations
 	for k, v := range claimMeta.Annotations {
-		if tv, ok := templateMeta.Annotations[k]; ok && tv != v {
+		if tv, ok := templateMeta.Annotations[k]; ok && tv != v && !overridable(k) {
 			return fmt.Errorf("metadata override conflict: annotation %q is defined in template with value %q, but claim requests %q", k, tv, v)
 		}
 	}
@@ -1172,6 +1562,60 @@ func (r *SandboxClaimReconciler) injectEnvs(logger logr.Logger, container *corev
 	return nil
 }
 
+// applyResourceOverrides merges the claim's resource overrides into the template's
+// containers. Overrides are merged per resource name, so a claim raising the memory
+// request keeps the template's CPU request and limits.
+func applyResourceOverrides(containers []corev1.Container, overrides []extensionsv1beta1.ContainerResources, policy extensionsv1beta1.ResourcesOverridePolicy) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	if policy != extensionsv1beta1.ResourcesOverridePolicyAllowed {
+		return fmt.Errorf("%w: overriding container resources is not allowed by the template policy", ErrResourcesOverrideRejected)
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("%w: template defines no containers", ErrResourcesOverrideRejected)
+	}
+
+	seen := make(map[string]bool, len(overrides))
+	for _, override := range overrides {
+		name := override.ContainerName
+		if name == "" {
+			name = containers[0].Name
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: multiple resource overrides target container %q", ErrResourcesOverrideRejected, name)
+		}
+		seen[name] = true
+
+		idx := slices.IndexFunc(containers, func(c corev1.Container) bool { return c.Name == name })
+		if idx < 0 {
+			return fmt.Errorf("%w: target container %q not found in template", ErrResourcesOverrideRejected, name)
+		}
+		resources := &containers[idx].Resources
+		if len(override.Requests) > 0 {
+			if resources.Requests == nil {
+				resources.Requests = make(corev1.ResourceList, len(override.Requests))
+			}
+			maps.Copy(resources.Requests, override.Requests)
+		}
+		if len(override.Limits) > 0 {
+			if resources.Limits == nil {
+				resources.Limits = make(corev1.ResourceList, len(override.Limits))
+			}
+			maps.Copy(resources.Limits, override.Limits)
+		}
+		// Catch a request raised above the template's limit here rather than
+		// letting the Sandbox controller fail to create the Pod.
+		for resourceName, request := range resources.Requests {
+			if limit, ok := resources.Limits[resourceName]; ok && request.Cmp(limit) > 0 {
+				return fmt.Errorf("%w: container %q %s request %s exceeds its limit %s",
+					ErrResourcesOverrideRejected, name, resourceName, request.String(), limit.String())
+			}
+		}
+	}
+	return nil
+}
+
 func (r *SandboxClaimReconciler) createSandbox(ctx context.Context, claim *extensionsv1beta1.SandboxClaim, template *extensionsv1beta1.SandboxTemplate) (*v1beta1.Sandbox, error) {
 	logger := log.FromContext(ctx)
 
@@ -1231,10 +1675,15 @@ func (r *SandboxClaimReconciler) createSandbox(ctx context.Context, claim *exten
 	sandbox.Labels = ensureClaimIdentityLabels(sandbox.Labels, claim)
 	sandbox.Labels[v1beta1.SandboxLaunchTypeLabel] = v1beta1.SandboxLaunchTypeCold
 	sandbox.Labels[sandboxTemplateRefHash] = templateHash
+	blueprintHash, err := computeSandboxBlueprintHash(template)
+	if err != nil {
+		return nil, err
+	}
+	sandbox.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel] = blueprintHash
 	sandbox.Spec.PodTemplate.ObjectMeta.Labels = ensureClaimIdentityLabels(sandbox.Spec.PodTemplate.ObjectMeta.Labels, claim)
 	sandbox.Spec.PodTemplate.ObjectMeta.Labels[sandboxTemplateRefHash] = templateHash
 
-	if err := r.mergePodMetadata(&sandbox.Spec.PodTemplate.ObjectMeta, &claim.Spec.AdditionalPodMetadata); err != nil {
+	if err := r.mergePodMetadata(&sandbox.Spec.PodTemplate.ObjectMeta, &claim.Spec.AdditionalPodMetadata, template.Spec.PodMetadataOverridePolicy); err != nil {
 		return nil, err
 	}
 
@@ -1306,6 +1755,11 @@ func (r *SandboxClaimReconciler) createSandbox(ctx context.Context, claim *exten
 		}
 	}
 
+	if err := applyResourceOverrides(sandbox.Spec.PodTemplate.Spec.Containers, claim.Spec.Resources, template.Spec.ResourcesOverridePolicy); err != nil {
+		logger.Error(err, "Resources override rejected", "claimName", claim.Name)
+		return nil, err
+	}
+
 	// Apply secure defaults to the sandbox pod spec
 	ApplySandboxSecureDefaults(template, &sandbox.Spec.PodTemplate.Spec)
 
@@ -1572,9 +2026,9 @@ func (r *SandboxClaimReconciler) getOrCreateSandbox(ctx context.Context, claim *
 	}
 
 	// Implicit Cold Start Detection (Bypassing the Queue):
-	// If len(claim.Spec.Env) > 0 or len(claim.Spec.VolumeClaimTemplates) > 0, the controller immediately bypasses the warm pool queue.
-	if len(claim.Spec.Env) > 0 || len(claim.Spec.VolumeClaimTemplates) > 0 {
-		logger.Info("Bypassing warm pool adoption because custom configuration is provided (env or volume claim templates)", "claim", claim.Name)
+	// If the claim sets env, resources or volumeClaimTemplates, the controller immediately bypasses the warm pool queue.
+	if len(claim.Spec.Env) > 0 || len(claim.Spec.Resources) > 0 || len(claim.Spec.VolumeClaimTemplates) > 0 {
+		logger.Info("Bypassing warm pool adoption because custom configuration is provided (env, resources or volume claim templates)", "claim", claim.Name)
 		return nil, nil
 	}
 
@@ -1684,6 +2138,7 @@ func (r *SandboxClaimReconciler) getTimingPredicate() predicate.Funcs {
 			if ok && entry.uid == e.Object.GetUID() {
 				r.observedTimes.Delete(key)
 			}
+			asmetrics.SetClaimPending(key.Namespace, key.Name, false)
 			return true
 		},
 	}
@@ -1728,20 +2183,62 @@ func (r *SandboxClaimReconciler) mapWarmPoolToClaims(ctx context.Context, obj cl
 	return requests
 }
 
+// mapTemplateToClaims enqueues the claims whose warm pool references the
+// SandboxTemplate. Unbound claims may be waiting for the template to appear
+// (ErrTemplateNotFound requeue path), and bound claims with updatePolicy
+// Recreate replace their Sandbox once it changes. Other bound claims never act
+// on template changes and are skipped, as are claims being deleted.
+func (r *SandboxClaimReconciler) mapTemplateToClaims(ctx context.Context, obj client.Object) []ctrl.Request {
+	template, ok := obj.(*extensionsv1beta1.SandboxTemplate)
+	if !ok {
+		log.FromContext(ctx).Error(fmt.Errorf("unexpected object type %T", obj), "expected SandboxTemplate in watch map function")
+		return nil
+	}
+	var warmPools extensionsv1beta1.SandboxWarmPoolList
+	if err := r.List(ctx, &warmPools, client.InNamespace(template.Namespace), client.MatchingFields{extensionsv1beta1.TemplateRefField: template.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list SandboxWarmPools for SandboxTemplate", "namespace", template.Namespace, "name", template.Name)
+		return nil
+	}
+	var requests []ctrl.Request
+	for i := range warmPools.Items {
+		var claims extensionsv1beta1.SandboxClaimList
+		if err := r.List(ctx, &claims, client.InNamespace(template.Namespace), client.MatchingFields{extensionsv1beta1.WarmPoolRefField: warmPools.Items[i].Name}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list SandboxClaims for SandboxWarmPool", "namespace", template.Namespace, "name", warmPools.Items[i].Name)
+			return nil
+		}
+		for j := range claims.Items {
+			claim := &claims.Items[j]
+			if !claim.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if claim.Status.SandboxStatus.Name != "" && claim.Spec.UpdatePolicy != extensionsv1beta1.SandboxClaimUpdatePolicyRecreate {
+				continue
+			}
+			requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}})
+		}
+	}
+	return requests
+}
+
+// sandboxClaimWarmPoolRefIndexer extracts the warm pool reference name for the
+// WarmPoolRefField cache field index. The warm pool controller lists claims
+// through the same index, and tests register it on fake clients.
+func sandboxClaimWarmPoolRefIndexer(rawObj client.Object) []string {
+	claim, ok := rawObj.(*extensionsv1beta1.SandboxClaim)
+	if !ok {
+		return nil
+	}
+	if claim.Spec.WarmPoolRef.Name == "" {
+		return nil
+	}
+	return []string{claim.Spec.WarmPoolRef.Name}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SandboxClaimReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWorkers int) error {
 	r.MaxConcurrentReconciles = concurrentWorkers
 
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &extensionsv1beta1.SandboxClaim{}, extensionsv1beta1.WarmPoolRefField, func(rawObj client.Object) []string {
-		claim, ok := rawObj.(*extensionsv1beta1.SandboxClaim)
-		if !ok {
-			return nil
-		}
-		if claim.Spec.WarmPoolRef.Name == "" {
-			return nil
-		}
-		return []string{claim.Spec.WarmPoolRef.Name}
-	}); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &extensionsv1beta1.SandboxClaim{}, extensionsv1beta1.WarmPoolRefField, sandboxClaimWarmPoolRefIndexer); err != nil {
 		return err
 	}
 
@@ -1766,8 +2263,11 @@ func (r *SandboxClaimReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWo
 			// ErrWarmPoolNotFound / ErrTemplateNotFound.
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
-		// TODO: Keep a lightweight SandboxTemplate -> claims map watch to promptly reconcile
-		// claims when a missing template is created, instead of relying on the 1-minute fallback.
+		Watches(
+			&extensionsv1beta1.SandboxTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTemplateToClaims),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(controller.Options{MaxConcurrentReconciles: concurrentWorkers}).
 		Complete(r)
 }
@@ -1964,6 +2464,16 @@ func hasClaimExpiredCondition(conditions []metav1.Condition) bool {
 	return readyCondition != nil && readyCondition.Reason == extensionsv1beta1.ClaimExpiredReason
 }
 
+func hasClaimRecyclingCondition(conditions []metav1.Condition) bool {
+	readyCondition := meta.FindStatusCondition(conditions, string(v1beta1.SandboxConditionReady))
+	return readyCondition != nil && readyCondition.Reason == extensionsv1beta1.ClaimRecyclingReason
+}
+
+func hasClaimReadinessTimeoutCondition(conditions []metav1.Condition) bool {
+	readyCondition := meta.FindStatusCondition(conditions, string(v1beta1.SandboxConditionReady))
+	return readyCondition != nil && readyCondition.Reason == extensionsv1beta1.ClaimReadinessTimeoutReason
+}
+
 // sandboxEventHandler implements handler.EventHandler for the SandboxClaimReconciler.
 type sandboxEventHandler struct {
 	sandboxQueue queue.SandboxQueue
@@ -1993,13 +2503,15 @@ func (h *sandboxEventHandler) Update(ctx context.Context, e event.UpdateEvent, _
 
 	poolChanged := oldWarmPoolName != newWarmPoolName
 	nodeScheduled := oldSandbox.Status.NodeName != newSandbox.Status.NodeName
+	readinessChanged := !sandboxReadySince(oldSandbox).Equal(sandboxReadySince(newSandbox))
 
-	if (!oldAdoptable && newAdoptable) || (newAdoptable && poolChanged) || (newAdoptable && nodeScheduled) {
+	if (!oldAdoptable && newAdoptable) || (newAdoptable && (poolChanged || nodeScheduled || readinessChanged)) {
 		// Add/update sandbox in the queue
 		key := queue.SandboxKey{
-			Namespace: newSandbox.Namespace,
-			Name:      newSandbox.Name,
-			NodeName:  newSandbox.Status.NodeName,
+			Namespace:  newSandbox.Namespace,
+			Name:       newSandbox.Name,
+			NodeName:   newSandbox.Status.NodeName,
+			ReadySince: sandboxReadySince(newSandbox),
 		}
 		logger.V(1).Info("Adding/updating sandbox in warm pool queue", "warmPool", newWarmPoolName, "namespace", newSandbox.Namespace, "sandbox", key)
 		if newWarmPoolName != "" {
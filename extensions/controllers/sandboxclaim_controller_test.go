@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -44,10 +45,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
-	sandboxcontrollers "sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/extensions/controllers/queue"
 	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 )
 
 func TestSandboxClaimReconcile(t *testing.T) {
@@ -124,7 +125,7 @@ func TestSandboxClaimReconcile(t *testing.T) {
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
 			ObjectMeta: sandboxv1beta1.PodMetadata{
 				Labels: map[string]string{
-					sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+					sandboxTemplateRefHash: naming.NameHash("test-template"),
 				},
 			},
 			Spec: template.Spec.PodTemplate.Spec,
@@ -147,7 +148,7 @@ func TestSandboxClaimReconcile(t *testing.T) {
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
 			ObjectMeta: sandboxv1beta1.PodMetadata{
 				Labels: map[string]string{
-					sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+					sandboxTemplateRefHash: naming.NameHash("test-template"),
 				},
 			},
 			Spec: template.Spec.PodTemplate.Spec,
@@ -921,8 +922,8 @@ func TestSandboxClaimReconcile(t *testing.T) {
 						Name:      "adoptable-warm-sandbox",
 						Namespace: "default",
 						Labels: map[string]string{
-							warmPoolSandboxLabel:   sandboxcontrollers.NameHash("test-warmpool-env-override"),
-							sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template-env-override"),
+							warmPoolSandboxLabel:   naming.NameHash("test-warmpool-env-override"),
+							sandboxTemplateRefHash: naming.NameHash("test-template-env-override"),
 						},
 						OwnerReferences: []metav1.OwnerReference{{
 							APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -1306,6 +1307,14 @@ func TestSandboxClaimCleanupPolicy(t *testing.T) {
 			expectSandboxStatusCleared: true,
 			expectStatus:               extensionsv1beta1.ClaimExpiredReason,
 		},
+		{
+			name:                 "Policy=Recycle past shutdownTime -> Should Retain Claim but DELETE Sandbox",
+			claim:                createClaim("recycle-claim-shutdown", extensionsv1beta1.ShutdownPolicyRecycle),
+			sandboxIsExpired:     false,
+			expectClaimDeleted:   false,
+			expectSandboxDeleted: true, // shutdownTime is final, so nothing is recycled.
+			expectStatus:         extensionsv1beta1.ClaimExpiredReason,
+		},
 		{
 			name:               "Policy=Delete && Sandbox Expired -> Should Delete Claim",
 			claim:              createClaim("delete-claim-synced", extensionsv1beta1.ShutdownPolicyDelete),
@@ -1513,6 +1522,83 @@ func TestSandboxClaimMirrorsFinishedConditionAndSchedulesTTL(t *testing.T) {
 	require.Equal(t, "SandboxNotReady", readyCondition.Reason)
 }
 
+func TestSandboxClaimStatusCopiesSandboxRoute(t *testing.T) {
+	scheme := newScheme(t)
+
+	claim := &extensionsv1beta1.SandboxClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "route-claim", Namespace: "default", UID: "route-claim"},
+		Spec: extensionsv1beta1.SandboxClaimSpec{
+			WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: "route-warmpool"},
+		},
+	}
+
+	template := &extensionsv1beta1.SandboxTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "route-template", Namespace: "default"},
+		Spec:       extensionsv1beta1.SandboxTemplateSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{}}},
+	}
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "route-warmpool", Namespace: "default"},
+		Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "route-template"}},
+	}
+
+	controller := true
+	sandbox := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: extensionsv1beta1.GroupVersion.String(),
+				Kind:       extensionsv1beta1.SandboxClaimKind,
+				Name:       claim.Name,
+				UID:        claim.UID,
+				Controller: &controller,
+			}},
+		},
+		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{}}},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(claim, template, warmPool, sandbox).
+		WithStatusSubresource(claim).
+		Build()
+
+	reconciler := &SandboxClaimReconciler{
+		Client:           client,
+		Scheme:           scheme,
+		Recorder:         events.NewFakeRecorder(10),
+		Tracer:           asmetrics.NewNoOp(),
+		WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claim.Name, Namespace: claim.Namespace}}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	updatedClaim := &extensionsv1beta1.SandboxClaim{}
+	require.NoError(t, client.Get(context.Background(), req.NamespacedName, updatedClaim))
+	require.Equal(t, claim.Name, updatedClaim.Status.SandboxStatus.Name)
+	require.Empty(t, updatedClaim.Status.SandboxStatus.ServiceFQDN)
+
+	// The Sandbox controller reports the route once the Service and pod exist.
+	require.NoError(t, client.Get(context.Background(), req.NamespacedName, sandbox))
+	sandbox.Status.ServiceFQDN = "route-claim.default.svc.cluster.local"
+	sandbox.Status.PodName = "route-claim"
+	sandbox.Status.PodIPs = []string{"10.244.0.7"}
+	require.NoError(t, client.Update(context.Background(), sandbox))
+
+	_, err = reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Get(context.Background(), req.NamespacedName, updatedClaim))
+	require.Equal(t, extensionsv1beta1.SandboxStatus{
+		Name:        claim.Name,
+		ServiceFQDN: "route-claim.default.svc.cluster.local",
+		PodName:     "route-claim",
+		PodIPs:      []string{"10.244.0.7"},
+	}, updatedClaim.Status.SandboxStatus)
+}
+
 func TestSandboxClaimTTLAfterFinishedCleanupPolicy(t *testing.T) {
 	scheme := newScheme(t)
 	ttlZero := int32(0)
@@ -1746,6 +1832,190 @@ func TestSandboxClaimTTLCleanupRequiresPersistedExpiredStatus(t *testing.T) {
 	require.True(t, k8errors.IsNotFound(err))
 }
 
+func TestSandboxClaimIdleTimeout(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle-warmpool", Namespace: "default"},
+		Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "idle-template"}},
+	}
+
+	template := &extensionsv1beta1.SandboxTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle-template", Namespace: "default"},
+		Spec: extensionsv1beta1.SandboxTemplateSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "test-image"}}},
+		}}},
+	}
+
+	testCases := []struct {
+		name                 string
+		policy               extensionsv1beta1.ShutdownPolicy
+		lastActivity         time.Time
+		sandboxActivity      string
+		expectClaimDeleted   bool
+		expectSandboxDeleted bool
+		expectRecycled       bool
+		expectReason         string
+	}{
+		{
+			name:               "delete deletes idle claim",
+			policy:             extensionsv1beta1.ShutdownPolicyDelete,
+			lastActivity:       now.Add(-time.Hour),
+			expectClaimDeleted: true,
+		},
+		{
+			name:               "delete foreground deletes idle claim",
+			policy:             extensionsv1beta1.ShutdownPolicyDeleteForeground,
+			lastActivity:       now.Add(-time.Hour),
+			expectClaimDeleted: true,
+		},
+		{
+			name:                 "retain deletes sandbox of idle claim",
+			policy:               extensionsv1beta1.ShutdownPolicyRetain,
+			lastActivity:         now.Add(-time.Hour),
+			expectSandboxDeleted: true,
+			expectReason:         extensionsv1beta1.ClaimExpiredReason,
+		},
+		{
+			name:           "recycle replaces sandbox of idle claim",
+			policy:         extensionsv1beta1.ShutdownPolicyRecycle,
+			lastActivity:   now.Add(-time.Hour),
+			expectRecycled: true,
+		},
+		{
+			name:         "recent activity keeps claim",
+			policy:       extensionsv1beta1.ShutdownPolicyRecycle,
+			lastActivity: now.Add(-time.Minute),
+			expectReason: "SandboxReady",
+		},
+		{
+			name:            "recent activity on sandbox keeps claim",
+			policy:          extensionsv1beta1.ShutdownPolicyDelete,
+			lastActivity:    now.Add(-time.Hour),
+			sandboxActivity: now.Add(-time.Minute).Format(time.RFC3339),
+			expectReason:    "SandboxReady",
+		},
+		{
+			name:               "stale activity on sandbox does not keep claim",
+			policy:             extensionsv1beta1.ShutdownPolicyDelete,
+			lastActivity:       now.Add(-time.Hour),
+			sandboxActivity:    now.Add(-30 * time.Minute).Format(time.RFC3339),
+			expectClaimDeleted: true,
+		},
+		{
+			name:               "malformed activity on sandbox is ignored",
+			policy:             extensionsv1beta1.ShutdownPolicyDelete,
+			lastActivity:       now.Add(-time.Hour),
+			sandboxActivity:    "yesterday",
+			expectClaimDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := newScheme(t)
+			claim := &extensionsv1beta1.SandboxClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "idle-claim", Namespace: "default", UID: "idle-claim-uid",
+					CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+					Annotations: map[string]string{
+						extensionsv1beta1.LastActivityAnnotation: tc.lastActivity.Format(time.RFC3339),
+					},
+				},
+				Spec: extensionsv1beta1.SandboxClaimSpec{
+					WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: "idle-warmpool"},
+					Lifecycle: &extensionsv1beta1.Lifecycle{
+						ShutdownPolicy: tc.policy,
+						IdleTimeout:    &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				Status: extensionsv1beta1.SandboxClaimStatus{
+					SandboxStatus: extensionsv1beta1.SandboxStatus{Name: "idle-claim"},
+				},
+			}
+			sandbox := &sandboxv1beta1.Sandbox{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      claim.Name,
+					Namespace: claim.Namespace,
+					Labels:    map[string]string{"generation": "old"},
+					Annotations: map[string]string{
+						sandboxv1beta1.SandboxLastActivityAnnotation: tc.sandboxActivity,
+					},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: extensionsv1beta1.GroupVersion.String(),
+						Kind:       extensionsv1beta1.SandboxClaimKind,
+						Name:       claim.Name,
+						UID:        claim.UID,
+						Controller: new(true),
+					}},
+				},
+				Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{}}},
+				Status: sandboxv1beta1.SandboxStatus{Conditions: []metav1.Condition{{
+					Type:   string(sandboxv1beta1.SandboxConditionReady),
+					Status: metav1.ConditionTrue,
+					Reason: "SandboxReady",
+				}}},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(claim, sandbox, warmPool, template).
+				WithStatusSubresource(claim).
+				Build()
+			reconciler := &SandboxClaimReconciler{
+				Client:           client,
+				Scheme:           scheme,
+				Recorder:         events.NewFakeRecorder(10),
+				Tracer:           asmetrics.NewNoOp(),
+				WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+				Clock:            clocktesting.NewFakePassiveClock(now),
+			}
+
+			ctx := context.Background()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claim.Name, Namespace: claim.Namespace}}
+			var result reconcile.Result
+			for range 3 {
+				var err error
+				result, err = reconciler.Reconcile(ctx, req)
+				require.NoError(t, err)
+			}
+
+			updatedClaim := &extensionsv1beta1.SandboxClaim{}
+			err := client.Get(ctx, req.NamespacedName, updatedClaim)
+			if tc.expectClaimDeleted {
+				require.True(t, k8errors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+
+			updatedSandbox := &sandboxv1beta1.Sandbox{}
+			err = client.Get(ctx, req.NamespacedName, updatedSandbox)
+			if tc.expectSandboxDeleted {
+				require.True(t, k8errors.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+			}
+
+			readyCondition := meta.FindStatusCondition(updatedClaim.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+			require.NotNil(t, readyCondition)
+			if tc.expectRecycled {
+				// A fresh Sandbox replaced the old one and the idle timer restarted.
+				require.Empty(t, updatedSandbox.Labels["generation"])
+				require.Equal(t, claim.Name, updatedClaim.Status.SandboxStatus.Name)
+				require.Equal(t, now.UTC().Format(time.RFC3339), updatedClaim.Annotations[extensionsv1beta1.LastActivityAnnotation])
+				require.NotEqual(t, extensionsv1beta1.ClaimExpiredReason, readyCondition.Reason)
+				require.NotEqual(t, extensionsv1beta1.ClaimRecyclingReason, readyCondition.Reason)
+				require.Equal(t, 10*time.Minute, result.RequeueAfter)
+				return
+			}
+			require.Equal(t, tc.expectReason, readyCondition.Reason)
+			if !tc.expectSandboxDeleted {
+				require.Equal(t, "old", updatedSandbox.Labels["generation"])
+				require.Equal(t, 9*time.Minute, result.RequeueAfter)
+			}
+		})
+	}
+}
+
 // TestSandboxProvisionEvent verifies that Sandbox creation emits "SandboxProvisioned".
 func TestSandboxProvisionEvent(t *testing.T) {
 	scheme := newScheme(t)
@@ -1885,6 +2155,287 @@ func TestCreateSandboxPropagatesVolumeClaimTemplates(t *testing.T) {
 	}
 }
 
+func TestCreateSandboxAppliesResourceOverrides(t *testing.T) {
+	scheme := newScheme(t)
+	claimName := "resources-claim"
+
+	claim := &extensionsv1beta1.SandboxClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: "default", UID: types.UID(claimName)},
+		Spec: extensionsv1beta1.SandboxClaimSpec{
+			WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: "resources-warmpool"},
+			Resources: []extensionsv1beta1.ContainerResources{
+				{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+				{
+					ContainerName: "sidecar",
+					Requests:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+				},
+			},
+		},
+	}
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "resources-warmpool", Namespace: "default"},
+		Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "resources-template"}},
+	}
+
+	template := &extensionsv1beta1.SandboxTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "resources-template", Namespace: "default"},
+		Spec: extensionsv1beta1.SandboxTemplateSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app-image:v1",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("1"),
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							},
+						},
+						{Name: "sidecar", Image: "sidecar-image:v1"},
+					},
+				},
+			}},
+			ResourcesOverridePolicy: extensionsv1beta1.ResourcesOverridePolicyAllowed,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(claim, template, warmPool).
+		WithStatusSubresource(claim).Build()
+
+	reconciler := &SandboxClaimReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Recorder:         events.NewFakeRecorder(10),
+		Tracer:           asmetrics.NewNoOp(),
+		WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claimName, Namespace: "default"}}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	sandbox := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fakeClient.Get(context.Background(), req.NamespacedName, sandbox))
+	containers := sandbox.Spec.PodTemplate.Spec.Containers
+	require.Len(t, containers, 2)
+
+	// The template's images are preserved; only resources are overridden.
+	require.Equal(t, "app-image:v1", containers[0].Image)
+	require.Equal(t, "sidecar-image:v1", containers[1].Image)
+	require.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}, containers[0].Resources)
+	require.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+	}, containers[1].Resources)
+
+	// The template itself is left untouched.
+	liveTemplate := &extensionsv1beta1.SandboxTemplate{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "resources-template", Namespace: "default"}, liveTemplate))
+	require.Equal(t, resource.MustParse("1Gi"), liveTemplate.Spec.PodTemplate.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory])
+}
+
+func TestApplyResourceOverridesErrors(t *testing.T) {
+	newContainers := func() []corev1.Container {
+		return []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		name        string
+		overrides   []extensionsv1beta1.ContainerResources
+		policy      extensionsv1beta1.ResourcesOverridePolicy
+		wantMessage string
+	}{
+		{
+			name:        "disallowed by default",
+			overrides:   []extensionsv1beta1.ContainerResources{{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}},
+			wantMessage: "resources override rejected: overriding container resources is not allowed by the template policy",
+		},
+		{
+			name:        "unknown container",
+			overrides:   []extensionsv1beta1.ContainerResources{{ContainerName: "missing", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}},
+			policy:      extensionsv1beta1.ResourcesOverridePolicyAllowed,
+			wantMessage: `resources override rejected: target container "missing" not found in template`,
+		},
+		{
+			name: "container targeted twice",
+			overrides: []extensionsv1beta1.ContainerResources{
+				{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+				{ContainerName: "app", Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+			},
+			policy:      extensionsv1beta1.ResourcesOverridePolicyAllowed,
+			wantMessage: `resources override rejected: multiple resource overrides target container "app"`,
+		},
+		{
+			name:        "request above the template limit",
+			overrides:   []extensionsv1beta1.ContainerResources{{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")}}},
+			policy:      extensionsv1beta1.ResourcesOverridePolicyAllowed,
+			wantMessage: `resources override rejected: container "app" memory request 2Gi exceeds its limit 1Gi`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := applyResourceOverrides(newContainers(), tc.overrides, tc.policy)
+			require.ErrorIs(t, err, ErrResourcesOverrideRejected)
+			require.EqualError(t, err, tc.wantMessage)
+		})
+	}
+}
+
+func TestCreateSandboxAppliesPodMetadataOverrides(t *testing.T) {
+	scheme := newScheme(t)
+	claimName := "metadata-claim"
+
+	claim := &extensionsv1beta1.SandboxClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: "default", UID: types.UID(claimName)},
+		Spec: extensionsv1beta1.SandboxClaimSpec{
+			WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: "metadata-warmpool"},
+			AdditionalPodMetadata: sandboxv1beta1.PodMetadata{
+				Labels:      map[string]string{"sandbox.users.io/tier": "gold"},
+				Annotations: map[string]string{"example.com/owner": "alice"},
+			},
+		},
+	}
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "metadata-warmpool", Namespace: "default"},
+		Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "metadata-template"}},
+	}
+
+	template := &extensionsv1beta1.SandboxTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "metadata-template", Namespace: "default"},
+		Spec: extensionsv1beta1.SandboxTemplateSpec{
+			SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				ObjectMeta: sandboxv1beta1.PodMetadata{
+					Labels:      map[string]string{"sandbox.users.io/tier": "bronze", "sandbox.users.io/team": "platform"},
+					Annotations: map[string]string{"example.com/owner": "platform-team"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app-image:v1"}}},
+			}},
+			PodMetadataOverridePolicy: extensionsv1beta1.PodMetadataOverridePolicyOverrides,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(claim, template, warmPool).
+		WithStatusSubresource(claim).Build()
+
+	reconciler := &SandboxClaimReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Recorder:         events.NewFakeRecorder(10),
+		Tracer:           asmetrics.NewNoOp(),
+		WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claimName, Namespace: "default"}}
+	_, err := reconciler.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+
+	sandbox := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fakeClient.Get(context.Background(), req.NamespacedName, sandbox))
+	podMeta := sandbox.Spec.PodTemplate.ObjectMeta
+	require.Equal(t, "gold", podMeta.Labels["sandbox.users.io/tier"])
+	require.Equal(t, "platform", podMeta.Labels["sandbox.users.io/team"])
+	require.Equal(t, "alice", podMeta.Annotations["example.com/owner"])
+	require.Equal(t, "app-image:v1", sandbox.Spec.PodTemplate.Spec.Containers[0].Image)
+}
+
+func TestMergePodMetadataOverridePolicy(t *testing.T) {
+	newTemplateMeta := func() *sandboxv1beta1.PodMetadata {
+		return &sandboxv1beta1.PodMetadata{
+			Labels: map[string]string{
+				"sandbox.users.io/tier":          "bronze",
+				extensionsv1beta1.SandboxIDLabel: "claim-uid",
+			},
+			Annotations: map[string]string{
+				"example.com/owner":             "platform-team",
+				autoscalerSafeToEvictAnnotation: "true",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		claimMeta   sandboxv1beta1.PodMetadata
+		policy      extensionsv1beta1.PodMetadataOverridePolicy
+		wantMeta    *sandboxv1beta1.PodMetadata
+		wantMessage string
+	}{
+		{
+			name:        "label conflict rejected by default",
+			claimMeta:   sandboxv1beta1.PodMetadata{Labels: map[string]string{"sandbox.users.io/tier": "gold"}},
+			wantMessage: `metadata override conflict: label "sandbox.users.io/tier" is defined in template with value "bronze", but claim requests "gold"`,
+		},
+		{
+			name:        "annotation conflict rejected when disallowed",
+			claimMeta:   sandboxv1beta1.PodMetadata{Annotations: map[string]string{"example.com/owner": "alice"}},
+			policy:      extensionsv1beta1.PodMetadataOverridePolicyDisallowed,
+			wantMessage: `metadata override conflict: annotation "example.com/owner" is defined in template with value "platform-team", but claim requests "alice"`,
+		},
+		{
+			name: "overrides replace template values",
+			claimMeta: sandboxv1beta1.PodMetadata{
+				Labels: map[string]string{"sandbox.users.io/tier": "gold"},
+				Annotations: map[string]string{
+					"example.com/owner":             "alice",
+					autoscalerSafeToEvictAnnotation: "false",
+				},
+			},
+			policy: extensionsv1beta1.PodMetadataOverridePolicyOverrides,
+			wantMeta: &sandboxv1beta1.PodMetadata{
+				Labels: map[string]string{
+					"sandbox.users.io/tier":          "gold",
+					extensionsv1beta1.SandboxIDLabel: "claim-uid",
+				},
+				Annotations: map[string]string{
+					"example.com/owner":             "alice",
+					autoscalerSafeToEvictAnnotation: "false",
+				},
+			},
+		},
+		{
+			name:        "system labels are never overridden",
+			claimMeta:   sandboxv1beta1.PodMetadata{Labels: map[string]string{extensionsv1beta1.SandboxIDLabel: "other-uid"}},
+			policy:      extensionsv1beta1.PodMetadataOverridePolicyOverrides,
+			wantMessage: fmt.Sprintf(`metadata override conflict: label %q is defined in template with value "claim-uid", but claim requests "other-uid"`, extensionsv1beta1.SandboxIDLabel),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The system domain is allowlisted only so the conflict check is reached.
+			r := &SandboxClaimReconciler{AllowedLabelDomains: []string{"sandbox.users.io", "agents.x-k8s.io"}}
+			templateMeta := newTemplateMeta()
+			err := r.mergePodMetadata(templateMeta, &tc.claimMeta, tc.policy)
+			if tc.wantMessage != "" {
+				require.EqualError(t, err, tc.wantMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantMeta, templateMeta)
+		})
+	}
+}
+
 func TestSandboxClaimSandboxAdoption(t *testing.T) {
 	template := &extensionsv1beta1.SandboxTemplate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1918,7 +2469,7 @@ func TestSandboxClaimSandboxAdoption(t *testing.T) {
 	}
 
 	warmPoolUID := types.UID("warmpool-uid-123")
-	poolNameHash := sandboxcontrollers.NameHash("test-pool")
+	poolNameHash := naming.NameHash("test-pool")
 
 	warmPool := &extensionsv1beta1.SandboxWarmPool{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default", UID: warmPoolUID},
@@ -1985,7 +2536,7 @@ func TestSandboxClaimSandboxAdoption(t *testing.T) {
 				Namespace: "default",
 				Labels: map[string]string{
 					warmPoolSandboxLabel:   poolNameHash,
-					sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+					sandboxTemplateRefHash: naming.NameHash("test-template"),
 				},
 				OwnerReferences: []metav1.OwnerReference{
 					{
@@ -2532,7 +3083,7 @@ func TestSandboxClaimNoReAdoption(t *testing.T) {
 		Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"}},
 	}
 
-	poolNameHash := sandboxcontrollers.NameHash("test-pool")
+	poolNameHash := naming.NameHash("test-pool")
 
 	// Claim that already adopted a sandbox (name recorded in status)
 	claim := &extensionsv1beta1.SandboxClaim{
@@ -2561,7 +3112,7 @@ func TestSandboxClaimNoReAdoption(t *testing.T) {
 			Name: "pool-sb-extra", Namespace: "default",
 			Labels: map[string]string{
 				warmPoolSandboxLabel:   poolNameHash,
-				sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+				sandboxTemplateRefHash: naming.NameHash("test-template"),
 			},
 		},
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}}}}, OperatingMode: sandboxv1beta1.SandboxOperatingModeRunning},
@@ -2985,14 +3536,14 @@ func TestSandboxClaimCreationMetric(t *testing.T) {
 		asmetrics.SandboxClaimCreationTotal.Reset()
 
 		// Create a warm pool sandbox
-		poolNameHash := sandboxcontrollers.NameHash("test-warmpool")
+		poolNameHash := naming.NameHash("test-warmpool")
 		warmSandbox := &sandboxv1beta1.Sandbox{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "warm-sb",
 				Namespace: "default",
 				Labels: map[string]string{
 					warmPoolSandboxLabel:          poolNameHash,
-					sandboxTemplateRefHash:        sandboxcontrollers.NameHash("test-template"),
+					sandboxTemplateRefHash:        naming.NameHash("test-template"),
 					sandboxv1beta1.CreatedByLabel: "controller",
 				},
 				Annotations: map[string]string{
@@ -3647,7 +4198,7 @@ func countObservedTimesEntries(r *SandboxClaimReconciler) int {
 
 func TestVerifySandboxCandidate_NamespaceIsolation(t *testing.T) {
 	templateName := "test-template"
-	templateHash := sandboxcontrollers.NameHash(templateName)
+	templateHash := naming.NameHash(templateName)
 
 	claim := &extensionsv1beta1.SandboxClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3753,8 +4304,8 @@ func TestSandboxClaimPreventsDuplicateAdoptionDuringCacheLag(t *testing.T) {
 			UID:       "adopted-sb-uid",
 			Labels: map[string]string{
 				extensionsv1beta1.SandboxIDLabel: "claim-uid-123",
-				sandboxTemplateRefHash:           sandboxcontrollers.NameHash("test-template"),
-				warmPoolSandboxLabel:             sandboxcontrollers.NameHash("test-pool"),
+				sandboxTemplateRefHash:           naming.NameHash("test-template"),
+				warmPoolSandboxLabel:             naming.NameHash("test-pool"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -3775,14 +4326,14 @@ func TestSandboxClaimPreventsDuplicateAdoptionDuringCacheLag(t *testing.T) {
 	}
 
 	// Another sandbox in the warm pool that we want to make sure doesn't get adopted
-	poolNameHash := sandboxcontrollers.NameHash("test-pool")
+	poolNameHash := naming.NameHash("test-pool")
 	extraSandbox := &sandboxv1beta1.Sandbox{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "pool-sb-extra",
 			Namespace: "default",
 			Labels: map[string]string{
 				warmPoolSandboxLabel:   poolNameHash,
-				sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+				sandboxTemplateRefHash: naming.NameHash("test-template"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -3983,8 +4534,8 @@ func TestSandboxClaimAdoptionCacheLagRepatchesIdempotently(t *testing.T) {
 			UID:       "adopted-sb-uid",
 			Labels: map[string]string{
 				extensionsv1beta1.SandboxIDLabel: "claim-uid-123",
-				sandboxTemplateRefHash:           sandboxcontrollers.NameHash("test-template"),
-				warmPoolSandboxLabel:             sandboxcontrollers.NameHash("test-pool"),
+				sandboxTemplateRefHash:           naming.NameHash("test-template"),
+				warmPoolSandboxLabel:             naming.NameHash("test-pool"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -4141,8 +4692,8 @@ func TestSandboxClaimAdoptionCacheLagPreservesFinalizedStatus(t *testing.T) {
 			UID:       "adopted-sb-uid",
 			Labels: map[string]string{
 				extensionsv1beta1.SandboxIDLabel: "claim-uid-123",
-				sandboxTemplateRefHash:           sandboxcontrollers.NameHash("test-template"),
-				warmPoolSandboxLabel:             sandboxcontrollers.NameHash("test-pool"),
+				sandboxTemplateRefHash:           naming.NameHash("test-template"),
+				warmPoolSandboxLabel:             naming.NameHash("test-pool"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -4271,8 +4822,8 @@ func TestSandboxClaimFreshAdoptionStaleCacheKeepsFinalizedStatus(t *testing.T) {
 			Namespace: "default",
 			UID:       "warm-sb-uid",
 			Labels: map[string]string{
-				warmPoolSandboxLabel:   sandboxcontrollers.NameHash("test-pool"),
-				sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+				warmPoolSandboxLabel:   naming.NameHash("test-pool"),
+				sandboxTemplateRefHash: naming.NameHash("test-template"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -4406,8 +4957,8 @@ func TestSandboxClaimPreventsAdoptionFromWrongWarmPool(t *testing.T) {
 			Namespace: "default",
 			UID:       "wrong-sb-uid",
 			Labels: map[string]string{
-				warmPoolSandboxLabel:   sandboxcontrollers.NameHash("wrong-pool"),
-				sandboxTemplateRefHash: sandboxcontrollers.NameHash("correct-template"),
+				warmPoolSandboxLabel:   naming.NameHash("wrong-pool"),
+				sandboxTemplateRefHash: naming.NameHash("correct-template"),
 			},
 			OwnerReferences: []metav1.OwnerReference{{
 				APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -4618,19 +5169,69 @@ func TestMapWarmPoolToClaims(t *testing.T) {
 
 	requests := reconciler.mapWarmPoolToClaims(context.Background(), warmPool)
 
-	if len(requests) != 2 {
-		t.Fatalf("expected 2 requests (unbound claims only), got %d: %v", len(requests), requests)
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests (unbound claims only), got %d: %v", len(requests), requests)
+	}
+
+	expectedNames := map[string]bool{"claim-1": true, "claim-2": true}
+	for _, req := range requests {
+		if !expectedNames[req.Name] {
+			t.Errorf("unexpected claim name in requests: %s", req.Name)
+		}
+		if req.Namespace != "default" {
+			t.Errorf("expected namespace 'default', got %s", req.Namespace)
+		}
+	}
+}
+
+func TestMapTemplateToClaims(t *testing.T) {
+	scheme := newScheme(t)
+	newClaim := func(name, pool string) *extensionsv1beta1.SandboxClaim {
+		return &extensionsv1beta1.SandboxClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       extensionsv1beta1.SandboxClaimSpec{WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: pool}},
+		}
+	}
+	newPool := func(name, template string) *extensionsv1beta1.SandboxWarmPool {
+		return &extensionsv1beta1.SandboxWarmPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       extensionsv1beta1.SandboxWarmPoolSpec{TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: template}},
+		}
+	}
+
+	unbound := newClaim("claim-unbound", "pool-1")
+	otherPool := newClaim("claim-other-pool", "pool-2")
+	bound := newClaim("claim-bound", "pool-1")
+	bound.Status.SandboxStatus.Name = "bound-sandbox"
+	boundRecreate := newClaim("claim-bound-recreate", "pool-2")
+	boundRecreate.Spec.UpdatePolicy = extensionsv1beta1.SandboxClaimUpdatePolicyRecreate
+	boundRecreate.Status.SandboxStatus.Name = "recreate-sandbox"
+	otherTemplate := newClaim("claim-other-template", "pool-3")
+	deleting := newClaim("claim-deleting", "pool-1")
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleting.Finalizers = []string{"test-finalizer"}
+
+	template := &extensionsv1beta1.SandboxTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
 	}
 
-	expectedNames := map[string]bool{"claim-1": true, "claim-2": true}
-	for _, req := range requests {
-		if !expectedNames[req.Name] {
-			t.Errorf("unexpected claim name in requests: %s", req.Name)
-		}
-		if req.Namespace != "default" {
-			t.Errorf("expected namespace 'default', got %s", req.Namespace)
-		}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(unbound, otherPool, bound, boundRecreate, otherTemplate, deleting,
+			newPool("pool-1", "test-template"), newPool("pool-2", "test-template"), newPool("pool-3", "other-template")).
+		WithIndex(&extensionsv1beta1.SandboxClaim{}, extensionsv1beta1.WarmPoolRefField, func(obj client.Object) []string {
+			return []string{obj.(*extensionsv1beta1.SandboxClaim).Spec.WarmPoolRef.Name}
+		}).
+		WithIndex(&extensionsv1beta1.SandboxWarmPool{}, extensionsv1beta1.TemplateRefField, sandboxTemplateRefNameIndexer).
+		Build()
+	reconciler := &SandboxClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+	var names []string
+	for _, req := range reconciler.mapTemplateToClaims(context.Background(), template) {
+		require.Equal(t, "default", req.Namespace)
+		names = append(names, req.Name)
 	}
+	require.ElementsMatch(t, []string{"claim-unbound", "claim-other-pool", "claim-bound-recreate"}, names)
 }
 
 // TestWarmPoolMapWatchPredicate pins the event classes the pool->claims map watch
@@ -4761,8 +5362,8 @@ func TestSandboxClaimLegacyLabelMigration(t *testing.T) {
 
 func TestIsAdoptable_RejectsUnowned(t *testing.T) {
 	// 1. Create a warm pool template hash
-	poolNameHash := sandboxcontrollers.NameHash("test-pool")
-	templateHash := sandboxcontrollers.NameHash("test-template")
+	poolNameHash := naming.NameHash("test-pool")
+	templateHash := naming.NameHash("test-template")
 
 	// 2. Mock an unowned Sandbox (no OwnerReferences)
 	unownedSandbox := &sandboxv1beta1.Sandbox{
@@ -4856,7 +5457,7 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 		if ready {
 			conditionStatus = metav1.ConditionTrue
 		}
-		poolNameHash := sandboxcontrollers.NameHash("test-pool")
+		poolNameHash := naming.NameHash("test-pool")
 		return &sandboxv1beta1.Sandbox{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:              name,
@@ -4864,7 +5465,7 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 				CreationTimestamp: creationTime,
 				Labels: map[string]string{
 					warmPoolSandboxLabel:   poolNameHash,
-					sandboxTemplateRefHash: sandboxcontrollers.NameHash("test-template"),
+					sandboxTemplateRefHash: naming.NameHash("test-template"),
 				},
 				OwnerReferences: []metav1.OwnerReference{
 					{
@@ -4895,6 +5496,11 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 		}
 	}
 
+	recentlyReady := func(sb *sandboxv1beta1.Sandbox) *sandboxv1beta1.Sandbox {
+		sb.Status.Conditions[0].LastTransitionTime = metav1.Now()
+		return sb
+	}
+
 	createClaim := func(name string) *extensionsv1beta1.SandboxClaim {
 		return &extensionsv1beta1.SandboxClaim{
 			ObjectMeta: metav1.ObjectMeta{
@@ -4913,6 +5519,7 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 	testCases := []struct {
 		name                   string
 		existingSandboxes      []*sandboxv1beta1.Sandbox
+		minReadySeconds        int32
 		otherObjects           []client.Object
 		expectedAdoptedSandbox string
 		expectedRemainingKeys  []string
@@ -4946,6 +5553,26 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 			expectedAdoptedSandbox: "sb-node2-younger-1",
 			expectedRemainingKeys:  []string{"sb-node1-oldest", "sb-node2-younger-2"},
 		},
+		{
+			name: "skips sandbox ready for less than minReadySeconds to adopt an available one",
+			existingSandboxes: []*sandboxv1beta1.Sandbox{
+				recentlyReady(createWarmPoolSandboxWithNode("sb-old-recently-ready", metav1.Time{Time: metav1.Now().Add(-2 * time.Hour)}, true, "node-1")),
+				createWarmPoolSandboxWithNode("sb-young-available", metav1.Now(), true, "node-2"),
+			},
+			minReadySeconds:        60,
+			expectedAdoptedSandbox: "sb-young-available",
+			expectedRemainingKeys:  []string{"sb-old-recently-ready"},
+		},
+		{
+			name: "prefers recently ready sandbox over unready one when none is available",
+			existingSandboxes: []*sandboxv1beta1.Sandbox{
+				createWarmPoolSandboxWithNode("sb-old-unready", metav1.Time{Time: metav1.Now().Add(-2 * time.Hour)}, false, "node-1"),
+				recentlyReady(createWarmPoolSandboxWithNode("sb-young-recently-ready", metav1.Now(), true, "node-2")),
+			},
+			minReadySeconds:        60,
+			expectedAdoptedSandbox: "sb-young-recently-ready",
+			expectedRemainingKeys:  []string{"sb-old-unready"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4967,6 +5594,7 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 					TemplateRef: extensionsv1beta1.SandboxTemplateRef{
 						Name: "test-template",
 					},
+					MinReadySeconds: tc.minReadySeconds,
 				},
 			}
 
@@ -5025,6 +5653,185 @@ func TestSandboxClaimAdoptionStrategy(t *testing.T) {
 	}
 }
 
+func TestSandboxClaimAdoptsLongestReadySandbox(t *testing.T) {
+	scheme := newScheme(t)
+	now := time.Now()
+
+	createReadySandbox := func(name string, readyFor time.Duration, nodeName string) *sandboxv1beta1.Sandbox {
+		return &sandboxv1beta1.Sandbox{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+				Labels: map[string]string{
+					warmPoolSandboxLabel:   naming.NameHash("test-pool"),
+					sandboxTemplateRefHash: naming.NameHash("test-template"),
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: extensionsv1beta1.GroupVersion.String(),
+					Kind:       extensionsv1beta1.SandboxWarmPoolKind,
+					Name:       "test-pool",
+					UID:        "warmpool-uid",
+					Controller: ptr.To(true), // nolint:modernize
+				}},
+			},
+			Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "test-image"}}},
+			}}},
+			Status: sandboxv1beta1.SandboxStatus{
+				NodeName: nodeName,
+				Conditions: []metav1.Condition{{
+					Type:               string(sandboxv1beta1.SandboxConditionReady),
+					Status:             metav1.ConditionTrue,
+					Reason:             "DependenciesReady",
+					LastTransitionTime: metav1.NewTime(now.Add(-readyFor)),
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		sandboxes              []*sandboxv1beta1.Sandbox
+		minReadySeconds        int32
+		expectedAdoptedSandbox string
+	}{
+		{
+			name: "picks the sandbox ready longest over one that just restarted",
+			sandboxes: []*sandboxv1beta1.Sandbox{
+				createReadySandbox("sb-restarted", 5*time.Second, "node-1"),
+				createReadySandbox("sb-ready-10m", 10*time.Minute, "node-1"),
+				createReadySandbox("sb-ready-1h", time.Hour, "node-1"),
+				createReadySandbox("sb-ready-30m", 30*time.Minute, "node-1"),
+			},
+			expectedAdoptedSandbox: "sb-ready-1h",
+		},
+		{
+			name: "picks the sandbox ready longest among unscheduled candidates",
+			sandboxes: []*sandboxv1beta1.Sandbox{
+				createReadySandbox("sb-ready-1m", time.Minute, ""),
+				createReadySandbox("sb-ready-2m", 2*time.Minute, ""),
+			},
+			expectedAdoptedSandbox: "sb-ready-2m",
+		},
+		{
+			name: "node spread still takes precedence over ready time",
+			sandboxes: []*sandboxv1beta1.Sandbox{
+				createReadySandbox("sb-node1-ready-1h", time.Hour, "node-1"),
+				createReadySandbox("sb-node2-ready-1m", time.Minute, "node-2"),
+				createReadySandbox("sb-node2-ready-5m", 5*time.Minute, "node-2"),
+			},
+			expectedAdoptedSandbox: "sb-node2-ready-5m",
+		},
+		{
+			name: "only sandboxes past minReadySeconds are candidates",
+			sandboxes: []*sandboxv1beta1.Sandbox{
+				createReadySandbox("sb-ready-10s", 10*time.Second, "node-1"),
+				createReadySandbox("sb-ready-1m", time.Minute, "node-2"),
+				createReadySandbox("sb-ready-5m", 5*time.Minute, "node-2"),
+			},
+			minReadySeconds:        120,
+			expectedAdoptedSandbox: "sb-ready-5m",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &extensionsv1beta1.SandboxTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+				Spec:       extensionsv1beta1.SandboxTemplateSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "img"}}}}}},
+			}
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default", UID: "warmpool-uid"},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					TemplateRef:     extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+					MinReadySeconds: tc.minReadySeconds,
+				},
+			}
+			claim := &extensionsv1beta1.SandboxClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-claim", Namespace: "default", UID: "test-claim-uid"},
+				Spec: extensionsv1beta1.SandboxClaimSpec{
+					WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: "test-pool"},
+				},
+			}
+
+			allObjects := []client.Object{template, warmPool, claim}
+			for _, sb := range tc.sandboxes {
+				allObjects = append(allObjects, sb)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(allObjects...).
+				WithStatusSubresource(claim).
+				Build()
+
+			// Enqueue the sandboxes the way the watch does, recording their ready times.
+			warmSandboxQueue := queue.NewSimpleSandboxQueue()
+			handler := &sandboxEventHandler{sandboxQueue: warmSandboxQueue}
+			for _, sb := range tc.sandboxes {
+				handler.Create(context.Background(), event.CreateEvent{Object: sb}, nil)
+			}
+
+			reconciler := &SandboxClaimReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Recorder:         events.NewFakeRecorder(10),
+				WarmSandboxQueue: warmSandboxQueue,
+				Tracer:           asmetrics.NewNoOp(),
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-claim", Namespace: "default"}}
+			_, err := reconciler.Reconcile(context.Background(), req)
+			require.NoError(t, err)
+
+			var adopted sandboxv1beta1.Sandbox
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: tc.expectedAdoptedSandbox}, &adopted))
+			require.True(t, metav1.IsControlledBy(&adopted, claim))
+			require.Equal(t, tc.expectedAdoptedSandbox, adopted.Annotations[sandboxv1beta1.SandboxPodNameAnnotation])
+		})
+	}
+}
+
+func TestSandboxEventHandlerTracksReadyTime(t *testing.T) {
+	q := queue.NewSimpleSandboxQueue()
+	handler := &sandboxEventHandler{sandboxQueue: q}
+	namespacedWarmPoolName := queue.GetNamespacedWarmPoolName("default", "test-pool")
+
+	sb := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sb",
+			Namespace: "default",
+			Labels: map[string]string{
+				warmPoolSandboxLabel:   naming.NameHash("test-pool"),
+				sandboxTemplateRefHash: naming.NameHash("test-template"),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: extensionsv1beta1.GroupVersion.String(),
+				Kind:       extensionsv1beta1.SandboxWarmPoolKind,
+				Name:       "test-pool",
+				UID:        "warmpool-uid",
+				Controller: ptr.To(true), // nolint:modernize
+			}},
+		},
+	}
+	handler.Create(context.Background(), event.CreateEvent{Object: sb}, nil)
+
+	readyAt := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	ready := sb.DeepCopy()
+	ready.Status.Conditions = []metav1.Condition{{
+		Type:               string(sandboxv1beta1.SandboxConditionReady),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: readyAt,
+	}}
+	handler.Update(context.Background(), event.UpdateEvent{ObjectOld: sb, ObjectNew: ready}, nil)
+
+	key, ok := q.Get(namespacedWarmPoolName)
+	require.True(t, ok)
+	require.Equal(t, "sb", key.Name)
+	require.True(t, readyAt.Time.Equal(key.ReadySince), "expected ReadySince %v, got %v", readyAt.Time, key.ReadySince)
+	_, ok = q.Get(namespacedWarmPoolName)
+	require.False(t, ok, "the update must refresh the queued key rather than add a second one")
+}
+
 func TestCreateSandboxClaimVolumeClaimTemplatesSuccess(t *testing.T) {
 	template := &extensionsv1beta1.SandboxTemplate{
 		ObjectMeta: metav1.ObjectMeta{Name: "vct-template", Namespace: "default"},
@@ -5141,14 +5948,14 @@ func TestCreateSandboxClaimVolumeClaimTemplatesSuccess(t *testing.T) {
 			var readyWarmSandbox *sandboxv1beta1.Sandbox
 			warmSandboxQueue := queue.NewSimpleSandboxQueue()
 			if tc.setupWarmPoolSandbox {
-				poolNameHash := sandboxcontrollers.NameHash("vct-warmpool")
+				poolNameHash := naming.NameHash("vct-warmpool")
 				readyWarmSandbox = &sandboxv1beta1.Sandbox{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "warm-sandbox",
 						Namespace: "default",
 						Labels: map[string]string{
 							warmPoolSandboxLabel:   poolNameHash,
-							sandboxTemplateRefHash: sandboxcontrollers.NameHash("vct-template"),
+							sandboxTemplateRefHash: naming.NameHash("vct-template"),
 						},
 						OwnerReferences: []metav1.OwnerReference{{
 							APIVersion: extensionsv1beta1.GroupVersion.String(),
@@ -5557,3 +6364,254 @@ func TestReconcile_TracingNormalization(t *testing.T) {
 	require.NotNil(t, mt.capturedAttrs)
 	require.Equal(t, "unknown", mt.capturedAttrs[sandboxv1beta1.CreatedByLabel], "created-by label must be normalized in span attributes")
 }
+
+func TestRecordClaimBindMetrics(t *testing.T) {
+	asmetrics.ClaimBindTotal.Reset()
+	claim := &extensionsv1beta1.SandboxClaim{ObjectMeta: metav1.ObjectMeta{Name: "bind-claim", Namespace: "bind-ns"}}
+	t.Cleanup(func() { asmetrics.SetClaimPending(claim.Namespace, claim.Name, false) })
+	pending := func() float64 { return testutil.ToFloat64(asmetrics.ClaimsPending.WithLabelValues(claim.Namespace)) }
+	setReady := func(status metav1.ConditionStatus, reason string) {
+		meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+			Type: string(sandboxv1beta1.SandboxConditionReady), Status: status, Reason: reason,
+		})
+	}
+
+	// A failed attempt to get a sandbox counts as an error.
+	unbound := claim.Status.DeepCopy()
+	recordClaimBindMetrics(claim, unbound, errors.New("boom"))
+	require.Equal(t, 1.0, testutil.ToFloat64(asmetrics.ClaimBindTotal.WithLabelValues(asmetrics.ClaimBindResultError)))
+
+	// Binding counts once, and the claim is pending until its sandbox is ready.
+	claim.Status.SandboxStatus.Name = "bind-sandbox"
+	setReady(metav1.ConditionFalse, "SandboxNotReady")
+	recordClaimBindMetrics(claim, unbound, nil)
+	bound := claim.Status.DeepCopy()
+	setReady(metav1.ConditionFalse, sandboxv1beta1.SandboxReasonDependenciesNotReady)
+	recordClaimBindMetrics(claim, bound, nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(asmetrics.ClaimBindTotal.WithLabelValues(asmetrics.ClaimBindResultSuccess)))
+	require.Equal(t, 1.0, pending())
+
+	setReady(metav1.ConditionTrue, sandboxv1beta1.SandboxReasonDependenciesReady)
+	recordClaimBindMetrics(claim, bound, nil)
+	require.Equal(t, 0.0, pending())
+}
+
+func TestSandboxClaimReadinessTimeout(t *testing.T) {
+	testCases := []struct {
+		name              string
+		lifecycle         *extensionsv1beta1.Lifecycle
+		wantSandboxKept   bool
+		wantSandboxStatus string
+	}{
+		{
+			name:              "default policy keeps the failed sandbox",
+			wantSandboxKept:   true,
+			wantSandboxStatus: "stuck-sandbox",
+		},
+		{
+			name:      "delete policy deletes the failed sandbox",
+			lifecycle: &extensionsv1beta1.Lifecycle{ShutdownPolicy: extensionsv1beta1.ShutdownPolicyDelete},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := newScheme(t)
+			created := time.Now().Truncate(time.Second)
+			fakeClock := clocktesting.NewFakePassiveClock(created.Add(10 * time.Second))
+
+			claim := &extensionsv1beta1.SandboxClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-claim", Namespace: "default", UID: "claim-uid",
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: extensionsv1beta1.SandboxClaimSpec{
+					WarmPoolRef:             extensionsv1beta1.SandboxWarmPoolRef{Name: "test-pool"},
+					Lifecycle:               tc.lifecycle,
+					ReadinessTimeoutSeconds: new(int32(60)),
+				},
+				Status: extensionsv1beta1.SandboxClaimStatus{
+					SandboxStatus: extensionsv1beta1.SandboxStatus{Name: "stuck-sandbox"},
+				},
+			}
+			sandbox := &sandboxv1beta1.Sandbox{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "stuck-sandbox", Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: extensionsv1beta1.GroupVersion.String(), Kind: extensionsv1beta1.SandboxClaimKind,
+						Name: "test-claim", UID: "claim-uid", Controller: new(true),
+					}},
+				},
+				Spec: sandboxv1beta1.SandboxSpec{
+					SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "bad-image"}}}}},
+				},
+				Status: sandboxv1beta1.SandboxStatus{
+					Conditions: []metav1.Condition{{
+						Type:   string(sandboxv1beta1.SandboxConditionReady),
+						Status: metav1.ConditionFalse,
+						Reason: sandboxv1beta1.SandboxReasonDependenciesNotReady,
+					}},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(claim, sandbox).
+				WithStatusSubresource(claim).
+				Build()
+			reconciler := &SandboxClaimReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Recorder:         events.NewFakeRecorder(10),
+				Tracer:           asmetrics.NewNoOp(),
+				WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+				Clock:            fakeClock,
+			}
+			ctx := context.Background()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claim.Name, Namespace: "default"}}
+
+			// Before the timeout the claim waits and checks back when it would fire.
+			result, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, 50*time.Second, result.RequeueAfter)
+			got := &extensionsv1beta1.SandboxClaim{}
+			require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+			cond := meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+			require.NotNil(t, cond)
+			require.Equal(t, sandboxv1beta1.SandboxReasonDependenciesNotReady, cond.Reason)
+
+			// Past the timeout the claim fails terminally.
+			fakeClock.SetTime(created.Add(61 * time.Second))
+			result, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.Zero(t, result.RequeueAfter)
+			require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+			cond = meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+			require.NotNil(t, cond)
+			require.Equal(t, metav1.ConditionFalse, cond.Status)
+			require.Equal(t, extensionsv1beta1.ClaimReadinessTimeoutReason, cond.Reason)
+
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: "stuck-sandbox", Namespace: "default"}, &sandboxv1beta1.Sandbox{})
+			if tc.wantSandboxKept {
+				require.NoError(t, err)
+			} else {
+				require.True(t, k8errors.IsNotFound(err), "expected sandbox to be deleted, got %v", err)
+			}
+
+			// The claim stays failed and does not acquire a new sandbox.
+			_, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, got))
+			require.Equal(t, extensionsv1beta1.ClaimReadinessTimeoutReason, meta.FindStatusCondition(got.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady)).Reason)
+			require.Equal(t, tc.wantSandboxStatus, got.Status.SandboxStatus.Name)
+			sandboxes := &sandboxv1beta1.SandboxList{}
+			require.NoError(t, fakeClient.List(ctx, sandboxes))
+			if tc.wantSandboxKept {
+				require.Len(t, sandboxes.Items, 1)
+			} else {
+				require.Empty(t, sandboxes.Items)
+			}
+		})
+	}
+}
+
+func TestSandboxClaimUpdatePolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		updatePolicy extensionsv1beta1.SandboxClaimUpdatePolicy
+		wantImage    string
+	}{
+		{
+			name:         "none keeps the sandbox",
+			updatePolicy: extensionsv1beta1.SandboxClaimUpdatePolicyNone,
+			wantImage:    "app:v1",
+		},
+		{
+			name:         "recreate replaces the sandbox",
+			updatePolicy: extensionsv1beta1.SandboxClaimUpdatePolicyRecreate,
+			wantImage:    "app:v2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := newScheme(t)
+			template := &extensionsv1beta1.SandboxTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-template", Namespace: "default"},
+				Spec: extensionsv1beta1.SandboxTemplateSpec{
+					SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app:v1"}}},
+					}},
+				},
+			}
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+				},
+			}
+			claim := &extensionsv1beta1.SandboxClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-claim", Namespace: "default", UID: "claim-uid"},
+				Spec: extensionsv1beta1.SandboxClaimSpec{
+					WarmPoolRef:  extensionsv1beta1.SandboxWarmPoolRef{Name: "test-pool"},
+					UpdatePolicy: tc.updatePolicy,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(template, warmPool, claim).
+				WithStatusSubresource(claim).
+				Build()
+			reconciler := &SandboxClaimReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Recorder:         events.NewFakeRecorder(10),
+				Tracer:           asmetrics.NewNoOp(),
+				WarmSandboxQueue: queue.NewSimpleSandboxQueue(),
+			}
+			ctx := context.Background()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: claim.Name, Namespace: "default"}}
+			sandboxKey := types.NamespacedName{Name: claim.Name, Namespace: "default"}
+
+			_, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+			original := &sandboxv1beta1.Sandbox{}
+			require.NoError(t, fakeClient.Get(ctx, sandboxKey, original))
+			originalHash, err := computeSandboxBlueprintHash(template)
+			require.NoError(t, err)
+			require.Equal(t, originalHash, original.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel])
+
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(template), template))
+			template.Spec.PodTemplate.Spec.Containers[0].Image = "app:v2"
+			require.NoError(t, fakeClient.Update(ctx, template))
+			updatedHash, err := computeSandboxBlueprintHash(template)
+			require.NoError(t, err)
+
+			// The first pass deletes a stale sandbox, the second creates its replacement.
+			_, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+			err = fakeClient.Get(ctx, sandboxKey, &sandboxv1beta1.Sandbox{})
+			if tc.updatePolicy == extensionsv1beta1.SandboxClaimUpdatePolicyRecreate {
+				require.True(t, k8errors.IsNotFound(err), "expected sandbox to be deleted, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			_, err = reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			got := &sandboxv1beta1.Sandbox{}
+			require.NoError(t, fakeClient.Get(ctx, sandboxKey, got))
+			require.Equal(t, tc.wantImage, got.Spec.PodTemplate.Spec.Containers[0].Image)
+			if tc.updatePolicy == extensionsv1beta1.SandboxClaimUpdatePolicyRecreate {
+				require.Equal(t, updatedHash, got.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel])
+			} else {
+				require.Equal(t, originalHash, got.Labels[extensionsv1beta1.SandboxClaimTemplateHashLabel])
+			}
+
+			gotClaim := &extensionsv1beta1.SandboxClaim{}
+			require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, gotClaim))
+			require.Equal(t, got.Name, gotClaim.Status.SandboxStatus.Name)
+		})
+	}
+}
@@ -32,10 +32,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
-	sandboxcontrollers "sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/extensions/controllers/queue"
 	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 	"sigs.k8s.io/agent-sandbox/internal/utils"
 )
 
@@ -46,8 +46,8 @@ func TestWarmPoolPodExclusivity(t *testing.T) {
 	scheme := newScheme(t)
 	ctx := context.Background()
 
-	poolNameHash := sandboxcontrollers.NameHash("pool")
-	templateHash := sandboxcontrollers.NameHash("tpl")
+	poolNameHash := naming.NameHash("pool")
+	templateHash := naming.NameHash("tpl")
 	warmPoolUID := types.UID("pool-uid")
 
 	template := &extensionsv1beta1.SandboxTemplate{
@@ -0,0 +1,276 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
+)
+
+// SandboxClaimSetReconciler reconciles a SandboxClaimSet object.
+type SandboxClaimSetReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	MaxBatchSize int
+}
+
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaimsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaimsets/finalizers,verbs=get;update;patch
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaimsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaims,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile implements the reconciliation loop for SandboxClaimSet.
+func (r *SandboxClaimSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	claimSet := &extensionsv1beta1.SandboxClaimSet{}
+	if err := r.Get(ctx, req.NamespacedName, claimSet); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("SandboxClaimSet resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get SandboxClaimSet")
+		return ctrl.Result{}, err
+	}
+
+	// The claims are garbage collected through their owner references.
+	if !claimSet.DeletionTimestamp.IsZero() {
+		logger.Info("SandboxClaimSet is being deleted")
+		return ctrl.Result{}, nil
+	}
+
+	oldStatus := claimSet.Status.DeepCopy()
+	reconcileErr := r.reconcileClaims(ctx, claimSet)
+
+	if err := r.updateStatus(ctx, oldStatus, claimSet); err != nil {
+		logger.Error(err, "Failed to update SandboxClaimSet status")
+		return ctrl.Result{}, errors.Join(reconcileErr, err)
+	}
+	return ctrl.Result{}, reconcileErr
+}
+
+// reconcileClaims creates or deletes claims until the set holds spec.replicas of
+// them, and aggregates their readiness into the status.
+func (r *SandboxClaimSetReconciler) reconcileClaims(ctx context.Context, claimSet *extensionsv1beta1.SandboxClaimSet) error {
+	logger := log.FromContext(ctx)
+
+	setNameHash := naming.NameHash(claimSet.Name)
+	claimList := &extensionsv1beta1.SandboxClaimList{}
+	if err := r.List(ctx, claimList,
+		client.InNamespace(claimSet.Namespace),
+		client.MatchingLabels{extensionsv1beta1.SandboxClaimSetLabel: setNameHash},
+	); err != nil {
+		logger.Error(err, "Failed to list sandbox claims")
+		return err
+	}
+
+	// Only count claims this set controls and that are not already on their way out.
+	var activeClaims []extensionsv1beta1.SandboxClaim
+	for _, claim := range claimList.Items {
+		if !metav1.IsControlledBy(&claim, claimSet) || !claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		activeClaims = append(activeClaims, claim)
+	}
+
+	desiredReplicas := int32(1)
+	if claimSet.Spec.Replicas != nil {
+		desiredReplicas = *claimSet.Spec.Replicas
+	}
+	currentReplicas := int32(len(activeClaims))
+
+	readyClaims := int32(0)
+	timedOutClaims := int32(0)
+	for i := range activeClaims {
+		if isClaimReady(&activeClaims[i]) {
+			readyClaims++
+		} else if hasClaimReadinessTimeoutCondition(activeClaims[i].Status.Conditions) {
+			timedOutClaims++
+		}
+	}
+	claimSet.Status.Replicas = currentReplicas
+	claimSet.Status.ReadyClaims = readyClaims
+	for _, cond := range computeClaimSetConditions(claimSet, desiredReplicas, currentReplicas, readyClaims, timedOutClaims) {
+		meta.SetStatusCondition(&claimSet.Status.Conditions, cond)
+	}
+
+	maxBatchSize := int32(r.MaxBatchSize)
+	if maxBatchSize <= 0 {
+		maxBatchSize = sandboxCreateDeleteMaxBatchSize
+	}
+
+	var allErrors error
+	if currentReplicas < desiredReplicas {
+		claimsToCreate := min(desiredReplicas-currentReplicas, maxBatchSize)
+		logger.Info("Creating sandbox claims", "count", claimsToCreate, "claimSet", claimSet.Name)
+
+		claim, err := r.buildClaim(claimSet, setNameHash)
+		if err != nil {
+			return err
+		}
+		// Parallel claim creation with adaptive slow-start batching (starts with 1 and doubles on success)
+		_, createErr := slowStartBatch(ctx, int(claimsToCreate), 1, func(_ int) error {
+			return r.Create(ctx, claim.DeepCopy())
+		})
+		if createErr != nil {
+			logger.Error(createErr, "Failed to create sandbox claims")
+			allErrors = errors.Join(allErrors, createErr)
+		}
+	}
+
+	if currentReplicas > desiredReplicas {
+		claimsToDelete := min(currentReplicas-desiredReplicas, maxBatchSize)
+		logger.Info("Deleting excess sandbox claims", "count", claimsToDelete, "claimSet", claimSet.Name)
+
+		// Release unready claims before ready ones, then newest first within each
+		// group, so that sandboxes already in use are the last to go.
+		slices.SortFunc(activeClaims, func(a, b extensionsv1beta1.SandboxClaim) int {
+			aReady, bReady := isClaimReady(&a), isClaimReady(&b)
+			if aReady != bReady {
+				if aReady {
+					return 1
+				}
+				return -1
+			}
+			return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+		})
+		_, deleteErr := slowStartBatch(ctx, int(claimsToDelete), 1, func(idx int) error {
+			return client.IgnoreNotFound(r.Delete(ctx, &activeClaims[idx]))
+		})
+		if deleteErr != nil {
+			logger.Error(deleteErr, "Failed to delete sandbox claims")
+			allErrors = errors.Join(allErrors, deleteErr)
+		}
+	}
+
+	return allErrors
+}
+
+// buildClaim returns the claim the set creates for each missing replica.
+func (r *SandboxClaimSetReconciler) buildClaim(claimSet *extensionsv1beta1.SandboxClaimSet, setNameHash string) (*extensionsv1beta1.SandboxClaim, error) {
+	claim := &extensionsv1beta1.SandboxClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: claimSet.Name + "-",
+			Namespace:    claimSet.Namespace,
+			Labels: map[string]string{
+				extensionsv1beta1.SandboxClaimSetLabel: setNameHash,
+			},
+		},
+		Spec: extensionsv1beta1.SandboxClaimSpec{
+			WarmPoolRef:             claimSet.Spec.WarmPoolRef,
+			ReadinessTimeoutSeconds: claimSet.Spec.ReadinessTimeoutSeconds,
+		},
+	}
+	if err := controllerutil.SetControllerReference(claimSet, claim, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference on sandbox claim: %w", err)
+	}
+	return claim, nil
+}
+
+// computeClaimSetConditions derives the Ready, InProgress and Degraded conditions
+// from the set's claim counts. timedOutClaims counts the claims whose Sandbox did
+// not become Ready within spec.readinessTimeoutSeconds.
+func computeClaimSetConditions(claimSet *extensionsv1beta1.SandboxClaimSet, desiredReplicas, currentReplicas, readyClaims, timedOutClaims int32) []metav1.Condition {
+	message := fmt.Sprintf("%d of %d claims are ready", readyClaims, desiredReplicas)
+
+	ready := metav1.Condition{
+		Type:               string(sandboxv1beta1.SandboxConditionReady),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: claimSet.Generation,
+		Reason:             "ClaimsReady",
+		Message:            message,
+	}
+	inProgress := metav1.Condition{
+		Type:               extensionsv1beta1.SandboxClaimSetConditionInProgress,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: claimSet.Generation,
+		Reason:             "ClaimsReady",
+		Message:            message,
+	}
+	switch {
+	case currentReplicas != desiredReplicas:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "Scaling"
+		inProgress.Status = metav1.ConditionTrue
+		inProgress.Reason = "Scaling"
+		inProgress.Message = fmt.Sprintf("Scaling from %d to %d claims", currentReplicas, desiredReplicas)
+	case readyClaims < desiredReplicas:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "WaitingForReady"
+		inProgress.Status = metav1.ConditionTrue
+		inProgress.Reason = "WaitingForReady"
+	}
+
+	degraded := metav1.Condition{
+		Type:               extensionsv1beta1.SandboxClaimSetConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: claimSet.Generation,
+		Reason:             "NoTimedOutClaims",
+		Message:            "No claims timed out waiting for their Sandbox",
+	}
+	if timedOutClaims > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = extensionsv1beta1.ClaimReadinessTimeoutReason
+		degraded.Message = fmt.Sprintf("%d of %d claims timed out waiting for their Sandbox", timedOutClaims, currentReplicas)
+	}
+
+	return []metav1.Condition{ready, inProgress, degraded}
+}
+
+// updateStatus updates the status of the SandboxClaimSet if it has changed.
+func (r *SandboxClaimSetReconciler) updateStatus(ctx context.Context, oldStatus *extensionsv1beta1.SandboxClaimSetStatus, claimSet *extensionsv1beta1.SandboxClaimSet) error {
+	if equality.Semantic.DeepEqual(oldStatus, &claimSet.Status) {
+		return nil
+	}
+
+	oldClaimSet := claimSet.DeepCopy()
+	oldClaimSet.Status = *oldStatus
+	if err := r.Status().Patch(ctx, claimSet, client.MergeFrom(oldClaimSet)); err != nil {
+		return fmt.Errorf("failed to update SandboxClaimSet status: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Updated SandboxClaimSet status", "replicas", claimSet.Status.Replicas, "readyClaims", claimSet.Status.ReadyClaims)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SandboxClaimSetReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWorkers int) error {
+	if r.MaxBatchSize <= 0 {
+		r.MaxBatchSize = sandboxCreateDeleteMaxBatchSize
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&extensionsv1beta1.SandboxClaimSet{}).
+		Owns(&extensionsv1beta1.SandboxClaim{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: concurrentWorkers}).
+		Complete(r)
+}
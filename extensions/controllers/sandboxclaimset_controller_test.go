@@ -0,0 +1,269 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSandboxClaimSetReconcile(t *testing.T) {
+	const (
+		setName   = "test-set"
+		namespace = "default"
+		poolName  = "test-pool"
+	)
+	setNameHash := naming.NameHash(setName)
+
+	newClaimSet := func(replicas *int32) *extensionsv1beta1.SandboxClaimSet {
+		return &extensionsv1beta1.SandboxClaimSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       setName,
+				Namespace:  namespace,
+				UID:        "claimset-uid-123",
+				Generation: 1,
+			},
+			Spec: extensionsv1beta1.SandboxClaimSetSpec{
+				Replicas:                replicas,
+				WarmPoolRef:             extensionsv1beta1.SandboxWarmPoolRef{Name: poolName},
+				ReadinessTimeoutSeconds: ptr.To(int32(300)),
+			},
+		}
+	}
+
+	// newSetClaim returns a claim owned by the set with the given Ready condition.
+	newSetClaim := func(name string, age time.Duration, readyStatus metav1.ConditionStatus, readyReason string) *extensionsv1beta1.SandboxClaim {
+		claim := &extensionsv1beta1.SandboxClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+				Labels:            map[string]string{extensionsv1beta1.SandboxClaimSetLabel: setNameHash},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: extensionsv1beta1.GroupVersion.String(),
+					Kind:       extensionsv1beta1.SandboxClaimSetKind,
+					Name:       setName,
+					UID:        "claimset-uid-123",
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: extensionsv1beta1.SandboxClaimSpec{
+				WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: poolName},
+			},
+		}
+		claim.Status.Conditions = []metav1.Condition{{
+			Type:   string(sandboxv1beta1.SandboxConditionReady),
+			Status: readyStatus,
+			Reason: readyReason,
+		}}
+		return claim
+	}
+
+	testCases := []struct {
+		name            string
+		replicas        *int32
+		initialObjs     []runtime.Object
+		wantClaims      int
+		wantKeptClaims  []string
+		wantReplicas    int32
+		wantReadyClaims int32
+		wantReady       metav1.ConditionStatus
+		wantReadyReason string
+		wantDegraded    metav1.ConditionStatus
+	}{
+		{
+			name:            "nil replicas defaults to 1",
+			wantClaims:      1,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Scaling",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name:            "creates claims when the set is empty",
+			replicas:        ptr.To(int32(3)),
+			wantClaims:      3,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Scaling",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "aggregates ready claims",
+			replicas: ptr.To(int32(2)),
+			initialObjs: []runtime.Object{
+				newSetClaim("test-set-a", time.Hour, metav1.ConditionTrue, "Ready"),
+				newSetClaim("test-set-b", time.Hour, metav1.ConditionTrue, "Ready"),
+			},
+			wantClaims:      2,
+			wantReplicas:    2,
+			wantReadyClaims: 2,
+			wantReady:       metav1.ConditionTrue,
+			wantReadyReason: "ClaimsReady",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "waits for unready claims",
+			replicas: ptr.To(int32(2)),
+			initialObjs: []runtime.Object{
+				newSetClaim("test-set-a", time.Hour, metav1.ConditionTrue, "Ready"),
+				newSetClaim("test-set-b", time.Hour, metav1.ConditionFalse, "SandboxNotReady"),
+			},
+			wantClaims:      2,
+			wantReplicas:    2,
+			wantReadyClaims: 1,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "WaitingForReady",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "reports timed out claims as degraded without replacing them",
+			replicas: ptr.To(int32(2)),
+			initialObjs: []runtime.Object{
+				newSetClaim("test-set-a", time.Hour, metav1.ConditionTrue, "Ready"),
+				newSetClaim("test-set-b", time.Hour, metav1.ConditionFalse, extensionsv1beta1.ClaimReadinessTimeoutReason),
+			},
+			wantClaims:      2,
+			wantKeptClaims:  []string{"test-set-a", "test-set-b"},
+			wantReplicas:    2,
+			wantReadyClaims: 1,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "WaitingForReady",
+			wantDegraded:    metav1.ConditionTrue,
+		},
+		{
+			name:     "deletes unready and newest claims first when scaling down",
+			replicas: ptr.To(int32(1)),
+			initialObjs: []runtime.Object{
+				newSetClaim("test-set-old-ready", 2*time.Hour, metav1.ConditionTrue, "Ready"),
+				newSetClaim("test-set-new-ready", time.Hour, metav1.ConditionTrue, "Ready"),
+				newSetClaim("test-set-unready", 3*time.Hour, metav1.ConditionFalse, "SandboxNotReady"),
+			},
+			wantClaims:      1,
+			wantKeptClaims:  []string{"test-set-old-ready"},
+			wantReplicas:    3,
+			wantReadyClaims: 2,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Scaling",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name:     "ignores claims the set does not control",
+			replicas: ptr.To(int32(1)),
+			initialObjs: []runtime.Object{
+				func() runtime.Object {
+					claim := newSetClaim("test-set-orphan", time.Hour, metav1.ConditionTrue, "Ready")
+					claim.OwnerReferences = nil
+					return claim
+				}(),
+			},
+			// The orphan is neither counted nor deleted, so a new claim is created next to it.
+			wantClaims:      2,
+			wantReady:       metav1.ConditionFalse,
+			wantReadyReason: "Scaling",
+			wantDegraded:    metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claimSet := newClaimSet(tc.replicas)
+			scheme := newTestScheme()
+			r := &SandboxClaimSetReconciler{
+				Client: newFakeClient(scheme, append(tc.initialObjs, claimSet)...),
+				Scheme: scheme,
+			}
+
+			_, err := r.Reconcile(t.Context(), reconcile.Request{NamespacedName: types.NamespacedName{Name: setName, Namespace: namespace}})
+			require.NoError(t, err)
+
+			claims := &extensionsv1beta1.SandboxClaimList{}
+			require.NoError(t, r.List(t.Context(), claims, client.InNamespace(namespace)))
+			require.Len(t, claims.Items, tc.wantClaims)
+			var names []string
+			for _, claim := range claims.Items {
+				names = append(names, claim.Name)
+				assert.Equal(t, poolName, claim.Spec.WarmPoolRef.Name)
+				if metav1.IsControlledBy(&claim, claimSet) {
+					assert.Equal(t, setNameHash, claim.Labels[extensionsv1beta1.SandboxClaimSetLabel])
+				}
+			}
+			if tc.wantKeptClaims != nil {
+				assert.ElementsMatch(t, tc.wantKeptClaims, names)
+			}
+
+			live := &extensionsv1beta1.SandboxClaimSet{}
+			require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: setName, Namespace: namespace}, live))
+			assert.Equal(t, tc.wantReplicas, live.Status.Replicas)
+			assert.Equal(t, tc.wantReadyClaims, live.Status.ReadyClaims)
+			ready := meta.FindStatusCondition(live.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+			require.NotNil(t, ready)
+			assert.Equal(t, tc.wantReady, ready.Status)
+			assert.Equal(t, tc.wantReadyReason, ready.Reason)
+			assert.Equal(t, int64(1), ready.ObservedGeneration)
+			degraded := meta.FindStatusCondition(live.Status.Conditions, extensionsv1beta1.SandboxClaimSetConditionDegraded)
+			require.NotNil(t, degraded)
+			assert.Equal(t, tc.wantDegraded, degraded.Status)
+		})
+	}
+}
+
+func TestSandboxClaimSetCreatesClaimsFromSpec(t *testing.T) {
+	claimSet := &extensionsv1beta1.SandboxClaimSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "default", UID: "batch-uid"},
+		Spec: extensionsv1beta1.SandboxClaimSetSpec{
+			Replicas:                ptr.To(int32(5)),
+			WarmPoolRef:             extensionsv1beta1.SandboxWarmPoolRef{Name: "pool"},
+			ReadinessTimeoutSeconds: ptr.To(int32(60)),
+		},
+	}
+	scheme := newTestScheme()
+	r := &SandboxClaimSetReconciler{
+		Client:       newFakeClient(scheme, claimSet),
+		Scheme:       scheme,
+		MaxBatchSize: 2,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "batch", Namespace: "default"}}
+
+	// Each reconcile creates at most MaxBatchSize claims.
+	for i, want := range []int{2, 4, 5, 5} {
+		_, err := r.Reconcile(t.Context(), req)
+		require.NoError(t, err)
+		claims := &extensionsv1beta1.SandboxClaimList{}
+		require.NoError(t, r.List(t.Context(), claims, client.InNamespace("default")))
+		require.Len(t, claims.Items, want, fmt.Sprintf("after reconcile %d", i+1))
+	}
+
+	claims := &extensionsv1beta1.SandboxClaimList{}
+	require.NoError(t, r.List(t.Context(), claims, client.InNamespace("default")))
+	for _, claim := range claims.Items {
+		assert.Contains(t, claim.Name, "batch-")
+		assert.True(t, metav1.IsControlledBy(&claim, claimSet))
+		assert.Equal(t, "pool", claim.Spec.WarmPoolRef.Name)
+		assert.Equal(t, ptr.To(int32(60)), claim.Spec.ReadinessTimeoutSeconds)
+	}
+}
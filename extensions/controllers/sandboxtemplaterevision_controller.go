@@ -0,0 +1,202 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+)
+
+// templateRevisionHistoryLimit is the number of ControllerRevisions kept per
+// SandboxTemplate. Older ones are pruned, oldest first.
+const templateRevisionHistoryLimit = 10
+
+// SandboxTemplateRevisionReconciler snapshots each SandboxTemplate into an owned
+// ControllerRevision whenever its blueprint hash changes. Warm pool Sandboxes and
+// their Pods carry the revision name in SandboxTemplateRevisionLabel, so the
+// template a pool Pod was built from can still be read after the template has
+// moved on, and a revision's data can be applied back to roll the template back.
+type SandboxTemplateRevisionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Tracer asmetrics.Instrumenter
+}
+
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxtemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
+
+func (r *SandboxTemplateRevisionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	template := &extensionsv1beta1.SandboxTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		if k8errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get sandbox template %q: %w", req.NamespacedName, err)
+	}
+	// The revisions are owned by the template, so the garbage collector removes them.
+	if !template.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	ctx, end := r.Tracer.StartSpan(ctx, template, "ReconcileSandboxTemplateRevision", nil)
+	defer end()
+
+	hash, err := computeSandboxBlueprintHash(template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	name := SandboxTemplateRevisionName(template.Name, hash)
+
+	revisions, err := r.listRevisions(ctx, template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var current *appsv1.ControllerRevision
+	var latest int64
+	for i := range revisions {
+		if revisions[i].Name == name {
+			current = &revisions[i]
+		}
+		latest = max(latest, revisions[i].Revision)
+	}
+
+	switch {
+	case current == nil:
+		revision, err := r.buildRevision(template, name, hash, latest+1)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, revision); err != nil {
+			if k8errors.IsAlreadyExists(err) {
+				return ctrl.Result{}, fmt.Errorf("refusing to reuse ControllerRevision %q as it is not controlled by SandboxTemplate %q", name, template.Name)
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to create ControllerRevision %q: %w", name, err)
+		}
+		logger.Info("Created SandboxTemplate revision", "name", name, "revision", revision.Revision)
+		revisions = append(revisions, *revision)
+	case current.Revision < latest:
+		// The template went back to an earlier blueprint: that revision is the
+		// newest again, as a Deployment does when rolled back.
+		original := current.DeepCopy()
+		current.Revision = latest + 1
+		if err := r.Patch(ctx, current, client.MergeFrom(original)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to renumber ControllerRevision %q: %w", name, err)
+		}
+		logger.Info("Restored SandboxTemplate revision", "name", name, "revision", current.Revision)
+	}
+
+	return ctrl.Result{}, r.pruneRevisions(ctx, revisions)
+}
+
+// SandboxTemplateRevisionName returns the name of the ControllerRevision holding the
+// template with the given blueprint hash. The template name is shortened when needed
+// so the result stays a valid label value.
+func SandboxTemplateRevisionName(templateName, hash string) string {
+	prefix := templateName
+	if maxLen := validation.LabelValueMaxLength - len(hash) - 1; len(prefix) > maxLen {
+		prefix = strings.TrimRight(prefix[:maxLen], "-.")
+	}
+	return prefix + "-" + hash
+}
+
+// listRevisions returns the ControllerRevisions controlled by the template.
+func (r *SandboxTemplateRevisionReconciler) listRevisions(ctx context.Context, template *extensionsv1beta1.SandboxTemplate) ([]appsv1.ControllerRevision, error) {
+	list := &appsv1.ControllerRevisionList{}
+	if err := r.List(ctx, list, client.InNamespace(template.Namespace), client.MatchingLabels{
+		sandboxTemplateRefHash: SandboxTemplateRefHash(template.Name),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list ControllerRevisions for sandbox template %q: %w", template.Name, err)
+	}
+	var revisions []appsv1.ControllerRevision
+	for _, rev := range list.Items {
+		if metav1.IsControlledBy(&rev, template) {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+// buildRevision snapshots the template spec into a ControllerRevision. The data holds
+// the spec under "spec", so it can be diffed against or applied back to the template.
+func (r *SandboxTemplateRevisionReconciler) buildRevision(template *extensionsv1beta1.SandboxTemplate, name, hash string, revision int64) (*appsv1.ControllerRevision, error) {
+	data, err := json.Marshal(map[string]any{"spec": template.Spec})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sandbox template %q for revision: %w", template.Name, err)
+	}
+	rev := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: template.Namespace,
+			Labels: map[string]string{
+				sandboxTemplateRefHash:                  SandboxTemplateRefHash(template.Name),
+				sandboxv1beta1.SandboxTemplateHashLabel: hash,
+			},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}
+	if err := controllerutil.SetControllerReference(template, rev, r.Scheme); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// pruneRevisions deletes the oldest revisions beyond templateRevisionHistoryLimit.
+// The current revision always has the highest number, so it is never pruned.
+func (r *SandboxTemplateRevisionReconciler) pruneRevisions(ctx context.Context, revisions []appsv1.ControllerRevision) error {
+	if len(revisions) <= templateRevisionHistoryLimit {
+		return nil
+	}
+	slices.SortFunc(revisions, func(a, b appsv1.ControllerRevision) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+	for i := range revisions[:len(revisions)-templateRevisionHistoryLimit] {
+		if err := r.Delete(ctx, &revisions[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to prune ControllerRevision %q: %w", revisions[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SandboxTemplateRevisionReconciler) SetupWithManager(mgr ctrl.Manager, concurrentWorkers int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("sandboxtemplate-revision").
+		For(&extensionsv1beta1.SandboxTemplate{}).
+		Owns(&appsv1.ControllerRevision{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: concurrentWorkers}).
+		Complete(r)
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+)
+
+// templateRevisions returns the template's ControllerRevisions keyed by name.
+func templateRevisions(t *testing.T, c client.Client, template *extensionsv1beta1.SandboxTemplate) map[string]appsv1.ControllerRevision {
+	t.Helper()
+	list := &appsv1.ControllerRevisionList{}
+	require.NoError(t, c.List(context.Background(), list, client.InNamespace(template.Namespace)))
+	revisions := make(map[string]appsv1.ControllerRevision, len(list.Items))
+	for _, rev := range list.Items {
+		revisions[rev.Name] = rev
+	}
+	return revisions
+}
+
+func TestSandboxTemplateRevisionOnSpecChange(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme()
+	template := createTemplate("default")
+	template.UID = "template-uid"
+	fc := newFakeClient(scheme, template)
+	r := &SandboxTemplateRevisionReconciler{Client: fc, Scheme: scheme, Tracer: asmetrics.NewNoOp()}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: template.Name, Namespace: template.Namespace}}
+
+	// setImage updates the template and reconciles, returning the name of the
+	// revision the template should now be at.
+	setImage := func(image string) string {
+		t.Helper()
+		require.NoError(t, fc.Get(ctx, req.NamespacedName, template))
+		template.Spec.PodTemplate.Spec.Containers[0].Image = image
+		require.NoError(t, fc.Update(ctx, template))
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		hash, err := computeSandboxBlueprintHash(template)
+		require.NoError(t, err)
+		return SandboxTemplateRevisionName(template.Name, hash)
+	}
+
+	v1 := setImage("image-v1")
+	revisions := templateRevisions(t, fc, template)
+	require.Len(t, revisions, 1)
+	first := revisions[v1]
+	require.Equal(t, int64(1), first.Revision)
+	require.True(t, metav1.IsControlledBy(&first, template), "revision should be controlled by the template")
+	require.Equal(t, SandboxTemplateRefHash(template.Name), first.Labels[sandboxv1beta1.SandboxTemplateRefHashLabel])
+	var snapshot struct {
+		Spec extensionsv1beta1.SandboxTemplateSpec `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(first.Data.Raw, &snapshot))
+	require.Equal(t, "image-v1", snapshot.Spec.PodTemplate.Spec.Containers[0].Image)
+
+	// Reconciling an unchanged template leaves the revisions alone.
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, templateRevisions(t, fc, template), 1)
+
+	// A spec change snapshots a new revision and keeps the old one.
+	v2 := setImage("image-v2")
+	require.NotEqual(t, v1, v2)
+	revisions = templateRevisions(t, fc, template)
+	require.Len(t, revisions, 2)
+	require.Equal(t, int64(1), revisions[v1].Revision)
+	require.Equal(t, int64(2), revisions[v2].Revision)
+
+	// Going back to an earlier spec reuses its revision as the newest one.
+	require.Equal(t, v1, setImage("image-v1"))
+	revisions = templateRevisions(t, fc, template)
+	require.Len(t, revisions, 2)
+	require.Equal(t, int64(3), revisions[v1].Revision)
+	require.Equal(t, int64(2), revisions[v2].Revision)
+}
+
+func TestSandboxTemplateRevisionPrunesHistory(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme()
+	template := createTemplate("default")
+	template.UID = "template-uid"
+	fc := newFakeClient(scheme, template)
+	r := &SandboxTemplateRevisionReconciler{Client: fc, Scheme: scheme, Tracer: asmetrics.NewNoOp()}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: template.Name, Namespace: template.Namespace}}
+
+	const updates = templateRevisionHistoryLimit + 3
+	for i := range updates {
+		require.NoError(t, fc.Get(ctx, req.NamespacedName, template))
+		template.Spec.PodTemplate.Spec.Containers[0].Image = fmt.Sprintf("image-v%d", i)
+		require.NoError(t, fc.Update(ctx, template))
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+	}
+
+	revisions := templateRevisions(t, fc, template)
+	require.Len(t, revisions, templateRevisionHistoryLimit)
+	for name, rev := range revisions {
+		require.Greater(t, rev.Revision, int64(updates-templateRevisionHistoryLimit), "revision %s should have been pruned", name)
+	}
+}
+
+func TestSandboxTemplateRevisionNotControlled(t *testing.T) {
+	ctx := context.Background()
+	scheme := newTestScheme()
+	template := createTemplate("default")
+	template.UID = "template-uid"
+	hash, err := computeSandboxBlueprintHash(template)
+	require.NoError(t, err)
+	foreign := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SandboxTemplateRevisionName(template.Name, hash),
+			Namespace: template.Namespace,
+		},
+		Revision: 1,
+	}
+	fc := newFakeClient(scheme, template, foreign)
+	r := &SandboxTemplateRevisionReconciler{Client: fc, Scheme: scheme, Tracer: asmetrics.NewNoOp()}
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: template.Name, Namespace: template.Namespace}})
+	require.ErrorContains(t, err, "not controlled by SandboxTemplate")
+
+	got := &appsv1.ControllerRevision{}
+	require.NoError(t, fc.Get(ctx, client.ObjectKeyFromObject(foreign), got))
+	require.Empty(t, got.OwnerReferences, "a revision the template doesn't control must be left alone")
+}
+
+func TestSandboxTemplateRevisionName(t *testing.T) {
+	require.Equal(t, "my-template-0123abcd", SandboxTemplateRevisionName("my-template", "0123abcd"))
+
+	// A long template name is shortened to keep the name a valid label value,
+	// without leaving a separator before the hash.
+	long := strings.Repeat("a", 53) + "-" + strings.Repeat("b", 20)
+	name := SandboxTemplateRevisionName(long, "0123abcd")
+	require.LessOrEqual(t, len(name), validation.LabelValueMaxLength)
+	require.Empty(t, validation.IsValidLabelValue(name))
+	require.Equal(t, strings.Repeat("a", 53)+"-0123abcd", name)
+}
@@ -24,24 +24,32 @@ import (
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
-	sandboxcontrollers "sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 )
 
 const (
@@ -49,12 +57,55 @@ const (
 	warmPoolSandboxLabel            = sandboxv1beta1.SandboxWarmPoolLabel
 	sandboxCreateDeleteMaxBatchSize = 300
 	autoscalerSafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// prePullPoolLabel selects the pods of a warm pool's image pre-pull DaemonSet.
+	// Its value is the hash of the pool's name.
+	prePullPoolLabel = "agents.x-k8s.io/prepull-pool"
+	// prePullTemplateHashAnnotation records the hash of the pre-pull DaemonSet's pod
+	// template, so the DaemonSet is only updated when the template images change.
+	prePullTemplateHashAnnotation = "agents.x-k8s.io/prepull-template-hash"
+	// prePullPauseImage keeps pre-pull pods running once their images are pulled.
+	prePullPauseImage = "registry.k8s.io/pause:3.10"
+	// prePullPauseUser is the non-root user the pause image is built to run as.
+	prePullPauseUser = int64(65535)
 	// sandboxWarmPoolLabelIndex is the cache field index over the warmPoolSandboxLabel
 	// value on warm sandboxes, so reconcilePool's member lookup is O(pool members) instead
 	// of O(sandboxes-in-namespace).
 	sandboxWarmPoolLabelIndex = ".metadata.labels[" + warmPoolSandboxLabel + "]"
+	// poolPodAntiAffinityWeight is the weight of the anti-affinity injected by
+	// spec.antiAffinity; the maximum, so spreading wins over softer preferences.
+	poolPodAntiAffinityWeight = 100
 )
 
+const (
+	// warmPoolRetryBaseDelay and warmPoolRetryMaxDelay bound the per-pool exponential
+	// backoff after a failed reconcile, such as a scale-up where some creates failed.
+	warmPoolRetryBaseDelay = 500 * time.Millisecond
+	warmPoolRetryMaxDelay  = 5 * time.Minute
+	// warmPoolRotationRequeueDelay is how soon a pool with expired sandboxes still
+	// waiting to be rotated is checked again.
+	warmPoolRotationRequeueDelay = 10 * time.Second
+)
+
+// jitteredRateLimiter stretches each delay of the wrapped rate limiter by a random
+// factor of up to 2x, so pools failing together (e.g. against an exhausted quota)
+// do not retry against the API server in lockstep.
+type jitteredRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+func (l jitteredRateLimiter) When(item reconcile.Request) time.Duration {
+	return wait.Jitter(l.TypedRateLimiter.When(item), 1.0)
+}
+
+// newWarmPoolRateLimiter returns the workqueue rate limiter of the SandboxWarmPool
+// controller: the controller-runtime default, with a slower, jittered per-pool backoff.
+func newWarmPoolRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	return jitteredRateLimiter{workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](warmPoolRetryBaseDelay, warmPoolRetryMaxDelay),
+		workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+	)}
+}
+
 // SandboxWarmPoolReconciler reconciles a SandboxWarmPool object.
 type SandboxWarmPoolReconciler struct {
 	client.Client
@@ -67,6 +118,9 @@ type SandboxWarmPoolReconciler struct {
 //+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxwarmpools/finalizers,verbs=get;update;patch
 //+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxwarmpools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=agents.x-k8s.io,resources=sandboxes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=extensions.agents.x-k8s.io,resources=sandboxclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;delete
 
 // Reconcile implements the reconciliation loop for SandboxWarmPool.
 func (r *SandboxWarmPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -97,13 +151,258 @@ func (r *SandboxWarmPoolReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// A pre-pull failure does not hold back the pool's status.
+	prePullErr := r.reconcilePrePull(ctx, warmPool)
+
 	// Update status if it has changed
 	if err := r.updateStatus(ctx, oldStatus, warmPool); err != nil {
 		logger.Error(err, "Failed to update SandboxWarmPool status")
 		return ctrl.Result{}, err
 	}
+	if prePullErr != nil {
+		logger.Error(prePullErr, "Failed to reconcile image pre-pull DaemonSet")
+		return ctrl.Result{}, prePullErr
+	}
+
+	// Ready sandboxes become available without any further event, so check back once
+	// the newest of them has had time to pass minReadySeconds.
+	result := ctrl.Result{}
+	if warmPool.Spec.MinReadySeconds > 0 && warmPool.Status.AvailableReplicas < warmPool.Status.ReadyReplicas {
+		result.RequeueAfter = time.Duration(warmPool.Spec.MinReadySeconds) * time.Second
+	}
+	// Sandboxes reach maxPodAge without any further event either.
+	if warmPool.Spec.MaxPodAge != nil {
+		next, err := r.nextSandboxExpiry(ctx, warmPool)
+		if err != nil {
+			logger.Error(err, "Failed to compute next sandbox rotation")
+			return ctrl.Result{}, err
+		}
+		if next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+			result.RequeueAfter = next
+		}
+	}
+	return result, nil
+}
+
+// reconcilePrePull keeps the image pre-pull DaemonSet of the pool in line with
+// spec.prePull and the images of its SandboxTemplate.
+func (r *SandboxWarmPoolReconciler) reconcilePrePull(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool) error {
+	logger := log.FromContext(ctx)
+
+	existing := &appsv1.DaemonSet{}
+	key := types.NamespacedName{Name: prePullDaemonSetName(warmPool), Namespace: warmPool.Namespace}
+	if err := r.Get(ctx, key, existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		existing = nil
+	}
+	if existing != nil && !metav1.IsControlledBy(existing, warmPool) {
+		return fmt.Errorf("daemonset %q exists and is not owned by warm pool %q", key.Name, warmPool.Name)
+	}
+
+	if !warmPool.Spec.PrePull {
+		if existing == nil {
+			return nil
+		}
+		logger.Info("Deleting image pre-pull DaemonSet", "daemonSet", existing.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, existing))
+	}
+
+	template, err := r.getTemplate(ctx, warmPool)
+	if err != nil {
+		// The pool's conditions already report a missing template.
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	desired, err := r.buildPrePullDaemonSet(warmPool, template)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		logger.Info("Creating image pre-pull DaemonSet", "daemonSet", desired.Name)
+		return r.Create(ctx, desired)
+	}
+	if existing.Annotations[prePullTemplateHashAnnotation] == desired.Annotations[prePullTemplateHashAnnotation] {
+		return nil
+	}
+	logger.Info("Updating image pre-pull DaemonSet", "daemonSet", existing.Name)
+	existing.Annotations = desired.Annotations
+	existing.Spec.Template = desired.Spec.Template
+	return r.Update(ctx, existing)
+}
 
-	return ctrl.Result{}, nil
+// prePullDaemonSetName returns the name of the pool's image pre-pull DaemonSet.
+func prePullDaemonSetName(warmPool *extensionsv1beta1.SandboxWarmPool) string {
+	return warmPool.Name + "-prepull"
+}
+
+// buildPrePullDaemonSet returns the DaemonSet that pulls every image of the
+// template onto the nodes its pods may be scheduled to. Each image is mounted as
+// an image volume, which the kubelet pulls without running anything from it, and
+// a locked-down pause container keeps the pod running so the DaemonSet does not
+// restart it. The pod runs under the template's runtime class and pod security
+// context, like the sandboxes it pulls for.
+func (r *SandboxWarmPoolReconciler) buildPrePullDaemonSet(warmPool *extensionsv1beta1.SandboxWarmPool, template *extensionsv1beta1.SandboxTemplate) (*appsv1.DaemonSet, error) {
+	podSpec := template.Spec.PodTemplate.Spec
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1m"),
+		corev1.ResourceMemory: resource.MustParse("8Mi"),
+	}
+
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	seen := map[string]bool{}
+	for _, c := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+		if c.Image == "" || seen[c.Image] {
+			continue
+		}
+		seen[c.Image] = true
+		name := fmt.Sprintf("prepull-%d", len(volumes))
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{
+				Reference:  c.Image,
+				PullPolicy: corev1.PullIfNotPresent,
+			}},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: "/prepull/" + name, ReadOnly: true})
+	}
+
+	var affinity *corev1.Affinity
+	if podSpec.Affinity != nil && podSpec.Affinity.NodeAffinity != nil {
+		affinity = &corev1.Affinity{NodeAffinity: podSpec.Affinity.NodeAffinity.DeepCopy()}
+	}
+
+	podLabels := map[string]string{prePullPoolLabel: naming.NameHash(warmPool.Name)}
+	podTemplate := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            "pause",
+				Image:           prePullPauseImage,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Resources:       corev1.ResourceRequirements{Requests: requests},
+				VolumeMounts:    mounts,
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					AllowPrivilegeEscalation: new(false),
+					ReadOnlyRootFilesystem:   new(true),
+					RunAsNonRoot:             new(true),
+					RunAsUser:                new(prePullPauseUser),
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+			}},
+			Volumes:                      volumes,
+			RuntimeClassName:             podSpec.RuntimeClassName,
+			SecurityContext:              podSpec.SecurityContext.DeepCopy(),
+			ImagePullSecrets:             podSpec.ImagePullSecrets,
+			NodeSelector:                 podSpec.NodeSelector,
+			Tolerations:                  podSpec.Tolerations,
+			Affinity:                     affinity,
+			AutomountServiceAccountToken: new(false),
+		},
+	}
+	templateJSON, err := json.Marshal(podTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pre-pull pod template: %w", err)
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prePullDaemonSetName(warmPool),
+			Namespace:   warmPool.Namespace,
+			Labels:      podLabels,
+			Annotations: map[string]string{prePullTemplateHashAnnotation: naming.NameHash(string(templateJSON))},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: podTemplate,
+		},
+	}
+	if err := controllerutil.SetControllerReference(warmPool, ds, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference on pre-pull daemonset: %w", err)
+	}
+	return ds, nil
+}
+
+// isSandboxExpired reports whether a pool sandbox has outlived spec.maxPodAge and
+// may be rotated. Only available sandboxes are rotated, so one still warming up
+// is never swapped for another cold start.
+func isSandboxExpired(sb *sandboxv1beta1.Sandbox, warmPool *extensionsv1beta1.SandboxWarmPool, now time.Time) bool {
+	if warmPool.Spec.MaxPodAge == nil || sb.CreationTimestamp.IsZero() {
+		return false
+	}
+	if now.Sub(sb.CreationTimestamp.Time) < warmPool.Spec.MaxPodAge.Duration {
+		return false
+	}
+	return isSandboxAvailable(sb, warmPool.Spec.MinReadySeconds, now)
+}
+
+// nextSandboxExpiry returns how long until the oldest pool sandbox reaches
+// spec.maxPodAge, or zero if none is still younger than that. An expired
+// sandbox that is still waiting its turn to be rotated yields
+// warmPoolRotationRequeueDelay. Other sandboxes already past maxPodAge are
+// waiting to become available, which readiness events and the minReadySeconds
+// requeue already cover.
+func (r *SandboxWarmPoolReconciler) nextSandboxExpiry(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool) (time.Duration, error) {
+	sandboxList := &sandboxv1beta1.SandboxList{}
+	if err := r.List(ctx, sandboxList,
+		client.InNamespace(warmPool.Namespace),
+		client.MatchingFields{sandboxWarmPoolLabelIndex: naming.NameHash(warmPool.Name)},
+	); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var next time.Duration
+	for i := range sandboxList.Items {
+		sb := &sandboxList.Items[i]
+		if sb.CreationTimestamp.IsZero() || !sb.DeletionTimestamp.IsZero() {
+			continue
+		}
+		remaining := sb.CreationTimestamp.Add(warmPool.Spec.MaxPodAge.Duration).Sub(now)
+		if remaining <= 0 {
+			if !isSandboxExpired(sb, warmPool, now) {
+				continue
+			}
+			remaining = warmPoolRotationRequeueDelay
+		}
+		if next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+	return next, nil
+}
+
+// recordWarmupLatency records the time a ready warm pool sandbox took to become ready.
+// The sandbox is annotated before the observation is recorded so that it is counted
+// at most once across reconciles.
+func (r *SandboxWarmPoolReconciler) recordWarmupLatency(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool, sb *sandboxv1beta1.Sandbox) {
+	if _, ok := sb.Annotations[asmetrics.ReadinessObservedAnnotation]; ok || sb.CreationTimestamp.IsZero() {
+		return
+	}
+	readyCond := meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	if readyCond == nil || readyCond.LastTransitionTime.IsZero() {
+		return
+	}
+
+	patch := client.MergeFrom(sb.DeepCopy())
+	if sb.Annotations == nil {
+		sb.Annotations = make(map[string]string)
+	}
+	sb.Annotations[asmetrics.ReadinessObservedAnnotation] = "true"
+	if err := r.Patch(ctx, sb, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to mark warm pool sandbox readiness as observed", "sandbox", sb.Name)
+		return
+	}
+
+	if latency := readyCond.LastTransitionTime.Sub(sb.CreationTimestamp.Time); latency >= 0 {
+		asmetrics.RecordWarmPoolWarmupLatency(latency, warmPool.Namespace, warmPool.Name)
+	}
 }
 
 // reconcilePool ensures the correct number of pre-allocated sandboxes exist in the pool.
@@ -111,7 +410,7 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 	logger := log.FromContext(ctx)
 
 	// Compute hash of the warm pool name for the pool label
-	poolNameHash := sandboxcontrollers.NameHash(warmPool.Name)
+	poolNameHash := naming.NameHash(warmPool.Name)
 
 	// List all Sandbox CRs with the warm pool label
 	sandboxList := &sandboxv1beta1.SandboxList{}
@@ -139,8 +438,20 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 	const warmPoolReadinessGracePeriod = 5 * time.Minute
 
 	now := time.Now()
-	var healthySandboxes []sandboxv1beta1.Sandbox
+	var healthySandboxes, expiredSandboxes []sandboxv1beta1.Sandbox
 	for _, sb := range activeSandboxes {
+		drained, err := r.isSandboxDrained(ctx, &sb)
+		if err != nil {
+			logger.Error(err, "Failed to check drain annotation", "sandbox", sb.Name)
+			allErrors = errors.Join(allErrors, err)
+		} else if drained {
+			logger.Info("Deleting drained warm pool sandbox", "sandbox", sb.Name)
+			if err := r.Delete(ctx, &sb); err != nil && !k8serrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete drained sandbox", "sandbox", sb.Name)
+				allErrors = errors.Join(allErrors, err)
+			}
+			continue
+		}
 		if !isSandboxReady(&sb) && !sb.CreationTimestamp.IsZero() && now.Sub(sb.CreationTimestamp.Time) > warmPoolReadinessGracePeriod {
 			logger.Info("Deleting stuck warm pool sandbox",
 				"sandbox", sb.Name,
@@ -151,6 +462,9 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 			}
 			continue
 		}
+		if isSandboxExpired(&sb, warmPool, now) {
+			expiredSandboxes = append(expiredSandboxes, sb)
+		}
 		healthySandboxes = append(healthySandboxes, sb)
 	}
 	activeSandboxes = healthySandboxes
@@ -159,31 +473,93 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 	if warmPool.Spec.Replicas != nil {
 		desiredReplicas = *warmPool.Spec.Replicas
 	}
+	claimedReplicas := int32(0)
+	if warmPool.Spec.MinAvailable != nil {
+		claimed, err := r.countClaimedSandboxes(ctx, warmPool)
+		if err != nil {
+			logger.Error(err, "Failed to count claimed sandboxes")
+			return errors.Join(allErrors, err)
+		}
+		claimedReplicas = claimed
+		// Size the pool so claimed plus spare sandboxes cover replicas while
+		// at least minAvailable spares remain.
+		desiredReplicas = max(desiredReplicas, claimedReplicas+*warmPool.Spec.MinAvailable) - claimedReplicas
+	}
+
+	// Rotate expired sandboxes one per pass, and only while the ready spares
+	// stay at or above desiredReplicas, so a pool whose sandboxes were created
+	// together does not empty out when they all reach maxPodAge. One replacement
+	// is surged above desiredReplicas until every expired sandbox is rotated.
+	surgeReplicas := int32(0)
+	if len(expiredSandboxes) > 0 {
+		readySpares := int32(0)
+		for i := range activeSandboxes {
+			if isSandboxReady(&activeSandboxes[i]) {
+				readySpares++
+			}
+		}
+		if readySpares > desiredReplicas {
+			oldest := slices.MinFunc(expiredSandboxes, func(a, b sandboxv1beta1.Sandbox) int {
+				return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+			})
+			logger.Info("Rotating expired warm pool sandbox",
+				"sandbox", oldest.Name,
+				"age", now.Sub(oldest.CreationTimestamp.Time).Round(time.Second))
+			if err := r.Delete(ctx, &oldest); err != nil && !k8serrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete expired sandbox", "sandbox", oldest.Name)
+				allErrors = errors.Join(allErrors, err)
+			} else {
+				isOldest := func(sb sandboxv1beta1.Sandbox) bool { return sb.Name == oldest.Name }
+				activeSandboxes = slices.DeleteFunc(activeSandboxes, isOldest)
+				expiredSandboxes = slices.DeleteFunc(expiredSandboxes, isOldest)
+			}
+		}
+		if len(expiredSandboxes) > 0 && desiredReplicas > 0 {
+			surgeReplicas = 1
+		}
+	}
+	targetReplicas := desiredReplicas + surgeReplicas
 	currentReplicas := int32(len(activeSandboxes))
 
 	logger.Info("Pool status",
 		"desired", desiredReplicas,
+		"surge", surgeReplicas,
 		"current", currentReplicas,
+		"claimed", claimedReplicas,
 		"poolName", warmPool.Name,
 		"poolNameHash", poolNameHash)
 
 	warmPool.Status.Replicas = currentReplicas
 	warmPool.Status.Selector = labelSelector.String()
 
-	// Calculate ready replicas by checking Sandbox Ready condition
+	// Calculate ready replicas by checking Sandbox Ready condition, and available
+	// replicas by how long they have been Ready
 	readyReplicas := int32(0)
+	availableReplicas := int32(0)
+	crashingReplicas := int32(0)
 	for i := range activeSandboxes {
 		if isSandboxReady(&activeSandboxes[i]) {
 			readyReplicas++
+			r.recordWarmupLatency(ctx, warmPool, &activeSandboxes[i])
+		} else if isSandboxCrashing(&activeSandboxes[i]) {
+			crashingReplicas++
+		}
+		if isSandboxAvailable(&activeSandboxes[i], warmPool.Spec.MinReadySeconds, now) {
+			availableReplicas++
 		}
 	}
 	warmPool.Status.ReadyReplicas = readyReplicas
+	warmPool.Status.AvailableReplicas = availableReplicas
+	meta.SetStatusCondition(&warmPool.Status.Conditions, computeWarmPoolReadyCondition(warmPool, desiredReplicas, readyReplicas, tmplErr))
+	for _, cond := range computeWarmPoolProgressConditions(warmPool, desiredReplicas, currentReplicas, readyReplicas, crashingReplicas, tmplErr) {
+		meta.SetStatusCondition(&warmPool.Status.Conditions, cond)
+	}
 
 	maxBatchSize := int32(r.MaxBatchSize)
 
 	// Create new sandboxes if we need more
-	if currentReplicas < desiredReplicas && tmplErr == nil {
-		sandboxesToCreate := min(desiredReplicas-currentReplicas, maxBatchSize)
+	if currentReplicas < targetReplicas && tmplErr == nil {
+		sandboxesToCreate := min(targetReplicas-currentReplicas, maxBatchSize)
 		logger.Info("Creating new pool sandboxes", "count", sandboxesToCreate)
 
 		sandboxCR, err := r.buildSandboxCR(warmPool, poolNameHash, template, currentPodTemplateHash, currentSandboxBlueprintHash)
@@ -203,8 +579,8 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 	}
 
 	// Delete excess sandboxes if we have too many
-	if currentReplicas > desiredReplicas {
-		sandboxesToDelete := min(currentReplicas-desiredReplicas, maxBatchSize)
+	if currentReplicas > targetReplicas {
+		sandboxesToDelete := min(currentReplicas-targetReplicas, maxBatchSize)
 		logger.Info("Deleting excess sandboxes", "count", sandboxesToDelete)
 
 		// Prioritize deleting unready sandboxes before ready ones,
@@ -239,6 +615,97 @@ func (r *SandboxWarmPoolReconciler) reconcilePool(ctx context.Context, warmPool
 	return allErrors
 }
 
+// computeWarmPoolReadyCondition reports whether the pool has all desired sandboxes ready.
+// A missing SandboxTemplate gets its own reason so that it is distinguishable from
+// a pool that is still replenishing.
+func computeWarmPoolReadyCondition(warmPool *extensionsv1beta1.SandboxWarmPool, desiredReplicas, readyReplicas int32, tmplErr error) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               string(sandboxv1beta1.SandboxConditionReady),
+		ObservedGeneration: warmPool.Generation,
+	}
+	switch {
+	case k8serrors.IsNotFound(tmplErr):
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "TemplateNotFound"
+		cond.Message = fmt.Sprintf("SandboxTemplate %q not found", warmPool.Spec.TemplateRef.Name)
+	case tmplErr != nil:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "ReconcilerError"
+		cond.Message = "Error seen: " + tmplErr.Error()
+	case readyReplicas < desiredReplicas:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Replenishing"
+		cond.Message = fmt.Sprintf("%d of %d sandboxes are ready", readyReplicas, desiredReplicas)
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "PoolReady"
+		cond.Message = fmt.Sprintf("%d of %d sandboxes are ready", readyReplicas, desiredReplicas)
+	}
+	return cond
+}
+
+// computeWarmPoolProgressConditions derives the InProgress, Current and Degraded
+// conditions from the pool's replica counts. crashingReplicas counts the unready
+// sandboxes whose pods restarted or failed.
+func computeWarmPoolProgressConditions(warmPool *extensionsv1beta1.SandboxWarmPool, desiredReplicas, currentReplicas, readyReplicas, crashingReplicas int32, tmplErr error) []metav1.Condition {
+	inProgress := metav1.Condition{
+		Type:               extensionsv1beta1.SandboxWarmPoolConditionInProgress,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: warmPool.Generation,
+		Reason:             "PoolReady",
+		Message:            fmt.Sprintf("%d of %d sandboxes are ready", readyReplicas, desiredReplicas),
+	}
+	switch {
+	case tmplErr != nil:
+		// Nothing progresses until the template is fixed; Ready carries the error.
+		inProgress.Reason = "TemplateUnavailable"
+		inProgress.Message = "Waiting for SandboxTemplate " + warmPool.Spec.TemplateRef.Name
+	case currentReplicas != desiredReplicas:
+		inProgress.Status = metav1.ConditionTrue
+		inProgress.Reason = "Scaling"
+		inProgress.Message = fmt.Sprintf("Scaling from %d to %d sandboxes", currentReplicas, desiredReplicas)
+	case readyReplicas < desiredReplicas:
+		inProgress.Status = metav1.ConditionTrue
+		inProgress.Reason = "WaitingForReady"
+	}
+
+	current := metav1.Condition{
+		Type:               extensionsv1beta1.SandboxWarmPoolConditionCurrent,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: warmPool.Generation,
+		Reason:             inProgress.Reason,
+		Message:            inProgress.Message,
+	}
+	if tmplErr == nil && inProgress.Status == metav1.ConditionFalse {
+		current.Status = metav1.ConditionTrue
+	}
+
+	degraded := metav1.Condition{
+		Type:               extensionsv1beta1.SandboxWarmPoolConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: warmPool.Generation,
+		Reason:             "NoCrashingSandboxes",
+		Message:            "No sandboxes are crashing",
+	}
+	if crashingReplicas > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "SandboxesCrashing"
+		degraded.Message = fmt.Sprintf("%d of %d sandboxes are not ready because their pods restarted or failed", crashingReplicas, currentReplicas)
+	}
+
+	return []metav1.Condition{inProgress, current, degraded}
+}
+
+// isSandboxCrashing reports whether an unready sandbox got there because its pod
+// restarted or failed, rather than because it is still starting.
+func isSandboxCrashing(sb *sandboxv1beta1.Sandbox) bool {
+	if sb.Status.RestartCount > 0 {
+		return true
+	}
+	cond := meta.FindStatusCondition(sb.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	return cond != nil && (cond.Reason == sandboxv1beta1.SandboxReasonPodFailed || cond.Reason == sandboxv1beta1.SandboxReasonRecreateLimitExceeded)
+}
+
 // adoptSandbox sets this warmpool as the owner of an orphaned sandbox.
 func (r *SandboxWarmPoolReconciler) adoptSandbox(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool, sb *sandboxv1beta1.Sandbox) error {
 	if err := controllerutil.SetControllerReference(warmPool, sb, r.Scheme); err != nil {
@@ -337,16 +804,21 @@ func computePodTemplateHash(template *extensionsv1beta1.SandboxTemplate) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal pod template for hashing: %w", err)
 	}
-	return sandboxcontrollers.NameHash(string(specJSON)), nil
+	return naming.NameHash(string(specJSON)), nil
 }
 
 // computeSandboxBlueprintHash computes a hash of the sandbox template's Spec.SandboxBlueprint.
+// The default readiness probe is included when set, since it is injected into the pool pods.
 func computeSandboxBlueprintHash(template *extensionsv1beta1.SandboxTemplate) (string, error) {
-	specJSON, err := json.Marshal(template.Spec.SandboxBlueprint)
+	var hashed any = template.Spec.SandboxBlueprint
+	if template.Spec.DefaultReadinessProbe != nil {
+		hashed = []any{template.Spec.SandboxBlueprint, template.Spec.DefaultReadinessProbe}
+	}
+	specJSON, err := json.Marshal(hashed)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal sandbox blueprint for hashing: %w", err)
 	}
-	return sandboxcontrollers.NameHash(string(specJSON)), nil
+	return naming.NameHash(string(specJSON)), nil
 }
 
 // fetchTemplateAndHash fetches the sandbox template and computes its hash.
@@ -381,6 +853,7 @@ func (r *SandboxWarmPoolReconciler) buildSandboxCR(
 		sandboxv1beta1.SandboxLaunchTypeLabel:                sandboxv1beta1.SandboxLaunchTypeWarm,
 		sandboxv1beta1.DeprecatedSandboxPodTemplateHashLabel: currentPodTemplateHash,
 		sandboxv1beta1.SandboxTemplateHashLabel:              currentSandboxBlueprintHash,
+		sandboxv1beta1.SandboxTemplateRevisionLabel:          SandboxTemplateRevisionName(template.Name, currentSandboxBlueprintHash),
 		sandboxv1beta1.CreatedByLabel:                        "controller",
 	}
 
@@ -411,6 +884,21 @@ func (r *SandboxWarmPoolReconciler) buildSandboxCR(
 	sandbox.Spec.PodTemplate.ObjectMeta.Labels[sandboxv1beta1.DeprecatedSandboxPodTemplateHashLabel] = currentPodTemplateHash
 	sandbox.Spec.PodTemplate.ObjectMeta.Labels[sandboxv1beta1.SandboxTemplateHashLabel] = currentSandboxBlueprintHash
 
+	// Spread pool pods when the pool asks for it and the template has no
+	// constraints of its own.
+	if len(warmPool.Spec.TopologySpreadConstraints) > 0 && len(sandbox.Spec.PodTemplate.Spec.TopologySpreadConstraints) == 0 {
+		sandbox.Spec.PodTemplate.Spec.TopologySpreadConstraints = poolTopologySpreadConstraints(warmPool, poolNameHash)
+	}
+
+	// Prefer keeping pool pods on separate nodes when the pool asks for it and the
+	// template has no pod anti-affinity of its own.
+	if warmPool.Spec.AntiAffinity && !hasPodAntiAffinity(&sandbox.Spec.PodTemplate.Spec) {
+		if sandbox.Spec.PodTemplate.Spec.Affinity == nil {
+			sandbox.Spec.PodTemplate.Spec.Affinity = &corev1.Affinity{}
+		}
+		sandbox.Spec.PodTemplate.Spec.Affinity.PodAntiAffinity = poolPodAntiAffinity(poolNameHash)
+	}
+
 	// Respect the template's custom eviction annotation if explicitly specified.
 	// Only apply the default eviction behavior if the annotation is not defined.
 	if _, exists := sandbox.Spec.PodTemplate.ObjectMeta.Annotations[autoscalerSafeToEvictAnnotation]; !exists {
@@ -424,6 +912,7 @@ func (r *SandboxWarmPoolReconciler) buildSandboxCR(
 
 	// Apply secure defaults to the sandbox pod spec
 	ApplySandboxSecureDefaults(template, &sandbox.Spec.PodTemplate.Spec)
+	ApplyDefaultReadinessProbe(template, &sandbox.Spec.PodTemplate.Spec)
 
 	// Set controller reference so the Sandbox is owned by the SandboxWarmPool
 	if err := ctrl.SetControllerReference(warmPool, sandbox, r.Scheme); err != nil {
@@ -433,6 +922,42 @@ func (r *SandboxWarmPoolReconciler) buildSandboxCR(
 	return sandbox, nil
 }
 
+// poolTopologySpreadConstraints returns the warm pool's topology spread constraints,
+// scoping any constraint without a labelSelector to the pool's pods.
+func poolTopologySpreadConstraints(warmPool *extensionsv1beta1.SandboxWarmPool, poolNameHash string) []corev1.TopologySpreadConstraint {
+	constraints := make([]corev1.TopologySpreadConstraint, len(warmPool.Spec.TopologySpreadConstraints))
+	for i := range warmPool.Spec.TopologySpreadConstraints {
+		warmPool.Spec.TopologySpreadConstraints[i].DeepCopyInto(&constraints[i])
+		if constraints[i].LabelSelector == nil {
+			constraints[i].LabelSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{warmPoolSandboxLabel: poolNameHash},
+			}
+		}
+	}
+	return constraints
+}
+
+// poolPodAntiAffinity returns a preferred pod anti-affinity that steers a pool's
+// pods away from nodes already running another pod of the same pool.
+func poolPodAntiAffinity(poolNameHash string) *corev1.PodAntiAffinity {
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight: poolPodAntiAffinityWeight,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{warmPoolSandboxLabel: poolNameHash},
+				},
+				TopologyKey: corev1.LabelHostname,
+			},
+		}},
+	}
+}
+
+// hasPodAntiAffinity reports whether spec defines a pod anti-affinity.
+func hasPodAntiAffinity(spec *corev1.PodSpec) bool {
+	return spec.Affinity != nil && spec.Affinity.PodAntiAffinity != nil
+}
+
 // createPoolSandbox creates a full Sandbox CR for the warm pool using a pre-built sandboxCR.
 func (r *SandboxWarmPoolReconciler) createPoolSandbox(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool, sandboxCR *sandboxv1beta1.Sandbox) error {
 	logger := log.FromContext(ctx)
@@ -473,7 +998,7 @@ func (r *SandboxWarmPoolReconciler) updateStatus(ctx context.Context, oldStatus
 		return fmt.Errorf("failed to update SandboxWarmPool status: %w", err)
 	}
 
-	logger.Info("Updated SandboxWarmPool status", "replicas", warmPool.Status.Replicas, "readyReplicas", warmPool.Status.ReadyReplicas)
+	logger.Info("Updated SandboxWarmPool status", "replicas", warmPool.Status.Replicas, "readyReplicas", warmPool.Status.ReadyReplicas, "availableReplicas", warmPool.Status.AvailableReplicas)
 	return nil
 }
 
@@ -558,6 +1083,26 @@ func (r *SandboxWarmPoolReconciler) comparePodSpecs(template *extensionsv1beta1.
 	// Create what the sandbox SHOULD look like if it were created from the current template.
 	expectedSpec := template.Spec.PodTemplate.Spec.DeepCopy()
 	ApplySandboxSecureDefaults(template, expectedSpec)
+	ApplyDefaultReadinessProbe(template, expectedSpec)
+
+	// Pool-level topology spread constraints are only injected when the template
+	// has none, and pool changes do not make sandboxes stale, so ignore them.
+	if len(expectedSpec.TopologySpreadConstraints) == 0 && len(actualSandboxSpec.TopologySpreadConstraints) > 0 {
+		actualCopy := *actualSandboxSpec
+		actualCopy.TopologySpreadConstraints = nil
+		actualSandboxSpec = &actualCopy
+	}
+	// The same goes for the pool-level pod anti-affinity.
+	if !hasPodAntiAffinity(expectedSpec) && hasPodAntiAffinity(actualSandboxSpec) {
+		actualCopy := *actualSandboxSpec
+		affinity := *actualCopy.Affinity
+		affinity.PodAntiAffinity = nil
+		actualCopy.Affinity = &affinity
+		if expectedSpec.Affinity == nil && affinity == (corev1.Affinity{}) {
+			actualCopy.Affinity = nil
+		}
+		actualSandboxSpec = &actualCopy
+	}
 
 	// Compare the actual sandbox spec to the expected "perfect" spec.
 	// Since both have now undergone the exact same defaulting logic,
@@ -603,6 +1148,46 @@ func sandboxWarmPoolLabelIndexer(obj client.Object) []string {
 	return nil
 }
 
+// isSandboxDrained reports whether the pod of a pool sandbox carries the drain
+// annotation. A sandbox whose pod does not exist yet is not drained.
+func (r *SandboxWarmPoolReconciler) isSandboxDrained(ctx context.Context, sb *sandboxv1beta1.Sandbox) (bool, error) {
+	podName := sb.Name
+	if name := sb.Annotations[sandboxv1beta1.SandboxPodNameAnnotation]; name != "" {
+		podName = name
+	}
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Name: podName, Namespace: sb.Namespace}, pod); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return pod.Annotations[extensionsv1beta1.WarmPoolDrainAnnotation] == "true", nil
+}
+
+// countClaimedSandboxes returns how many claims referencing the warm pool hold a
+// Sandbox. Expired claims no longer do, and deleting claims are about to let
+// theirs go.
+func (r *SandboxWarmPoolReconciler) countClaimedSandboxes(ctx context.Context, warmPool *extensionsv1beta1.SandboxWarmPool) (int32, error) {
+	claims := &extensionsv1beta1.SandboxClaimList{}
+	if err := r.List(ctx, claims,
+		client.InNamespace(warmPool.Namespace),
+		client.MatchingFields{extensionsv1beta1.WarmPoolRefField: warmPool.Name},
+	); err != nil {
+		return 0, err
+	}
+
+	claimed := int32(0)
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if !claim.DeletionTimestamp.IsZero() || claim.Status.SandboxStatus.Name == "" || hasClaimExpiredCondition(claim.Status.Conditions) {
+			continue
+		}
+		claimed++
+	}
+	return claimed, nil
+}
+
 // sandboxTemplateRefNameIndexer extracts the template reference name for the
 // TemplateRefField cache field index. Shared with tests so fake clients
 // register the same index the manager does.
@@ -635,14 +1220,65 @@ func (r *SandboxWarmPoolReconciler) SetupWithManager(mgr ctrl.Manager, concurren
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&extensionsv1beta1.SandboxWarmPool{}).
 		Owns(&sandboxv1beta1.Sandbox{}).
-		WithOptions(controller.Options{MaxConcurrentReconciles: concurrentWorkers}).
+		Owns(&appsv1.DaemonSet{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: concurrentWorkers,
+			RateLimiter:             newWarmPoolRateLimiter(),
+		}).
 		Watches(
 			&extensionsv1beta1.SandboxTemplate{},
 			handler.EnqueueRequestsFromMapFunc(r.findWarmPoolsForTemplate),
 		).
+		Watches(
+			&extensionsv1beta1.SandboxClaim{},
+			handler.EnqueueRequestsFromMapFunc(findWarmPoolForClaim),
+		).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.findWarmPoolForDrainedPod),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}),
+		).
 		Complete(r)
 }
 
+// findWarmPoolForDrainedPod returns the warm pool owning the sandbox of a pod
+// that carries the drain annotation.
+func (r *SandboxWarmPoolReconciler) findWarmPoolForDrainedPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetAnnotations()[extensionsv1beta1.WarmPoolDrainAnnotation] != "true" {
+		return nil
+	}
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "Sandbox" {
+		return nil
+	}
+	sb := &sandboxv1beta1.Sandbox{}
+	if err := r.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: obj.GetNamespace()}, sb); err != nil {
+		return nil
+	}
+	poolRef := metav1.GetControllerOf(sb)
+	if poolRef == nil || poolRef.Kind != extensionsv1beta1.SandboxWarmPoolKind {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: poolRef.Name, Namespace: sb.Namespace},
+	}}
+}
+
+// findWarmPoolForClaim returns the warm pool a claim references, so pools with
+// minAvailable resize as claims bind to and release their sandboxes.
+func findWarmPoolForClaim(_ context.Context, obj client.Object) []reconcile.Request {
+	claim, ok := obj.(*extensionsv1beta1.SandboxClaim)
+	if !ok || claim.Spec.WarmPoolRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      claim.Spec.WarmPoolRef.Name,
+			Namespace: claim.Namespace,
+		},
+	}}
+}
+
 // findWarmPoolsForTemplate returns a list of reconcile.Requests for all SandboxWarmPools that reference the template.
 func (r *SandboxWarmPoolReconciler) findWarmPoolsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
 	logger := log.FromContext(ctx)
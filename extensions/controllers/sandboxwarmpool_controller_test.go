@@ -20,23 +20,33 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
-	sandboxcontrollers "sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // Create a test scheme with extensions types registered.
@@ -51,9 +61,10 @@ func newTestScheme() *runtime.Scheme {
 func newFakeClient(scheme *runtime.Scheme, initialObjs ...runtime.Object) client.WithWatch {
 	return fake.NewClientBuilder().
 		WithScheme(scheme).
-		WithStatusSubresource(&extensionsv1beta1.SandboxWarmPool{}).
+		WithStatusSubresource(&extensionsv1beta1.SandboxWarmPool{}, &extensionsv1beta1.SandboxClaimSet{}).
 		WithIndex(&sandboxv1beta1.Sandbox{}, sandboxWarmPoolLabelIndex, sandboxWarmPoolLabelIndexer).
 		WithIndex(&extensionsv1beta1.SandboxWarmPool{}, extensionsv1beta1.TemplateRefField, sandboxTemplateRefNameIndexer).
+		WithIndex(&extensionsv1beta1.SandboxClaim{}, extensionsv1beta1.WarmPoolRefField, sandboxClaimWarmPoolRefIndexer).
 		WithRuntimeObjects(initialObjs...).
 		Build()
 }
@@ -64,7 +75,7 @@ func createPoolSandbox(poolName, namespace, poolNameHash string, template *exten
 	var podSpec corev1.PodSpec
 
 	if template != nil {
-		templateRefHash = sandboxcontrollers.NameHash(template.Name)
+		templateRefHash = naming.NameHash(template.Name)
 		podSpec = *template.Spec.PodTemplate.Spec.DeepCopy()
 		ApplySandboxSecureDefaults(template, &podSpec)
 		// If template has a version label, we could use it as part of the hash placeholder
@@ -73,10 +84,10 @@ func createPoolSandbox(poolName, namespace, poolNameHash string, template *exten
 			sandboxBlueprintHash = "blueprint-hash-" + v
 		} else {
 			podTemplateJSON, _ := json.Marshal(template.Spec.PodTemplate)
-			podTemplateHash = sandboxcontrollers.NameHash(string(podTemplateJSON))
+			podTemplateHash = naming.NameHash(string(podTemplateJSON))
 
 			sandboxBlueprintJSON, _ := json.Marshal(template.Spec.SandboxBlueprint)
-			sandboxBlueprintHash = sandboxcontrollers.NameHash(string(sandboxBlueprintJSON))
+			sandboxBlueprintHash = naming.NameHash(string(sandboxBlueprintJSON))
 		}
 	} else {
 		// Fallback for tests that don't provide a template
@@ -89,10 +100,10 @@ func createPoolSandbox(poolName, namespace, poolNameHash string, template *exten
 			},
 		}
 		podTemplateJSON, _ := json.Marshal(sandboxv1beta1.PodTemplate{Spec: podSpec})
-		podTemplateHash = sandboxcontrollers.NameHash(string(podTemplateJSON))
+		podTemplateHash = naming.NameHash(string(podTemplateJSON))
 
 		sandboxBlueprintJSON, _ := json.Marshal(sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: podSpec}})
-		sandboxBlueprintHash = sandboxcontrollers.NameHash(string(sandboxBlueprintJSON))
+		sandboxBlueprintHash = naming.NameHash(string(sandboxBlueprintJSON))
 	}
 
 	return &sandboxv1beta1.Sandbox{
@@ -178,7 +189,7 @@ func TestReconcilePool(t *testing.T) {
 		},
 	}
 
-	poolNameHash := sandboxcontrollers.NameHash(poolName)
+	poolNameHash := naming.NameHash(poolName)
 	scheme := newTestScheme()
 
 	testCases := []struct {
@@ -305,7 +316,7 @@ func TestReconcilePoolControllerRef(t *testing.T) {
 		},
 	}
 
-	poolNameHash := sandboxcontrollers.NameHash(poolName)
+	poolNameHash := naming.NameHash(poolName)
 
 	createSandboxWithOwner := func(suffix string, ownerUID string) *sandboxv1beta1.Sandbox {
 		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
@@ -477,7 +488,7 @@ func TestPoolLabelValueInIntegration(t *testing.T) {
 			EnableWarmPoolEviction: true,
 		}
 
-		expectedPoolNameHash := sandboxcontrollers.NameHash(poolName)
+		expectedPoolNameHash := naming.NameHash(poolName)
 
 		err := r.reconcilePool(ctx, warmPool)
 		require.NoError(t, err)
@@ -490,7 +501,7 @@ func TestPoolLabelValueInIntegration(t *testing.T) {
 		for _, sb := range list.Items {
 			require.Equal(t, expectedPoolNameHash, sb.Labels[warmPoolSandboxLabel],
 				"sandbox %s should have correct warm pool label", sb.Name)
-			require.Equal(t, sandboxcontrollers.NameHash(templateName), sb.Labels[sandboxTemplateRefHash],
+			require.Equal(t, naming.NameHash(templateName), sb.Labels[sandboxTemplateRefHash],
 				"sandbox %s should have correct template ref label", sb.Name)
 			require.Equal(t, sandboxv1beta1.SandboxLaunchTypeWarm, sb.Labels[sandboxv1beta1.SandboxLaunchTypeLabel],
 				"sandbox %s should have warm launch type label", sb.Name)
@@ -590,6 +601,78 @@ func TestCreatePoolSandboxPropagatesVolumeClaimTemplates(t *testing.T) {
 		"sandbox should have template ref annotation for metrics")
 }
 
+// TestReconcilePoolConcurrentPoolsDoNotOvercreate runs reconcilePool for
+// several pools sharing a template with volumeClaimTemplates in parallel,
+// as --sandbox-warm-pool-concurrent-workers > 1 does. controller-runtime
+// never reconciles the same pool concurrently, so each pool must still end
+// up with exactly its replica count: every extra Sandbox would become an
+// extra set of PVCs.
+func TestReconcilePoolConcurrentPoolsDoNotOvercreate(t *testing.T) {
+	const (
+		poolNamespace = "default"
+		pools         = 8
+		replicas      = int32(5)
+	)
+	ctx := context.Background()
+	scheme := newTestScheme()
+
+	template := createTemplate(poolNamespace)
+	template.Spec.VolumeClaimTemplates = []sandboxv1beta1.PersistentVolumeClaimTemplate{
+		createVolumeClaimTemplate("data", "standard"),
+	}
+
+	r := SandboxWarmPoolReconciler{
+		Client:       newFakeClient(scheme, template),
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+
+	warmPools := make([]*extensionsv1beta1.SandboxWarmPool, pools)
+	for i := range warmPools {
+		warmPools[i] = &extensionsv1beta1.SandboxWarmPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pool-%d", i),
+				Namespace: poolNamespace,
+				UID:       types.UID(fmt.Sprintf("pool-uid-%d", i)),
+			},
+			Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+				Replicas:    new(replicas),
+				TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: template.Name},
+			},
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, pools)
+	for i, warmPool := range warmPools {
+		wg.Go(func() {
+			// Reconcile each pool repeatedly, as requeues would; later
+			// passes must see the Sandboxes the first one created.
+			for range 3 {
+				if err := r.reconcilePool(ctx, warmPool); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	for i, err := range errs {
+		require.NoError(t, err, "reconcile pool-%d", i)
+	}
+
+	for _, warmPool := range warmPools {
+		list := &sandboxv1beta1.SandboxList{}
+		require.NoError(t, r.List(ctx, list, client.InNamespace(poolNamespace),
+			client.MatchingLabels{warmPoolSandboxLabel: naming.NameHash(warmPool.Name)}))
+		require.Len(t, list.Items, int(replicas), "pool %s", warmPool.Name)
+		for _, sb := range list.Items {
+			require.Len(t, sb.Spec.VolumeClaimTemplates, 1)
+			require.True(t, metav1.IsControlledBy(&sb, warmPool), "sandbox %s is not controlled by %s", sb.Name, warmPool.Name)
+		}
+	}
+}
+
 func TestCreatePoolSandboxAppliesSecureDefaults(t *testing.T) {
 	poolName := "test-pool"
 	poolNamespace := "default"
@@ -690,6 +773,282 @@ func TestCreatePoolSandboxAppliesSecureDefaults(t *testing.T) {
 	}
 }
 
+func TestCreatePoolSandboxInjectsTopologySpreadConstraints(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	templateName := "test-template"
+	replicas := int32(1)
+	ctx := context.Background()
+	scheme := newTestScheme()
+
+	poolConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelTopologyZone,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}
+	templateConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           2,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "custom"}},
+	}
+	poolSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{warmPoolSandboxLabel: naming.NameHash(poolName)},
+	}
+	customSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "sandbox"}}
+
+	tests := []struct {
+		name                string
+		poolConstraints     []corev1.TopologySpreadConstraint
+		templateConstraints []corev1.TopologySpreadConstraint
+		want                []corev1.TopologySpreadConstraint
+	}{
+		{
+			name: "no constraints anywhere",
+		},
+		{
+			name:            "pool constraint is scoped to the pool's pods",
+			poolConstraints: []corev1.TopologySpreadConstraint{poolConstraint},
+			want: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: poolSelector},
+			},
+		},
+		{
+			name: "pool constraint keeps an explicit label selector",
+			poolConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: customSelector},
+			},
+			want: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: customSelector},
+			},
+		},
+		{
+			name:                "template constraints take precedence",
+			poolConstraints:     []corev1.TopologySpreadConstraint{poolConstraint},
+			templateConstraints: []corev1.TopologySpreadConstraint{templateConstraint},
+			want:                []corev1.TopologySpreadConstraint{templateConstraint},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &extensionsv1beta1.SandboxTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateName, Namespace: poolNamespace},
+				Spec: extensionsv1beta1.SandboxTemplateSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{
+						Containers:                []corev1.Container{{Name: "app", Image: "test-image"}},
+						TopologySpreadConstraints: tt.templateConstraints,
+					},
+				}}},
+			}
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName, Namespace: poolNamespace, UID: "warmpool-uid-topology"},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					Replicas:                  &replicas,
+					TemplateRef:               extensionsv1beta1.SandboxTemplateRef{Name: templateName},
+					TopologySpreadConstraints: tt.poolConstraints,
+				},
+			}
+
+			r := SandboxWarmPoolReconciler{
+				Client:       newFakeClient(scheme, template),
+				Scheme:       scheme,
+				MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+			}
+			require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+			list := &sandboxv1beta1.SandboxList{}
+			require.NoError(t, r.List(ctx, list, &client.ListOptions{Namespace: poolNamespace}))
+			require.Len(t, list.Items, 1)
+			require.Equal(t, tt.want, list.Items[0].Spec.PodTemplate.Spec.TopologySpreadConstraints)
+
+			// A pool sandbox built with injected constraints is not stale.
+			require.True(t, r.compareSandboxBlueprint(template, &list.Items[0].Spec.SandboxBlueprint))
+		})
+	}
+}
+
+func TestCreatePoolSandboxInjectsPodAntiAffinity(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	templateName := "test-template"
+	replicas := int32(1)
+	ctx := context.Background()
+	scheme := newTestScheme()
+
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"sandboxes"}},
+				},
+			}},
+		},
+	}
+	templateAntiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+			{TopologyKey: corev1.LabelTopologyZone},
+		},
+	}
+	poolAntiAffinity := &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{warmPoolSandboxLabel: naming.NameHash(poolName)},
+				},
+				TopologyKey: corev1.LabelHostname,
+			},
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		antiAffinity     bool
+		templateAffinity *corev1.Affinity
+		want             *corev1.Affinity
+	}{
+		{
+			name: "disabled by default",
+		},
+		{
+			name:         "injected when the template has no affinity",
+			antiAffinity: true,
+			want:         &corev1.Affinity{PodAntiAffinity: poolAntiAffinity},
+		},
+		{
+			name:             "injected alongside the template's node affinity",
+			antiAffinity:     true,
+			templateAffinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+			want:             &corev1.Affinity{NodeAffinity: nodeAffinity, PodAntiAffinity: poolAntiAffinity},
+		},
+		{
+			name:             "template anti-affinity takes precedence",
+			antiAffinity:     true,
+			templateAffinity: &corev1.Affinity{PodAntiAffinity: templateAntiAffinity},
+			want:             &corev1.Affinity{PodAntiAffinity: templateAntiAffinity},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &extensionsv1beta1.SandboxTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateName, Namespace: poolNamespace},
+				Spec: extensionsv1beta1.SandboxTemplateSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "test-image"}},
+						Affinity:   tt.templateAffinity.DeepCopy(),
+					},
+				}}},
+			}
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName, Namespace: poolNamespace, UID: "warmpool-uid-anti-affinity"},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					Replicas:     &replicas,
+					TemplateRef:  extensionsv1beta1.SandboxTemplateRef{Name: templateName},
+					AntiAffinity: tt.antiAffinity,
+				},
+			}
+
+			r := SandboxWarmPoolReconciler{
+				Client:       newFakeClient(scheme, template),
+				Scheme:       scheme,
+				MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+			}
+			require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+			list := &sandboxv1beta1.SandboxList{}
+			require.NoError(t, r.List(ctx, list, &client.ListOptions{Namespace: poolNamespace}))
+			require.Len(t, list.Items, 1)
+			require.Equal(t, tt.want, list.Items[0].Spec.PodTemplate.Spec.Affinity)
+
+			// A pool sandbox built with an injected anti-affinity is not stale.
+			require.True(t, r.compareSandboxBlueprint(template, &list.Items[0].Spec.SandboxBlueprint))
+		})
+	}
+}
+
+func TestCreatePoolSandboxInjectsDefaultReadinessProbe(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	templateName := "test-template"
+	replicas := int32(1)
+	ctx := context.Background()
+	scheme := newTestScheme()
+
+	defaultProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8888)}},
+	}
+	customProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}},
+	}
+
+	tests := []struct {
+		name          string
+		defaultProbe  *corev1.Probe
+		templateProbe *corev1.Probe
+		want          *corev1.Probe
+	}{
+		{
+			name: "no probes anywhere",
+		},
+		{
+			name:         "default probe is injected",
+			defaultProbe: defaultProbe,
+			want:         defaultProbe,
+		},
+		{
+			name:          "container probe takes precedence",
+			defaultProbe:  defaultProbe,
+			templateProbe: customProbe,
+			want:          customProbe,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &extensionsv1beta1.SandboxTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateName, Namespace: poolNamespace},
+				Spec: extensionsv1beta1.SandboxTemplateSpec{
+					SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "app", Image: "test-image", ReadinessProbe: tt.templateProbe},
+								{Name: "sidecar", Image: "sidecar-image"},
+							},
+						},
+					}},
+					DefaultReadinessProbe: tt.defaultProbe,
+				},
+			}
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName, Namespace: poolNamespace, UID: "warmpool-uid-probe"},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					Replicas:    &replicas,
+					TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: templateName},
+				},
+			}
+
+			r := SandboxWarmPoolReconciler{
+				Client:       newFakeClient(scheme, template),
+				Scheme:       scheme,
+				MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+			}
+			require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+			list := &sandboxv1beta1.SandboxList{}
+			require.NoError(t, r.List(ctx, list, &client.ListOptions{Namespace: poolNamespace}))
+			require.Len(t, list.Items, 1)
+			containers := list.Items[0].Spec.PodTemplate.Spec.Containers
+			require.Equal(t, tt.want, containers[0].ReadinessProbe)
+			require.Nil(t, containers[1].ReadinessProbe)
+
+			// A pool sandbox built with an injected probe is not stale.
+			require.True(t, r.compareSandboxBlueprint(template, &list.Items[0].Spec.SandboxBlueprint))
+		})
+	}
+}
+
 func TestReconcilePoolReadyReplicas(t *testing.T) {
 	poolName := "test-pool"
 	poolNamespace := "default"
@@ -713,7 +1072,7 @@ func TestReconcilePoolReadyReplicas(t *testing.T) {
 		},
 	}
 
-	poolNameHash := sandboxcontrollers.NameHash(poolName)
+	poolNameHash := naming.NameHash(poolName)
 
 	createSandboxWithReadyCondition := func(suffix string, ready metav1.ConditionStatus) *sandboxv1beta1.Sandbox {
 		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
@@ -742,52 +1101,468 @@ func TestReconcilePoolReadyReplicas(t *testing.T) {
 			expectedReadyReplicas: 0,
 		},
 		{
-			name: "some sandboxes ready",
-			initialObjs: []runtime.Object{
-				template,
-				createSandboxWithReadyCondition("-abc123", metav1.ConditionTrue),
-				createSandboxWithReadyCondition("-def456", metav1.ConditionFalse),
-				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
-			},
-			expectedReadyReplicas: 2,
+			name: "some sandboxes ready",
+			initialObjs: []runtime.Object{
+				template,
+				createSandboxWithReadyCondition("-abc123", metav1.ConditionTrue),
+				createSandboxWithReadyCondition("-def456", metav1.ConditionFalse),
+				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
+			},
+			expectedReadyReplicas: 2,
+		},
+		{
+			name: "all sandboxes ready",
+			initialObjs: []runtime.Object{
+				template,
+				createSandboxWithReadyCondition("-abc123", metav1.ConditionTrue),
+				createSandboxWithReadyCondition("-def456", metav1.ConditionTrue),
+				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
+			},
+			expectedReadyReplicas: 3,
+		},
+		{
+			name: "sandboxes with no ready condition",
+			initialObjs: []runtime.Object{
+				template,
+				createPoolSandbox(poolName, poolNamespace, poolNameHash, template, "-abc123"),
+				createPoolSandbox(poolName, poolNamespace, poolNameHash, template, "-def456"),
+				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
+			},
+			expectedReadyReplicas: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := SandboxWarmPoolReconciler{
+				Client: newFakeClient(scheme, tc.initialObjs...),
+				Scheme: scheme,
+			}
+
+			ctx := context.Background()
+
+			err := r.reconcilePool(ctx, warmPool)
+			require.NoError(t, err)
+			err = r.reconcilePool(ctx, warmPool)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedReadyReplicas, warmPool.Status.ReadyReplicas)
+		})
+	}
+}
+
+func TestReconcilePoolTemplateNotFound(t *testing.T) {
+	poolNamespace := "default"
+	replicas := int32(2)
+	scheme := newTestScheme()
+	ctx := context.Background()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-pool",
+			Namespace:  poolNamespace,
+			UID:        "warmpool-uid-123",
+			Generation: 1,
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas: &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{
+				Name: "test-template",
+			},
+		},
+	}
+
+	c := newFakeClient(scheme)
+	r := SandboxWarmPoolReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		MaxBatchSize: 10,
+	}
+
+	// A missing template is not retried as an error; the template watch requeues the pool.
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+	sandboxList := &sandboxv1beta1.SandboxList{}
+	require.NoError(t, c.List(ctx, sandboxList, client.InNamespace(poolNamespace)))
+	require.Empty(t, sandboxList.Items)
+
+	cond := meta.FindStatusCondition(warmPool.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, "TemplateNotFound", cond.Reason)
+	require.Equal(t, `SandboxTemplate "test-template" not found`, cond.Message)
+	require.Equal(t, int64(1), cond.ObservedGeneration)
+	cond = meta.FindStatusCondition(warmPool.Status.Conditions, extensionsv1beta1.SandboxWarmPoolConditionCurrent)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, "TemplateUnavailable", cond.Reason)
+
+	// Once the template exists the pool replenishes and the reason clears.
+	require.NoError(t, c.Create(ctx, createTemplate(poolNamespace)))
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+	require.NoError(t, c.List(ctx, sandboxList, client.InNamespace(poolNamespace)))
+	require.Len(t, sandboxList.Items, int(replicas))
+
+	cond = meta.FindStatusCondition(warmPool.Status.Conditions, string(sandboxv1beta1.SandboxConditionReady))
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, "Replenishing", cond.Reason)
+}
+
+func TestReconcilePoolProgressConditions(t *testing.T) {
+	poolNamespace := "default"
+	replicas := int32(2)
+	scheme := newTestScheme()
+	ctx := context.Background()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: poolNamespace, UID: "warmpool-uid-123", Generation: 1},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+		},
+	}
+	c := newFakeClient(scheme, createTemplate(poolNamespace))
+	r := SandboxWarmPoolReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		MaxBatchSize: 10,
+	}
+
+	requireCondition := func(condType string, status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		cond := meta.FindStatusCondition(warmPool.Status.Conditions, condType)
+		require.NotNil(t, cond, "condition %s", condType)
+		require.Equal(t, status, cond.Status, "condition %s", condType)
+		require.Equal(t, reason, cond.Reason, "condition %s", condType)
+		require.Equal(t, int64(1), cond.ObservedGeneration, "condition %s", condType)
+	}
+	setSandboxStatus := func(mutate func(*sandboxv1beta1.Sandbox)) {
+		t.Helper()
+		sandboxList := &sandboxv1beta1.SandboxList{}
+		require.NoError(t, c.List(ctx, sandboxList, client.InNamespace(poolNamespace)))
+		require.Len(t, sandboxList.Items, int(replicas))
+		for i := range sandboxList.Items {
+			mutate(&sandboxList.Items[i])
+			require.NoError(t, c.Update(ctx, &sandboxList.Items[i]))
+		}
+	}
+
+	// The first reconcile sees an empty pool and scales it up.
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionInProgress, metav1.ConditionTrue, "Scaling")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionCurrent, metav1.ConditionFalse, "Scaling")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionDegraded, metav1.ConditionFalse, "NoCrashingSandboxes")
+
+	// All sandboxes exist but none is Ready yet.
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionInProgress, metav1.ConditionTrue, "WaitingForReady")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionCurrent, metav1.ConditionFalse, "WaitingForReady")
+
+	// Crash-looping pods mark the pool Degraded while it keeps waiting.
+	setSandboxStatus(func(sb *sandboxv1beta1.Sandbox) {
+		sb.Status.RestartCount = 3
+	})
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionInProgress, metav1.ConditionTrue, "WaitingForReady")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionDegraded, metav1.ConditionTrue, "SandboxesCrashing")
+
+	// Once every sandbox is Ready the pool is current and no longer degraded.
+	setSandboxStatus(func(sb *sandboxv1beta1.Sandbox) {
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             metav1.ConditionTrue,
+			Reason:             sandboxv1beta1.SandboxReasonDependenciesReady,
+			LastTransitionTime: metav1.Now(),
+		}}
+	})
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionInProgress, metav1.ConditionFalse, "PoolReady")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionCurrent, metav1.ConditionTrue, "PoolReady")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionDegraded, metav1.ConditionFalse, "NoCrashingSandboxes")
+
+	// Scaling up again puts the pool back in progress.
+	replicas = 3
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionInProgress, metav1.ConditionTrue, "Scaling")
+	requireCondition(extensionsv1beta1.SandboxWarmPoolConditionCurrent, metav1.ConditionFalse, "Scaling")
+}
+
+func TestReconcilePoolCapsCreatesPerReconcile(t *testing.T) {
+	poolNamespace := "default"
+	replicas := int32(7)
+	scheme := newTestScheme()
+	ctx := context.Background()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: poolNamespace, UID: "warmpool-uid-123"},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+		},
+	}
+
+	c := newFakeClient(scheme, createTemplate(poolNamespace))
+	r := SandboxWarmPoolReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		MaxBatchSize: 3,
+	}
+
+	// Each reconcile creates at most MaxBatchSize sandboxes until the pool is full.
+	for _, want := range []int{3, 6, 7, 7} {
+		require.NoError(t, r.reconcilePool(ctx, warmPool))
+		sandboxList := &sandboxv1beta1.SandboxList{}
+		require.NoError(t, c.List(ctx, sandboxList, client.InNamespace(poolNamespace)))
+		require.Len(t, sandboxList.Items, want)
+	}
+}
+
+func TestWarmPoolRateLimiter(t *testing.T) {
+	limiter := newWarmPoolRateLimiter()
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-pool"}}
+
+	// Failures back off exponentially from the base delay, each stretched by up to 2x.
+	for i := range 4 {
+		base := warmPoolRetryBaseDelay << i
+		delay := limiter.When(item)
+		require.GreaterOrEqual(t, delay, base, "retry %d", i)
+		require.LessOrEqual(t, delay, 2*base, "retry %d", i)
+	}
+	require.Equal(t, 4, limiter.NumRequeues(item))
+
+	// A successful reconcile resets the backoff.
+	limiter.Forget(item)
+	require.LessOrEqual(t, limiter.When(item), 2*warmPoolRetryBaseDelay)
+}
+
+func TestReconcilePoolRecordsWarmupLatency(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	replicas := int32(2)
+
+	template := createTemplate(poolNamespace)
+	scheme := newTestScheme()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+			UID:       "warmpool-uid-123",
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+		},
+	}
+
+	poolNameHash := naming.NameHash(poolName)
+	created := metav1.NewTime(time.Now().Add(-time.Minute))
+	newSandbox := func(suffix string, ready metav1.ConditionStatus) *sandboxv1beta1.Sandbox {
+		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
+		sb.CreationTimestamp = created
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             ready,
+			LastTransitionTime: metav1.NewTime(created.Add(3 * time.Second)),
+		}}
+		return sb
+	}
+	readySandbox := newSandbox("-ready", metav1.ConditionTrue)
+	notReadySandbox := newSandbox("-notready", metav1.ConditionFalse)
+
+	fc := newFakeClient(scheme, template, readySandbox, notReadySandbox)
+	r := SandboxWarmPoolReconciler{
+		Client: fc,
+		Scheme: scheme,
+	}
+
+	asmetrics.WarmPoolWarmupLatency.Reset()
+	ctx := context.Background()
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+	metric := &dto.Metric{}
+	require.NoError(t, asmetrics.WarmPoolWarmupLatency.WithLabelValues(poolNamespace, poolName).(prometheus.Metric).Write(metric))
+	require.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount(), "warmup latency must be recorded once per ready sandbox")
+	require.Equal(t, float64(3000), metric.GetHistogram().GetSampleSum())
+
+	got := &sandboxv1beta1.Sandbox{}
+	require.NoError(t, fc.Get(ctx, types.NamespacedName{Name: readySandbox.Name, Namespace: poolNamespace}, got))
+	require.Equal(t, "true", got.Annotations[asmetrics.ReadinessObservedAnnotation])
+	require.NoError(t, fc.Get(ctx, types.NamespacedName{Name: notReadySandbox.Name, Namespace: poolNamespace}, got))
+	require.NotContains(t, got.Annotations, asmetrics.ReadinessObservedAnnotation)
+}
+
+func TestReconcileCountsAvailableReplicas(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	replicas := int32(3)
+
+	template := createTemplate(poolNamespace)
+	scheme := newTestScheme()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+			UID:       "warmpool-uid-123",
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:        &replicas,
+			TemplateRef:     extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+			MinReadySeconds: 30,
+		},
+	}
+
+	poolNameHash := naming.NameHash(poolName)
+	newSandbox := func(suffix string, ready metav1.ConditionStatus, readySince time.Duration) *sandboxv1beta1.Sandbox {
+		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
+		sb.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             ready,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-readySince)),
+		}}
+		return sb
+	}
+
+	fc := newFakeClient(scheme, template, warmPool,
+		newSandbox("-stable", metav1.ConditionTrue, time.Minute),
+		newSandbox("-flapping", metav1.ConditionTrue, 5*time.Second),
+		newSandbox("-notready", metav1.ConditionFalse, time.Minute),
+	)
+	r := SandboxWarmPoolReconciler{
+		Client:       fc,
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: poolName, Namespace: poolNamespace}}
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	// The flapping sandbox becomes available without another event, so check back.
+	require.Equal(t, 30*time.Second, result.RequeueAfter)
+
+	got := &extensionsv1beta1.SandboxWarmPool{}
+	require.NoError(t, fc.Get(ctx, req.NamespacedName, got))
+	require.Equal(t, int32(2), got.Status.ReadyReplicas)
+	require.Equal(t, int32(1), got.Status.AvailableReplicas)
+
+	// Without minReadySeconds every Ready sandbox is available.
+	got.Spec.MinReadySeconds = 0
+	require.NoError(t, fc.Update(ctx, got))
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	require.NoError(t, fc.Get(ctx, req.NamespacedName, got))
+	require.Equal(t, int32(2), got.Status.AvailableReplicas)
+}
+
+func TestReconcilePoolMinAvailable(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+
+	newClaim := func(name, sandboxName, readyReason string) *extensionsv1beta1.SandboxClaim {
+		claim := &extensionsv1beta1.SandboxClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: poolNamespace},
+			Spec: extensionsv1beta1.SandboxClaimSpec{
+				WarmPoolRef: extensionsv1beta1.SandboxWarmPoolRef{Name: poolName},
+			},
+		}
+		claim.Status.SandboxStatus.Name = sandboxName
+		if readyReason != "" {
+			claim.Status.Conditions = []metav1.Condition{{
+				Type:   string(sandboxv1beta1.SandboxConditionReady),
+				Status: metav1.ConditionFalse,
+				Reason: readyReason,
+			}}
+		}
+		return claim
+	}
+	claimed := func(n int) []runtime.Object {
+		var objs []runtime.Object
+		for i := range n {
+			objs = append(objs, newClaim(fmt.Sprintf("claim-%d", i), fmt.Sprintf("sandbox-%d", i), ""))
+		}
+		return objs
+	}
+
+	testCases := []struct {
+		name         string
+		replicas     int32
+		minAvailable *int32
+		claims       []runtime.Object
+		wantSpares   int
+	}{
+		{
+			name:       "without minAvailable claims do not resize the pool",
+			replicas:   3,
+			claims:     claimed(2),
+			wantSpares: 3,
+		},
+		{
+			name:         "replicas covers the buffer when nothing is claimed",
+			replicas:     3,
+			minAvailable: ptr.To(int32(2)),
+			wantSpares:   3,
 		},
 		{
-			name: "all sandboxes ready",
-			initialObjs: []runtime.Object{
-				template,
-				createSandboxWithReadyCondition("-abc123", metav1.ConditionTrue),
-				createSandboxWithReadyCondition("-def456", metav1.ConditionTrue),
-				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
-			},
-			expectedReadyReplicas: 3,
+			name:         "claimed sandboxes count toward replicas",
+			replicas:     3,
+			minAvailable: ptr.To(int32(1)),
+			claims:       claimed(1),
+			wantSpares:   2,
 		},
 		{
-			name: "sandboxes with no ready condition",
-			initialObjs: []runtime.Object{
-				template,
-				createPoolSandbox(poolName, poolNamespace, poolNameHash, template, "-abc123"),
-				createPoolSandbox(poolName, poolNamespace, poolNameHash, template, "-def456"),
-				createSandboxWithReadyCondition("-ghi789", metav1.ConditionTrue),
-			},
-			expectedReadyReplicas: 1,
+			name:         "grows past replicas to keep the buffer",
+			replicas:     3,
+			minAvailable: ptr.To(int32(2)),
+			claims:       claimed(4),
+			wantSpares:   2,
+		},
+		{
+			name:         "ignores claims without a sandbox and expired claims",
+			replicas:     1,
+			minAvailable: ptr.To(int32(2)),
+			claims: append(claimed(1),
+				newClaim("claim-pending", "", ""),
+				newClaim("claim-expired", "sandbox-expired", extensionsv1beta1.ClaimExpiredReason),
+			),
+			wantSpares: 2,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			template := createTemplate(poolNamespace)
+			scheme := newTestScheme()
+			warmPool := &extensionsv1beta1.SandboxWarmPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      poolName,
+					Namespace: poolNamespace,
+					UID:       "warmpool-uid-123",
+				},
+				Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+					Replicas:     ptr.To(tc.replicas),
+					MinAvailable: tc.minAvailable,
+					TemplateRef:  extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+				},
+			}
 			r := SandboxWarmPoolReconciler{
-				Client: newFakeClient(scheme, tc.initialObjs...),
-				Scheme: scheme,
+				Client:       newFakeClient(scheme, append(tc.claims, template, warmPool)...),
+				Scheme:       scheme,
+				MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
 			}
 
 			ctx := context.Background()
+			require.NoError(t, r.reconcilePool(ctx, warmPool))
 
-			err := r.reconcilePool(ctx, warmPool)
-			require.NoError(t, err)
-			err = r.reconcilePool(ctx, warmPool)
-			require.NoError(t, err)
-
-			require.Equal(t, tc.expectedReadyReplicas, warmPool.Status.ReadyReplicas)
+			sandboxes := &sandboxv1beta1.SandboxList{}
+			require.NoError(t, r.List(ctx, sandboxes, client.InNamespace(poolNamespace)))
+			require.Len(t, sandboxes.Items, tc.wantSpares)
 		})
 	}
 }
@@ -850,7 +1625,7 @@ func TestReconcilePoolGCStuckSandboxes(t *testing.T) {
 		},
 	}
 
-	poolNameHash := sandboxcontrollers.NameHash(poolName)
+	poolNameHash := naming.NameHash(poolName)
 
 	createSandboxWithAge := func(suffix string, ready metav1.ConditionStatus, age time.Duration) *sandboxv1beta1.Sandbox {
 		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
@@ -925,6 +1700,436 @@ func TestReconcilePoolGCStuckSandboxes(t *testing.T) {
 	})
 }
 
+func TestReconcilePoolDrainedSandbox(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	replicas := int32(2)
+
+	template := createTemplate(poolNamespace)
+	scheme := newTestScheme()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+		},
+	}
+
+	poolNameHash := naming.NameHash(poolName)
+	newReadySandbox := func(suffix string) *sandboxv1beta1.Sandbox {
+		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
+		sb.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:   string(sandboxv1beta1.SandboxConditionReady),
+			Status: metav1.ConditionTrue,
+		}}
+		return sb
+	}
+	newPod := func(sb *sandboxv1beta1.Sandbox, drained bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sb.Name,
+				Namespace: sb.Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: sandboxv1beta1.GroupVersion.String(),
+					Kind:       "Sandbox",
+					Name:       sb.Name,
+					Controller: ptr.To(true),
+				}},
+			},
+		}
+		if drained {
+			pod.Annotations = map[string]string{extensionsv1beta1.WarmPoolDrainAnnotation: "true"}
+		}
+		return pod
+	}
+
+	drained := newReadySandbox("-drained")
+	kept := newReadySandbox("-kept")
+	// A sandbox adopted by a claim has left the pool and is not drained by it.
+	claimed := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{Name: "claimed", Namespace: poolNamespace},
+	}
+
+	r := SandboxWarmPoolReconciler{
+		Client: newFakeClient(scheme, template, drained, kept, claimed,
+			newPod(drained, true), newPod(kept, false), newPod(claimed, true)),
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+
+	ctx := context.Background()
+	require.NoError(t, r.reconcilePool(ctx, warmPool))
+
+	// The drained sandbox is not counted and is replaced.
+	require.Equal(t, int32(1), warmPool.Status.AvailableReplicas)
+	list := &sandboxv1beta1.SandboxList{}
+	require.NoError(t, r.List(ctx, list, client.InNamespace(poolNamespace)))
+	var poolNames []string
+	for _, sb := range list.Items {
+		if sb.Labels[warmPoolSandboxLabel] == poolNameHash {
+			poolNames = append(poolNames, sb.Name)
+		}
+	}
+	require.Len(t, poolNames, int(replicas))
+	require.Contains(t, poolNames, kept.Name)
+	require.NotContains(t, poolNames, drained.Name)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "claimed", Namespace: poolNamespace}, &sandboxv1beta1.Sandbox{}))
+}
+
+func TestFindWarmPoolForDrainedPod(t *testing.T) {
+	scheme := newTestScheme()
+	sb := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool-sandbox",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: extensionsv1beta1.GroupVersion.String(),
+				Kind:       extensionsv1beta1.SandboxWarmPoolKind,
+				Name:       "test-pool",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	r := SandboxWarmPoolReconciler{Client: newFakeClient(scheme, sb), Scheme: scheme}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool-sandbox",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: sandboxv1beta1.GroupVersion.String(),
+				Kind:       "Sandbox",
+				Name:       "pool-sandbox",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+
+	require.Empty(t, r.findWarmPoolForDrainedPod(context.Background(), pod))
+
+	pod.Annotations = map[string]string{extensionsv1beta1.WarmPoolDrainAnnotation: "true"}
+	require.Equal(t, []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: "test-pool", Namespace: "default"},
+	}}, r.findWarmPoolForDrainedPod(context.Background(), pod))
+}
+
+func TestReconcilePoolRotatesExpiredSandboxes(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	replicas := int32(3)
+
+	template := createTemplate(poolNamespace)
+	scheme := newTestScheme()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:        &replicas,
+			TemplateRef:     extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+			MinReadySeconds: 30,
+			MaxPodAge:       &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	poolNameHash := naming.NameHash(poolName)
+	newSandbox := func(suffix string, age, readySince time.Duration) *sandboxv1beta1.Sandbox {
+		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, suffix)
+		sb.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-readySince)),
+		}}
+		return sb
+	}
+
+	old := newSandbox("-old", 2*time.Hour, 2*time.Hour)
+	// Past maxPodAge but Ready only recently, so not available and not rotated yet.
+	oldFlapping := newSandbox("-old-flapping", 2*time.Hour, 5*time.Second)
+	young := newSandbox("-young", 10*time.Minute, 10*time.Minute)
+	// An old sandbox adopted by a claim has left the pool and is left alone.
+	claimed := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "claimed",
+			Namespace:         poolNamespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+	}
+
+	fc := newFakeClient(scheme, template, warmPool, old, oldFlapping, young, claimed)
+	r := SandboxWarmPoolReconciler{
+		Client:       fc,
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: poolName, Namespace: poolNamespace}}
+	poolSandboxes := func() []sandboxv1beta1.Sandbox {
+		list := &sandboxv1beta1.SandboxList{}
+		require.NoError(t, fc.List(ctx, list, client.InNamespace(poolNamespace)))
+		var members []sandboxv1beta1.Sandbox
+		for _, sb := range list.Items {
+			if sb.Labels[warmPoolSandboxLabel] == poolNameHash {
+				members = append(members, sb)
+			}
+		}
+		return members
+	}
+	names := func(sandboxes []sandboxv1beta1.Sandbox) []string {
+		var out []string
+		for _, sb := range sandboxes {
+			out = append(out, sb.Name)
+		}
+		return out
+	}
+
+	// Rotating the old sandbox now would leave fewer ready spares than
+	// replicas, so a replacement is surged first and the rotation retried.
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, warmPoolRotationRequeueDelay, result.RequeueAfter)
+	members := poolSandboxes()
+	require.Len(t, members, int(replicas)+1)
+	require.Contains(t, names(members), old.Name)
+
+	// Once the replacement is Ready, the old sandbox is rotated out.
+	for i := range members {
+		if !isSandboxReady(&members[i]) {
+			members[i].Status.Conditions = []metav1.Condition{{
+				Type:               string(sandboxv1beta1.SandboxConditionReady),
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.Now(),
+			}}
+			require.NoError(t, fc.Update(ctx, &members[i]))
+		}
+	}
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	// The flapping sandbox becomes available first; the young one expires later.
+	require.Equal(t, 30*time.Second, result.RequeueAfter)
+
+	poolNames := names(poolSandboxes())
+	require.Len(t, poolNames, int(replicas))
+	require.NotContains(t, poolNames, old.Name)
+	require.Contains(t, poolNames, oldFlapping.Name)
+	require.Contains(t, poolNames, young.Name)
+	require.NoError(t, fc.Get(ctx, types.NamespacedName{Name: "claimed", Namespace: poolNamespace}, &sandboxv1beta1.Sandbox{}))
+
+	// Once nothing waits on minReadySeconds or rotation, the next expiry drives
+	// the requeue.
+	require.NoError(t, fc.Delete(ctx, oldFlapping))
+	got := &extensionsv1beta1.SandboxWarmPool{}
+	require.NoError(t, fc.Get(ctx, req.NamespacedName, got))
+	got.Spec.MinReadySeconds = 0
+	require.NoError(t, fc.Update(ctx, got))
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.InDelta(t, (50 * time.Minute).Seconds(), result.RequeueAfter.Seconds(), 5)
+}
+
+func TestReconcilePoolRotationKeepsSpares(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	replicas := int32(3)
+
+	template := createTemplate(poolNamespace)
+	scheme := newTestScheme()
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    &replicas,
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+			MaxPodAge:   &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	// The whole pool was created together and has reached maxPodAge at once.
+	poolNameHash := naming.NameHash(poolName)
+	objs := []runtime.Object{template, warmPool}
+	var expired []string
+	for i := range replicas {
+		sb := createPoolSandbox(poolName, poolNamespace, poolNameHash, template, fmt.Sprintf("-expired-%d", i))
+		sb.CreationTimestamp = metav1.NewTime(time.Now().Add(-2*time.Hour + time.Duration(i)*time.Minute))
+		sb.Status.Conditions = []metav1.Condition{{
+			Type:               string(sandboxv1beta1.SandboxConditionReady),
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		}}
+		objs = append(objs, sb)
+		expired = append(expired, sb.Name)
+	}
+
+	fc := newFakeClient(scheme, objs...)
+	r := SandboxWarmPoolReconciler{
+		Client:       fc,
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: poolName, Namespace: poolNamespace}}
+
+	remaining := len(expired)
+	for pass := 0; remaining > 0; pass++ {
+		require.Less(t, pass, 10, "expired sandboxes were never all rotated")
+		_, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		list := &sandboxv1beta1.SandboxList{}
+		require.NoError(t, fc.List(ctx, list, client.InNamespace(poolNamespace)))
+		ready := 0
+		remaining = 0
+		for i := range list.Items {
+			sb := &list.Items[i]
+			if slices.Contains(expired, sb.Name) {
+				remaining++
+			}
+			if isSandboxReady(sb) {
+				ready++
+			} else {
+				// Replacements become Ready before the next pass.
+				sb.Status.Conditions = []metav1.Condition{{
+					Type:               string(sandboxv1beta1.SandboxConditionReady),
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+				}}
+				require.NoError(t, fc.Update(ctx, sb))
+			}
+		}
+		require.GreaterOrEqual(t, ready, int(replicas), "pass %d left fewer ready spares than replicas", pass)
+		require.GreaterOrEqual(t, remaining, len(expired)-pass, "pass %d rotated more than one sandbox", pass)
+	}
+	list := &sandboxv1beta1.SandboxList{}
+	require.NoError(t, fc.List(ctx, list, client.InNamespace(poolNamespace)))
+	require.Len(t, list.Items, int(replicas))
+}
+
+func TestReconcilePrePullDaemonSet(t *testing.T) {
+	poolName := "test-pool"
+	poolNamespace := "default"
+	scheme := newTestScheme()
+
+	template := createTemplate(poolNamespace)
+	template.Spec.PodTemplate.Spec.InitContainers = []corev1.Container{{Name: "setup", Image: "agent:1"}}
+	template.Spec.PodTemplate.Spec.Containers = []corev1.Container{
+		{Name: "agent", Image: "agent:1"},
+		{Name: "sidecar", Image: "sidecar:1"},
+	}
+	template.Spec.PodTemplate.Spec.NodeSelector = map[string]string{"pool": "sandboxes"}
+	template.Spec.PodTemplate.Spec.RuntimeClassName = new("gvisor")
+	template.Spec.PodTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: new(int64(2000))}
+
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolName,
+			Namespace: poolNamespace,
+			UID:       "warmpool-uid-123",
+		},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    ptr.To(int32(0)),
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+			PrePull:     true,
+		},
+	}
+
+	fc := newFakeClient(scheme, template, warmPool)
+	r := SandboxWarmPoolReconciler{
+		Client:       fc,
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: poolName, Namespace: poolNamespace}}
+	dsKey := types.NamespacedName{Name: poolName + "-prepull", Namespace: poolNamespace}
+	images := func(ds *appsv1.DaemonSet) []string {
+		var out []string
+		for _, v := range ds.Spec.Template.Spec.Volumes {
+			out = append(out, v.Image.Reference)
+		}
+		return out
+	}
+
+	// The DaemonSet is created with one image volume per distinct image.
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	ds := &appsv1.DaemonSet{}
+	require.NoError(t, fc.Get(ctx, dsKey, ds))
+	pool := &extensionsv1beta1.SandboxWarmPool{}
+	require.NoError(t, fc.Get(ctx, req.NamespacedName, pool))
+	require.True(t, metav1.IsControlledBy(ds, pool))
+	require.Equal(t, []string{"agent:1", "sidecar:1"}, images(ds))
+	require.Equal(t, template.Spec.PodTemplate.Spec.NodeSelector, ds.Spec.Template.Spec.NodeSelector)
+	require.Equal(t, ds.Spec.Selector.MatchLabels, ds.Spec.Template.Labels)
+	// The pod runs under the template's isolation, and only the pause container
+	// runs: no template image is executed.
+	require.Equal(t, template.Spec.PodTemplate.Spec.RuntimeClassName, ds.Spec.Template.Spec.RuntimeClassName)
+	require.Equal(t, template.Spec.PodTemplate.Spec.SecurityContext, ds.Spec.Template.Spec.SecurityContext)
+	require.Empty(t, ds.Spec.Template.Spec.InitContainers)
+	require.Len(t, ds.Spec.Template.Spec.Containers, 1)
+	pause := ds.Spec.Template.Spec.Containers[0]
+	require.Equal(t, prePullPauseImage, pause.Image)
+	require.Len(t, pause.VolumeMounts, 2)
+	require.Equal(t, new(false), pause.SecurityContext.AllowPrivilegeEscalation)
+	require.Equal(t, []corev1.Capability{"ALL"}, pause.SecurityContext.Capabilities.Drop)
+
+	// A template image change rolls the DaemonSet.
+	require.NoError(t, fc.Get(ctx, types.NamespacedName{Name: template.Name, Namespace: poolNamespace}, template))
+	template.Spec.PodTemplate.Spec.Containers[1].Image = "sidecar:2"
+	require.NoError(t, fc.Update(ctx, template))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, fc.Get(ctx, dsKey, ds))
+	require.Equal(t, []string{"agent:1", "sidecar:2"}, images(ds))
+
+	// Turning prePull off removes the DaemonSet.
+	pool.Spec.PrePull = false
+	require.NoError(t, fc.Update(ctx, pool))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.True(t, k8serrors.IsNotFound(fc.Get(ctx, dsKey, &appsv1.DaemonSet{})))
+}
+
+func TestReconcilePrePullRefusesUnownedDaemonSet(t *testing.T) {
+	scheme := newTestScheme()
+	template := createTemplate("default")
+	warmPool := &extensionsv1beta1.SandboxWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default", UID: "warmpool-uid-123"},
+		Spec: extensionsv1beta1.SandboxWarmPoolSpec{
+			Replicas:    ptr.To(int32(1)),
+			TemplateRef: extensionsv1beta1.SandboxTemplateRef{Name: "test-template"},
+			PrePull:     true,
+		},
+	}
+	unowned := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "test-pool-prepull", Namespace: "default"}}
+
+	fc := newFakeClient(scheme, template, warmPool, unowned)
+	r := SandboxWarmPoolReconciler{
+		Client:       fc,
+		Scheme:       scheme,
+		MaxBatchSize: sandboxCreateDeleteMaxBatchSize,
+	}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pool", Namespace: "default"}}
+	_, err := r.Reconcile(ctx, req)
+	require.ErrorContains(t, err, "not owned by warm pool")
+
+	// The pool itself is still reconciled and its status written.
+	got := &extensionsv1beta1.SandboxWarmPool{}
+	require.NoError(t, fc.Get(ctx, req.NamespacedName, got))
+	require.NotEmpty(t, got.Status.Conditions)
+}
+
 func TestReconcilePool_TemplateUpdateRollout(t *testing.T) {
 	poolName := "test-pool"
 	poolNamespace := "default"
@@ -1020,6 +2225,7 @@ func TestReconcilePool_TemplateUpdateRollout(t *testing.T) {
 			for _, sb := range sandboxes.Items {
 				require.Equal(t, "image-v1", sb.Spec.PodTemplate.Spec.Containers[0].Image)
 				require.Equal(t, initialHash, sb.Labels[sandboxv1beta1.SandboxTemplateHashLabel], "Sandbox should have initial sandbox blueprint hash label")
+				require.Equal(t, SandboxTemplateRevisionName(template.Name, initialHash), sb.Labels[sandboxv1beta1.SandboxTemplateRevisionLabel], "Sandbox should name the initial template revision")
 			}
 
 			// Update the SandboxTemplate content
@@ -1047,6 +2253,7 @@ func TestReconcilePool_TemplateUpdateRollout(t *testing.T) {
 				for _, sb := range sandboxes.Items {
 					require.Equal(t, "image-v2", sb.Spec.PodTemplate.Spec.Containers[0].Image, "Sandbox should have updated image")
 					require.Equal(t, updatedHash, sb.Labels[sandboxv1beta1.SandboxTemplateHashLabel], "Sandbox should have updated sandbox blueprint hash label")
+					require.Equal(t, SandboxTemplateRevisionName(template.Name, updatedHash), sb.Labels[sandboxv1beta1.SandboxTemplateRevisionLabel], "Sandbox should name the updated template revision")
 				}
 				t.Log("Verified: All sandboxes updated immediately with Recreate strategy")
 			} else {
@@ -1192,7 +2399,7 @@ func TestReconcilePool_TemplateRefUpdate_SameSpec(t *testing.T) {
 	for _, sb := range sandboxes.Items {
 		// Sandboxes should be recreated (new names) because TemplateRef changed
 		require.False(t, initialSandboxNames[sb.Name], "Sandbox should have been recreated with new name")
-		require.Equal(t, sandboxcontrollers.NameHash(templateName2), sb.Labels[sandboxTemplateRefHash], "Sandbox should have updated template ref hash label")
+		require.Equal(t, naming.NameHash(templateName2), sb.Labels[sandboxTemplateRefHash], "Sandbox should have updated template ref hash label")
 		// The pod spec is identical, so the image remains image-v1
 		require.Equal(t, "image-v1", sb.Spec.PodTemplate.Spec.Containers[0].Image, "Sandbox should retain original image since spec is identical")
 	}
@@ -1315,6 +2522,35 @@ func TestComparePodSpecsNormalization(t *testing.T) {
 			secureByDef:    false,
 			expectedResult: false,
 		},
+		{
+			name: "Pool-injected topology spread constraints should match a template without any",
+			templateSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "img"}},
+			},
+			actualSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "img"}},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+				},
+			},
+			secureByDef:    true,
+			expectedResult: true,
+		},
+		{
+			name: "Topology spread constraint drift from the template should NOT match",
+			templateSpec: corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+				},
+			},
+			actualSpec: corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{MaxSkew: 2, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.ScheduleAnyway},
+				},
+			},
+			secureByDef:    true,
+			expectedResult: false,
+		},
 	}
 
 	r := &SandboxWarmPoolReconciler{}
@@ -1471,7 +2707,7 @@ func TestIsSandboxStale_OrphanedSandboxVetting(t *testing.T) {
 			Labels: map[string]string{
 				sandboxv1beta1.SandboxTemplateHashLabel: currentSandboxBlueprintHash,
 				sandboxTemplateRefHash:                  templateRefHash,
-				warmPoolSandboxLabel:                    sandboxcontrollers.NameHash(poolName),
+				warmPoolSandboxLabel:                    naming.NameHash(poolName),
 			},
 		},
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: *spoofedSpec}}},
@@ -1492,7 +2728,7 @@ func TestIsSandboxStale_OrphanedSandboxVetting(t *testing.T) {
 			Labels: map[string]string{
 				sandboxv1beta1.SandboxTemplateHashLabel: currentSandboxBlueprintHash,
 				sandboxTemplateRefHash:                  templateRefHash,
-				warmPoolSandboxLabel:                    sandboxcontrollers.NameHash(poolName),
+				warmPoolSandboxLabel:                    naming.NameHash(poolName),
 			},
 		},
 		Spec: sandboxv1beta1.SandboxSpec{SandboxBlueprint: sandboxv1beta1.SandboxBlueprint{PodTemplate: sandboxv1beta1.PodTemplate{Spec: *genuineSpec}}},
@@ -1942,6 +3178,11 @@ func TestComputeSandboxBlueprintHash(t *testing.T) {
 	svcEnabled := template.DeepCopy()
 	svcEnabled.Spec.Service = new(true)
 
+	withProbe := template.DeepCopy()
+	withProbe.Spec.DefaultReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8888)}},
+	}
+
 	testCases := []struct {
 		name        string
 		template    *extensionsv1beta1.SandboxTemplate
@@ -1967,6 +3208,11 @@ func TestComputeSandboxBlueprintHash(t *testing.T) {
 			template:    svcEnabled,
 			equalToBase: false,
 		},
+		{
+			name:        "default readiness probe produces different hash",
+			template:    withProbe,
+			equalToBase: false,
+		},
 	}
 
 	currentSandboxHash, err := computeSandboxBlueprintHash(template)
@@ -16,8 +16,8 @@ package controllers
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	sandboxcontrollers "sigs.k8s.io/agent-sandbox/controllers"
 	extensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 )
 
 // ApplySandboxSecureDefaults applies the controller's "Secure by Default" logic to a PodSpec.
@@ -47,7 +47,18 @@ func ApplySandboxSecureDefaults(template *extensionsv1beta1.SandboxTemplate, spe
 	}
 }
 
+// ApplyDefaultReadinessProbe injects the template's default readiness probe into
+// the first container of the pod spec, unless that container already has one.
+func ApplyDefaultReadinessProbe(template *extensionsv1beta1.SandboxTemplate, spec *corev1.PodSpec) {
+	if template.Spec.DefaultReadinessProbe == nil || len(spec.Containers) == 0 {
+		return
+	}
+	if spec.Containers[0].ReadinessProbe == nil {
+		spec.Containers[0].ReadinessProbe = template.Spec.DefaultReadinessProbe.DeepCopy()
+	}
+}
+
 // SandboxTemplateRefHash encapsulates the generation of the hash for a sandbox template ref.
 func SandboxTemplateRefHash(templateRefName string) string {
-	return sandboxcontrollers.NameHash(templateRefName)
+	return naming.NameHash(templateRefName)
 }
@@ -8,6 +8,21 @@
 {{- if hasKey .Values.controller "leaderElectionNamespace" }}
 - --leader-election-namespace={{ .Values.controller.leaderElectionNamespace }}
 {{- end }}
+{{- if hasKey .Values.controller "leaderElectionId" }}
+- --leader-election-id={{ .Values.controller.leaderElectionId }}
+{{- end }}
+{{- if hasKey .Values.controller "leaderElectLeaseDuration" }}
+- --leader-elect-lease-duration={{ .Values.controller.leaderElectLeaseDuration }}
+{{- end }}
+{{- if hasKey .Values.controller "leaderElectRenewDeadline" }}
+- --leader-elect-renew-deadline={{ .Values.controller.leaderElectRenewDeadline }}
+{{- end }}
+{{- if hasKey .Values.controller "leaderElectRetryPeriod" }}
+- --leader-elect-retry-period={{ .Values.controller.leaderElectRetryPeriod }}
+{{- end }}
+{{- if hasKey .Values.controller "metricsSecure" }}
+- --metrics-secure={{ .Values.controller.metricsSecure }}
+{{- end }}
 {{- if hasKey .Values.controller "extensions" }}
 - --extensions={{ .Values.controller.extensions }}
 {{- end }}
@@ -32,6 +47,9 @@
 {{- if hasKey .Values.controller "kubeApiBurst" }}
 - --kube-api-burst={{ .Values.controller.kubeApiBurst }}
 {{- end }}
+{{- if hasKey .Values.controller "reconcileTimeout" }}
+- --reconcile-timeout={{ .Values.controller.reconcileTimeout }}
+{{- end }}
 {{- if hasKey .Values.controller "sandboxConcurrentWorkers" }}
 - --sandbox-concurrent-workers={{ .Values.controller.sandboxConcurrentWorkers }}
 {{- end }}
@@ -44,6 +62,9 @@
 {{- if hasKey .Values.controller "sandboxTemplateConcurrentWorkers" }}
 - --sandbox-template-concurrent-workers={{ .Values.controller.sandboxTemplateConcurrentWorkers }}
 {{- end }}
+{{- if hasKey .Values.controller "sandboxClaimSetConcurrentWorkers" }}
+- --sandbox-claim-set-concurrent-workers={{ .Values.controller.sandboxClaimSetConcurrentWorkers }}
+{{- end }}
 {{- if hasKey .Values.controller "sandboxWarmPoolMaxBatchSize" }}
 - --sandbox-warm-pool-max-batch-size={{ .Values.controller.sandboxWarmPoolMaxBatchSize }}
 {{- end }}
@@ -16,6 +16,7 @@
 package metrics
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -37,6 +38,21 @@ const (
 	// CreationLatencyRecordedAnnotation marks a SandboxClaim whose startup/creation latency
 	// has already been recorded, preventing double-recording (e.g. after a suspend/resume).
 	CreationLatencyRecordedAnnotation = "agents.x-k8s.io/creation-latency-recorded"
+
+	// Reasons for SandboxReconcileErrorsTotal, naming the child resource that failed to reconcile.
+	ReconcileErrorReasonPod       = "pod"
+	ReconcileErrorReasonService   = "service"
+	ReconcileErrorReasonPVC       = "pvc"
+	ReconcileErrorReasonConfigMap = "configmap"
+	ReconcileErrorReasonSecret    = "secret"
+
+	// Results for ClaimBindTotal.
+	ClaimBindResultSuccess = "success"
+	ClaimBindResultError   = "error"
+
+	// ReadinessObservedAnnotation marks a warm pool Sandbox whose warmup latency has
+	// already been recorded, preventing double-recording on re-reconcile.
+	ReadinessObservedAnnotation = "agents.x-k8s.io/readiness-observed"
 )
 
 var (
@@ -83,6 +99,41 @@ var (
 		[]string{"namespace", "launch_type", "sandbox_template"},
 	)
 
+	// WarmPoolWarmupLatency measures the time from warm pool Sandbox creation to Sandbox Ready state.
+	// Labels:
+	// - namespace: the namespace of the warm pool
+	// - warmpool_name: the name of the SandboxWarmPool.
+	WarmPoolWarmupLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "agent_sandbox_warmpool_warmup_latency_ms",
+			Help: "Latency from warm pool Sandbox creation to Sandbox Ready state in milliseconds.",
+			// Buckets for latency from 50ms to 10 minutes
+			Buckets: []float64{50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 120000, 240000, 300000, 600000},
+		},
+		[]string{"namespace", "warmpool_name"},
+	)
+
+	// SandboxReconcileDuration measures the time spent reconciling a Sandbox's child resources.
+	SandboxReconcileDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "agent_sandbox_reconcile_duration_seconds",
+			Help:    "Time spent reconciling a Sandbox's PVCs, ConfigMaps, Secrets, Pod and Service, and resolving its pod FQDN, in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	// SandboxReconcileErrorsTotal counts failures to reconcile a Sandbox's child resources.
+	// Labels:
+	// - reason: the child resource that failed to reconcile: "pod", "service", "pvc",
+	//   "configmap", "secret".
+	SandboxReconcileErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_sandbox_reconcile_errors_total",
+			Help: "Total number of failures to reconcile a Sandbox's child resources, labeled by the failing resource.",
+		},
+		[]string{"reason"},
+	)
+
 	// SandboxClaimCreationTotal calculates the total number of SandboxClaims created.
 	// Labels:
 	// - namespace: the namespace of the claim
@@ -99,6 +150,33 @@ var (
 		[]string{"namespace", "sandbox_template", "launch_type", "warmpool_name", "pod_condition", "created_by"},
 	)
 
+	// ClaimBindTotal counts attempts to bind a SandboxClaim to its first Sandbox.
+	// Labels:
+	// - result: "success" once the claim has a Sandbox, "error" for a failed attempt.
+	ClaimBindTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_sandbox_claim_bind_total",
+			Help: "Total number of attempts to bind a SandboxClaim to a Sandbox, labeled by result.",
+		},
+		[]string{"result"},
+	)
+
+	// ClaimsPending is the number of SandboxClaims waiting for their Sandbox to become ready.
+	// Labels:
+	// - namespace: the namespace of the claim
+	ClaimsPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "agent_sandbox_claims_pending",
+			Help: "Number of SandboxClaims waiting for their Sandbox to become ready, labeled by namespace.",
+		},
+		[]string{"namespace"},
+	)
+
+	// pendingClaims holds the claims ClaimsPending currently counts, so a claim
+	// reported pending on every reconcile is only counted once.
+	pendingClaims   = map[claimKey]struct{}{}
+	pendingClaimsMu sync.Mutex
+
 	// AgentSandboxesDesc describes the agent_sandboxes metric point-in-time counts.
 	// Labels:
 	// - namespace: the namespace of the sandbox
@@ -140,7 +218,12 @@ func init() {
 	metrics.Registry.MustRegister(ClaimStartupLatency)
 	metrics.Registry.MustRegister(ClaimControllerStartupLatency)
 	metrics.Registry.MustRegister(SandboxCreationLatency)
+	metrics.Registry.MustRegister(WarmPoolWarmupLatency)
+	metrics.Registry.MustRegister(SandboxReconcileDuration)
+	metrics.Registry.MustRegister(SandboxReconcileErrorsTotal)
 	metrics.Registry.MustRegister(SandboxClaimCreationTotal)
+	metrics.Registry.MustRegister(ClaimBindTotal)
+	metrics.Registry.MustRegister(ClaimsPending)
 	metrics.Registry.MustRegister(BuildInfo)
 }
 
@@ -161,6 +244,21 @@ func RecordSandboxCreationLatency(duration time.Duration, namespace, launchType,
 	SandboxCreationLatency.WithLabelValues(namespace, launchType, templateName).Observe(float64(duration.Milliseconds()))
 }
 
+// RecordWarmPoolWarmupLatency records the measured latency for a warm pool sandbox to become ready.
+func RecordWarmPoolWarmupLatency(duration time.Duration, namespace, warmPoolName string) {
+	WarmPoolWarmupLatency.WithLabelValues(namespace, warmPoolName).Observe(float64(duration.Milliseconds()))
+}
+
+// RecordSandboxReconcileDuration records the time spent reconciling a Sandbox's child resources.
+func RecordSandboxReconcileDuration(duration time.Duration) {
+	SandboxReconcileDuration.Observe(duration.Seconds())
+}
+
+// RecordSandboxReconcileError increments the reconcile error count for the given child resource reason.
+func RecordSandboxReconcileError(reason string) {
+	SandboxReconcileErrorsTotal.WithLabelValues(reason).Inc()
+}
+
 // NormalizeCreatedBy returns the createdBy label normalized to a known allow-list
 // (go-client, python-client, controller) or "unknown" for anything else.
 func NormalizeCreatedBy(createdBy string) string {
@@ -177,3 +275,29 @@ func NormalizeCreatedBy(createdBy string) string {
 func RecordSandboxClaimCreation(namespace, templateName, launchType, warmPoolName, podCondition, createdBy string) {
 	SandboxClaimCreationTotal.WithLabelValues(namespace, templateName, launchType, warmPoolName, podCondition, NormalizeCreatedBy(createdBy)).Inc()
 }
+
+// RecordClaimBind increments the claim bind count for the given result.
+func RecordClaimBind(result string) {
+	ClaimBindTotal.WithLabelValues(result).Inc()
+}
+
+type claimKey struct {
+	namespace, name string
+}
+
+// SetClaimPending records whether the claim is waiting for its Sandbox to become
+// ready. Claims that are deleted must be reported with pending false.
+func SetClaimPending(namespace, name string, pending bool) {
+	key := claimKey{namespace: namespace, name: name}
+	pendingClaimsMu.Lock()
+	defer pendingClaimsMu.Unlock()
+	_, counted := pendingClaims[key]
+	switch {
+	case pending && !counted:
+		pendingClaims[key] = struct{}{}
+		ClaimsPending.WithLabelValues(namespace).Inc()
+	case !pending && counted:
+		delete(pendingClaims, key)
+		ClaimsPending.WithLabelValues(namespace).Dec()
+	}
+}
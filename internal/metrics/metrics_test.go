@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -81,6 +82,92 @@ func TestSandboxCreationLatencyRecording(t *testing.T) {
 	}
 }
 
+func TestWarmPoolWarmupLatencyRecording(t *testing.T) {
+	WarmPoolWarmupLatency.Reset()
+	RecordWarmPoolWarmupLatency(1500*time.Millisecond, "default", "test-pool")
+
+	if testutil.CollectAndCount(WarmPoolWarmupLatency) != 1 {
+		t.Errorf("Expected 1 observation")
+	}
+}
+
+func TestSandboxReconcileRecording(t *testing.T) {
+	sampleCount := func() uint64 {
+		m := &dto.Metric{}
+		require.NoError(t, SandboxReconcileDuration.Write(m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := sampleCount()
+	RecordSandboxReconcileDuration(250 * time.Millisecond)
+	if got := sampleCount() - before; got != 1 {
+		t.Errorf("Expected 1 observation, got %d", got)
+	}
+
+	SandboxReconcileErrorsTotal.Reset()
+	RecordSandboxReconcileError(ReconcileErrorReasonPod)
+	RecordSandboxReconcileError(ReconcileErrorReasonPod)
+	RecordSandboxReconcileError(ReconcileErrorReasonPVC)
+
+	expected := `
+# HELP agent_sandbox_reconcile_errors_total Total number of failures to reconcile a Sandbox's child resources, labeled by the failing resource.
+# TYPE agent_sandbox_reconcile_errors_total counter
+agent_sandbox_reconcile_errors_total{reason="pod"} 2
+agent_sandbox_reconcile_errors_total{reason="pvc"} 1
+`
+	if err := testutil.CollectAndCompare(SandboxReconcileErrorsTotal, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
+
+func TestClaimBindRecording(t *testing.T) {
+	ClaimBindTotal.Reset()
+	RecordClaimBind(ClaimBindResultSuccess)
+	RecordClaimBind(ClaimBindResultSuccess)
+	RecordClaimBind(ClaimBindResultError)
+
+	expected := `
+# HELP agent_sandbox_claim_bind_total Total number of attempts to bind a SandboxClaim to a Sandbox, labeled by result.
+# TYPE agent_sandbox_claim_bind_total counter
+agent_sandbox_claim_bind_total{result="error"} 1
+agent_sandbox_claim_bind_total{result="success"} 2
+`
+	if err := testutil.CollectAndCompare(ClaimBindTotal, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
+
+func TestClaimsPendingRecording(t *testing.T) {
+	ClaimsPending.Reset()
+	t.Cleanup(func() {
+		for _, name := range []string{"claim-a", "claim-b", "claim-c"} {
+			SetClaimPending("ns-1", name, false)
+		}
+		SetClaimPending("ns-2", "claim-a", false)
+	})
+
+	// Reporting the same claim pending on every reconcile counts it once.
+	SetClaimPending("ns-1", "claim-a", true)
+	SetClaimPending("ns-1", "claim-a", true)
+	SetClaimPending("ns-1", "claim-b", true)
+	SetClaimPending("ns-1", "claim-c", true)
+	SetClaimPending("ns-2", "claim-a", true)
+	// A claim that was never pending is not subtracted.
+	SetClaimPending("ns-2", "claim-never", false)
+	// Claims that become ready or are deleted leave the gauge.
+	SetClaimPending("ns-1", "claim-b", false)
+	SetClaimPending("ns-1", "claim-b", false)
+
+	expected := `
+# HELP agent_sandbox_claims_pending Number of SandboxClaims waiting for their Sandbox to become ready, labeled by namespace.
+# TYPE agent_sandbox_claims_pending gauge
+agent_sandbox_claims_pending{namespace="ns-1"} 2
+agent_sandbox_claims_pending{namespace="ns-2"} 1
+`
+	if err := testutil.CollectAndCompare(ClaimsPending, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
+
 func TestSandboxClaimCreationRecording(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -0,0 +1,197 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package activity records when sandboxes last served a request, so the
+// SandboxClaim controller can shut down claims that went idle
+// (lifecycle.idleTimeout).
+//
+// The record is the agents.x-k8s.io/last-activity annotation on the
+// Sandbox, an RFC 3339 timestamp with second precision. Writing it on
+// every request would turn proxy traffic into apiserver traffic, so the
+// Recorder writes at most once per sandbox per Interval. The timestamp
+// can therefore lag the real last request by up to Interval; pick an
+// Interval well below the idle timeouts in use.
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+)
+
+const (
+	// queueSize bounds the writes waiting for the worker. A burst beyond
+	// it drops the extra writes instead of blocking requests; each dropped
+	// sandbox is retried on its next request.
+	queueSize = 1024
+
+	// writeTimeout bounds a single annotation patch so a slow apiserver
+	// can't stall the queue behind it.
+	writeTimeout = 5 * time.Second
+)
+
+// SandboxResource is the Sandbox CR the annotation is written to.
+var SandboxResource = sandboxv1beta1.GroupVersion.WithResource("sandboxes")
+
+// Options configures a Recorder.
+type Options struct {
+	Client dynamic.Interface
+	Log    logr.Logger
+	// Interval is the minimum time between two writes for the same
+	// sandbox.
+	Interval time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type key struct {
+	namespace, name string
+}
+
+type write struct {
+	key key
+	at  time.Time
+}
+
+// Recorder writes the last-activity annotation onto Sandboxes, throttled
+// per sandbox. Touch is safe for concurrent use and never blocks; the
+// writes happen on the goroutine running Run.
+type Recorder struct {
+	client   dynamic.NamespaceableResourceInterface
+	log      logr.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// queued holds, per sandbox, the time of the last write handed to the
+	// worker. Entries older than interval are pruned by Run.
+	queued map[key]time.Time
+	queue  chan write
+}
+
+// New constructs a Recorder. Call Run to start writing.
+func New(o Options) (*Recorder, error) {
+	if o.Client == nil {
+		return nil, errors.New("activity: Client is required")
+	}
+	if o.Interval <= 0 {
+		return nil, errors.New("activity: Interval must be positive")
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return &Recorder{
+		client:   o.Client.Resource(SandboxResource),
+		log:      o.Log,
+		interval: o.Interval,
+		now:      o.Now,
+		queued:   make(map[key]time.Time),
+		queue:    make(chan write, queueSize),
+	}, nil
+}
+
+// Touch records a request to the sandbox namespace/name. It queues a
+// write unless one was queued for the same sandbox less than Interval
+// ago.
+func (r *Recorder) Touch(namespace, name string) {
+	k := key{namespace: namespace, name: name}
+	now := r.now()
+
+	r.mu.Lock()
+	if last, ok := r.queued[k]; ok && now.Sub(last) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.queued[k] = now
+	r.mu.Unlock()
+
+	select {
+	case r.queue <- write{key: k, at: now}:
+	default:
+		// Queue full: forget the write so the next request retries it.
+		r.mu.Lock()
+		if r.queued[k].Equal(now) {
+			delete(r.queued, k)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Run writes queued annotations until ctx is done.
+func (r *Recorder) Run(ctx context.Context) {
+	prune := time.NewTicker(r.interval)
+	defer prune.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case w := <-r.queue:
+			r.write(ctx, w)
+		case <-prune.C:
+			r.prune()
+		}
+	}
+}
+
+// write patches the annotation onto one Sandbox. A merge patch leaves the
+// rest of the object alone, so it never conflicts with the controllers
+// updating the same Sandbox.
+func (r *Recorder) write(ctx context.Context, w write) {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				sandboxv1beta1.SandboxLastActivityAnnotation: w.at.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		r.log.Error(err, "encode activity patch")
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	_, err = r.client.Namespace(w.key.namespace).Patch(ctx, w.key.name, types.MergePatchType, patch, metav1.PatchOptions{})
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		// The request named a host that isn't a Sandbox, or the Sandbox
+		// is already gone. Nothing to keep alive.
+		r.log.V(1).Info("no sandbox to record activity on", "namespace", w.key.namespace, "sandbox", w.key.name)
+	default:
+		r.log.Error(err, "record sandbox activity", "namespace", w.key.namespace, "sandbox", w.key.name)
+	}
+}
+
+// prune drops throttle entries old enough that they no longer suppress a
+// write, so the map only holds sandboxes active in the last Interval.
+func (r *Recorder) prune() {
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, last := range r.queued {
+		if now.Sub(last) >= r.interval {
+			delete(r.queued, k)
+		}
+	}
+}
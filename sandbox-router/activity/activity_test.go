@@ -0,0 +1,228 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activity
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+)
+
+func makeSandbox(ns, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(sandboxv1beta1.GroupVersion.String())
+	u.SetKind("Sandbox")
+	u.SetNamespace(ns)
+	u.SetName(name)
+	return u
+}
+
+// newFakeClient returns a dynamic client serving sandboxes. They are
+// created through the client because the tracker would guess the resource
+// of a seeded object from its kind, as "sandboxs".
+func newFakeClient(t *testing.T, sandboxes ...*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	t.Helper()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{SandboxResource: "SandboxList"})
+	for _, sb := range sandboxes {
+		if _, err := client.Resource(SandboxResource).Namespace(sb.GetNamespace()).Create(context.Background(), sb, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create sandbox: %v", err)
+		}
+	}
+	return client
+}
+
+// fakeClock is a settable Options.Now.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// drain runs the queued writes synchronously, standing in for Run.
+func drain(t *testing.T, r *Recorder) int {
+	t.Helper()
+	n := 0
+	for {
+		select {
+		case w := <-r.queue:
+			r.write(context.Background(), w)
+			n++
+		default:
+			return n
+		}
+	}
+}
+
+func lastActivity(t *testing.T, client *dynamicfake.FakeDynamicClient, ns, name string) string {
+	t.Helper()
+	u, err := client.Resource(SandboxResource).Namespace(ns).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get sandbox: %v", err)
+	}
+	return u.GetAnnotations()[sandboxv1beta1.SandboxLastActivityAnnotation]
+}
+
+func TestRecorderThrottlesWrites(t *testing.T) {
+	client := newFakeClient(t, makeSandbox("ns", "box"), makeSandbox("ns", "other"))
+	clock := &fakeClock{now: time.Date(2026, time.May, 4, 12, 0, 0, 0, time.UTC)}
+	r, err := New(Options{Client: client, Log: logr.Discard(), Interval: 30 * time.Second, Now: clock.Now})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// The first request writes; the next ones inside the interval don't.
+	r.Touch("ns", "box")
+	clock.advance(10 * time.Second)
+	r.Touch("ns", "box")
+	clock.advance(10 * time.Second)
+	r.Touch("ns", "box")
+	if n := drain(t, r); n != 1 {
+		t.Fatalf("writes inside interval: got %d, want 1", n)
+	}
+	if got, want := lastActivity(t, client, "ns", "box"), "2026-05-04T12:00:00Z"; got != want {
+		t.Fatalf("annotation: got %q, want %q", got, want)
+	}
+
+	// Sandboxes are throttled independently.
+	r.Touch("ns", "other")
+	if n := drain(t, r); n != 1 {
+		t.Fatalf("writes for another sandbox: got %d, want 1", n)
+	}
+
+	// Once the interval has passed since the last write, the next
+	// request writes again with its own time.
+	clock.advance(10 * time.Second)
+	r.Touch("ns", "box")
+	if n := drain(t, r); n != 1 {
+		t.Fatalf("writes after interval: got %d, want 1", n)
+	}
+	if got, want := lastActivity(t, client, "ns", "box"), "2026-05-04T12:00:30Z"; got != want {
+		t.Fatalf("annotation: got %q, want %q", got, want)
+	}
+}
+
+func TestRecorderMissingSandbox(t *testing.T) {
+	client := newFakeClient(t)
+	r, err := New(Options{Client: client, Log: logr.Discard(), Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r.Touch("ns", "gone")
+	if n := drain(t, r); n != 1 {
+		t.Fatalf("writes: got %d, want 1", n)
+	}
+	// The failed patch is the only call; nothing is created.
+	if _, err := client.Resource(SandboxResource).Namespace("ns").Get(context.Background(), "gone", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the sandbox to stay missing")
+	}
+}
+
+func TestRecorderQueueFullRetries(t *testing.T) {
+	client := newFakeClient(t, makeSandbox("ns", "box"))
+	r, err := New(Options{Client: client, Log: logr.Discard(), Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for range queueSize {
+		r.queue <- write{}
+	}
+
+	// The write doesn't fit, so it must not throttle the next request.
+	r.Touch("ns", "box")
+	if _, ok := r.queued[key{namespace: "ns", name: "box"}]; ok {
+		t.Fatal("dropped write should not be remembered")
+	}
+	for range queueSize {
+		<-r.queue
+	}
+	r.Touch("ns", "box")
+	if got := len(r.queue); got != 1 {
+		t.Fatalf("queued writes after retry: got %d, want 1", got)
+	}
+}
+
+func TestRecorderPrune(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, time.May, 4, 12, 0, 0, 0, time.UTC)}
+	r, err := New(Options{Client: newFakeClient(t), Log: logr.Discard(), Interval: 30 * time.Second, Now: clock.Now})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	r.Touch("ns", "old")
+	clock.advance(20 * time.Second)
+	r.Touch("ns", "recent")
+	clock.advance(15 * time.Second)
+	r.prune()
+
+	if _, ok := r.queued[key{namespace: "ns", name: "old"}]; ok {
+		t.Error("entry past the interval should be pruned")
+	}
+	if _, ok := r.queued[key{namespace: "ns", name: "recent"}]; !ok {
+		t.Error("entry inside the interval should be kept")
+	}
+}
+
+func TestRecorderRunStopsWithContext(t *testing.T) {
+	client := newFakeClient(t, makeSandbox("ns", "box"))
+	r, err := New(Options{Client: client, Log: logr.Discard(), Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { r.Run(ctx) })
+
+	r.Touch("ns", "box")
+	deadline := time.Now().Add(5 * time.Second)
+	for lastActivity(t, client, "ns", "box") == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("Run did not write the queued annotation")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestNewRequiresClientAndInterval(t *testing.T) {
+	if _, err := New(Options{Interval: time.Minute}); err == nil {
+		t.Error("expected error without Client")
+	}
+	if _, err := New(Options{Client: newFakeClient(t)}); err == nil {
+		t.Error("expected error without Interval")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/informers"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Endpoints reports whether a sandbox Service has ready endpoints, backed
+// by an EndpointSlice informer. It lets the router answer "sandbox not
+// ready" immediately instead of waiting out DNS retries for a sandbox
+// whose Pod has not passed its readiness probe yet.
+//
+// Lookups are served from the informer's local store, so the apiserver
+// only sees the initial LIST and the watch regardless of request rate.
+type Endpoints struct {
+	informer cache.SharedIndexInformer
+	lister   discoveryv1listers.EndpointSliceLister
+	factory  informers.SharedInformerFactory
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewEndpoints constructs an Endpoints backed by a filtered EndpointSlice
+// SharedInformer. Like New, the informer is NOT started; call Start and
+// WaitForSync.
+func NewEndpoints(o Options) (*Endpoints, error) {
+	if o.Client == nil {
+		return nil, errors.New("cache: Client is required")
+	}
+	if o.Resync == 0 {
+		o.Resync = defaultResync
+	}
+	// The EndpointSlice controller copies the Service's labels onto its
+	// slices, so sandbox slices carry the sandbox-name-hash label too.
+	hashSel, err := labels.NewRequirement(PodSandboxNameHashLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	tweak := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = labels.NewSelector().Add(*hashSel).String()
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		o.Client, o.Resync,
+		informers.WithNamespace(o.Namespace),
+		informers.WithTweakListOptions(tweak),
+	)
+	sliceInformer := factory.Discovery().V1().EndpointSlices()
+
+	return &Endpoints{
+		informer: sliceInformer.Informer(),
+		lister:   sliceInformer.Lister(),
+		factory:  factory,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start launches the informer goroutines.
+func (e *Endpoints) Start(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		e.stopOnce.Do(func() { close(e.stopCh) })
+	}()
+	e.factory.Start(e.stopCh)
+}
+
+// WaitForSync blocks until the informer's initial LIST has been
+// processed, or ctx is canceled. Returns true on successful sync.
+func (e *Endpoints) WaitForSync(ctx context.Context) bool {
+	return cache.WaitForCacheSync(ctx.Done(), e.informer.HasSynced)
+}
+
+// HasReadyEndpoints reports whether any EndpointSlice of the sandbox
+// Service namespace/name has a ready endpoint. An endpoint with an unknown
+// ready condition counts as ready, per the EndpointSlice API.
+func (e *Endpoints) HasReadyEndpoints(namespace, name string) bool {
+	slices, err := e.lister.EndpointSlices(namespace).List(
+		labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name}))
+	if err != nil {
+		return false
+	}
+	for _, slice := range slices {
+		for i := range slice.Endpoints {
+			if ready := slice.Endpoints[i].Conditions.Ready; ready == nil || *ready {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadyAddresses returns the addresses of the ready endpoints of the
+// sandbox Service namespace/name, sorted and without duplicates. It backs
+// cookie session affinity, which pins a client to one of them and checks
+// on every request that the pinned address is still listed.
+func (e *Endpoints) ReadyAddresses(namespace, name string) []string {
+	list, err := e.lister.EndpointSlices(namespace).List(
+		labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name}))
+	if err != nil {
+		return nil
+	}
+	var addrs []string
+	for _, slice := range list {
+		for i := range slice.Endpoints {
+			if ready := slice.Endpoints[i].Conditions.Ready; ready == nil || *ready {
+				addrs = append(addrs, slice.Endpoints[i].Addresses...)
+			}
+		}
+	}
+	slices.Sort(addrs)
+	return slices.Compact(addrs)
+}
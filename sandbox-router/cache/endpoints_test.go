@@ -0,0 +1,151 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func makeEndpointSlice(name, ns, service string, labeled bool, ready ...*bool) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	if labeled {
+		slice.Labels[PodSandboxNameHashLabel] = "abc123"
+	}
+	for _, r := range ready {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{testPodIP},
+			Conditions: discoveryv1.EndpointConditions{Ready: r},
+		})
+	}
+	return slice
+}
+
+func newEndpoints(t *testing.T, objs ...runtime.Object) (*Endpoints, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset(objs...)
+	e, err := NewEndpoints(Options{
+		Client: client,
+		Log:    logr.Discard(),
+		Resync: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewEndpoints: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	e.Start(ctx)
+	if ok := e.WaitForSync(ctx); !ok {
+		t.Fatalf("WaitForSync failed")
+	}
+	return e, client
+}
+
+func TestEndpointsHasReadyEndpoints(t *testing.T) {
+	notReady, ready := new(false), new(true)
+	e, _ := newEndpoints(t,
+		makeEndpointSlice("starting-abc", testPodNS, "starting", true, notReady),
+		makeEndpointSlice("running-abc", testPodNS, "running", true, notReady),
+		makeEndpointSlice("running-def", testPodNS, "running", true, ready),
+		makeEndpointSlice("unknown-abc", testPodNS, "unknown", true, nil),
+		makeEndpointSlice("empty-abc", testPodNS, "empty", true),
+		makeEndpointSlice("unlabeled-abc", testPodNS, "unlabeled", false, ready),
+	)
+
+	cases := []struct {
+		name      string
+		namespace string
+		service   string
+		want      bool
+	}{
+		{name: "no ready endpoints", namespace: testPodNS, service: "starting"},
+		{name: "ready endpoint in one of several slices", namespace: testPodNS, service: "running", want: true},
+		{name: "unknown ready condition counts as ready", namespace: testPodNS, service: "unknown", want: true},
+		{name: "slice without endpoints", namespace: testPodNS, service: "empty"},
+		{name: "unlabeled slice is not watched", namespace: testPodNS, service: "unlabeled"},
+		{name: "unknown service", namespace: testPodNS, service: "missing"},
+		{name: "wrong namespace", namespace: "default", service: "running"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := e.HasReadyEndpoints(tc.namespace, tc.service); got != tc.want {
+				t.Fatalf("HasReadyEndpoints(%q, %q) = %v, want %v", tc.namespace, tc.service, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEndpointsReadyAddresses(t *testing.T) {
+	notReady, ready := new(false), new(true)
+	running := makeEndpointSlice("running-abc", testPodNS, "running", true)
+	running.Endpoints = []discoveryv1.Endpoint{
+		{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ready}},
+		{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: notReady}},
+		{Addresses: []string{"10.0.0.1"}},
+	}
+	// The same endpoint can briefly appear in two slices while the
+	// EndpointSlice controller moves it.
+	moving := makeEndpointSlice("running-def", testPodNS, "running", true)
+	moving.Endpoints = []discoveryv1.Endpoint{
+		{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ready}},
+	}
+	e, _ := newEndpoints(t, running, moving,
+		makeEndpointSlice("starting-abc", testPodNS, "starting", true, notReady),
+	)
+
+	if got, want := e.ReadyAddresses(testPodNS, "running"), []string{"10.0.0.1", "10.0.0.2"}; !slices.Equal(got, want) {
+		t.Errorf("ReadyAddresses(running) = %v, want %v", got, want)
+	}
+	if got := e.ReadyAddresses(testPodNS, "starting"); len(got) != 0 {
+		t.Errorf("ReadyAddresses(starting) = %v, want none", got)
+	}
+	if got := e.ReadyAddresses(testPodNS, "missing"); len(got) != 0 {
+		t.Errorf("ReadyAddresses(missing) = %v, want none", got)
+	}
+}
+
+func TestEndpointsTracksUpdates(t *testing.T) {
+	slice := makeEndpointSlice("sandbox-abc", testPodNS, testPodName, true, new(false))
+	e, client := newEndpoints(t, slice)
+
+	if e.HasReadyEndpoints(testPodNS, testPodName) {
+		t.Fatalf("sandbox should not be ready before its endpoint is")
+	}
+
+	slice = slice.DeepCopy()
+	slice.Endpoints[0].Conditions.Ready = new(true)
+	if _, err := client.DiscoveryV1().EndpointSlices(testPodNS).Update(t.Context(), slice, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !waitFor(t, func() bool { return e.HasReadyEndpoints(testPodNS, testPodName) }) {
+		t.Fatalf("ready endpoint not observed after update")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/informers"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ServicePorts resolves named Service ports for sandboxes, backed by a
+// Service informer. It powers X-Sandbox-Port-Name routing: the sandbox
+// controller derives the headless Service's ports from the Pod's
+// containerPorts, so a Service port name maps 1:1 to the port the
+// sandbox is listening on.
+//
+// Kept separate from the Pod-IP Cache so deployments that only grant
+// Pod RBAC can keep running the fast path without also needing
+// Service list/watch.
+type ServicePorts struct {
+	informer cache.SharedIndexInformer
+	lister   corev1listers.ServiceLister
+	factory  informers.SharedInformerFactory
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewServicePorts constructs a ServicePorts backed by a filtered Service
+// SharedInformer. Like New, the informer is NOT started; call Start and
+// WaitForSync.
+func NewServicePorts(o Options) (*ServicePorts, error) {
+	if o.Client == nil {
+		return nil, errors.New("cache: Client is required")
+	}
+	if o.Resync == 0 {
+		o.Resync = defaultResync
+	}
+	// Sandbox Services carry the same sandbox-name-hash label as their
+	// Pods; filter server-side so unrelated Services never reach us.
+	hashSel, err := labels.NewRequirement(PodSandboxNameHashLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	tweak := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = labels.NewSelector().Add(*hashSel).String()
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		o.Client, o.Resync,
+		informers.WithNamespace(o.Namespace),
+		informers.WithTweakListOptions(tweak),
+	)
+	svcInformer := factory.Core().V1().Services()
+
+	return &ServicePorts{
+		informer: svcInformer.Informer(),
+		lister:   svcInformer.Lister(),
+		factory:  factory,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start launches the informer goroutines.
+func (s *ServicePorts) Start(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		s.stopOnce.Do(func() { close(s.stopCh) })
+	}()
+	s.factory.Start(s.stopCh)
+}
+
+// WaitForSync blocks until the informer's initial LIST has been
+// processed, or ctx is canceled. Returns true on successful sync.
+func (s *ServicePorts) WaitForSync(ctx context.Context) bool {
+	return cache.WaitForCacheSync(ctx.Done(), s.informer.HasSynced)
+}
+
+// ResolvePort returns the port number of the Service port named
+// portName on the sandbox Service namespace/name. Returns (0, false)
+// when the Service is unknown or has no port with that name.
+func (s *ServicePorts) ResolvePort(namespace, name, portName string) (int, bool) {
+	svc, err := s.lister.Services(namespace).Get(name)
+	if err != nil {
+		return 0, false
+	}
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Name == portName {
+			return int(svc.Spec.Ports[i].Port), true
+		}
+	}
+	return 0, false
+}
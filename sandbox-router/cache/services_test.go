@@ -0,0 +1,120 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func makeService(name, ns string, labeled bool, ports ...corev1.ServicePort) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, Ports: ports},
+	}
+	if labeled {
+		svc.Labels = map[string]string{PodSandboxNameHashLabel: "abc123"}
+	}
+	return svc
+}
+
+func newServicePorts(t *testing.T, objs ...runtime.Object) (*ServicePorts, *fake.Clientset) {
+	t.Helper()
+	client := fake.NewSimpleClientset(objs...)
+	s, err := NewServicePorts(Options{
+		Client: client,
+		Log:    logr.Discard(),
+		Resync: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewServicePorts: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	s.Start(ctx)
+	if ok := s.WaitForSync(ctx); !ok {
+		t.Fatalf("WaitForSync failed")
+	}
+	return s, client
+}
+
+func TestServicePortsResolvePort(t *testing.T) {
+	svc := makeService(testPodName, testPodNS, true,
+		corev1.ServicePort{Name: "http", Port: 8888},
+		corev1.ServicePort{Name: "jupyter", Port: 8889},
+	)
+	s, _ := newServicePorts(t, svc)
+
+	cases := []struct {
+		name      string
+		namespace string
+		service   string
+		portName  string
+		wantPort  int
+		wantOK    bool
+	}{
+		{name: "known name", namespace: testPodNS, service: testPodName, portName: "jupyter", wantPort: 8889, wantOK: true},
+		{name: "unknown name", namespace: testPodNS, service: testPodName, portName: "ssh"},
+		{name: "unknown service", namespace: testPodNS, service: "other", portName: "http"},
+		{name: "wrong namespace", namespace: "default", service: testPodName, portName: "http"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			port, ok := s.ResolvePort(tc.namespace, tc.service, tc.portName)
+			if ok != tc.wantOK || port != tc.wantPort {
+				t.Fatalf("ResolvePort(%q, %q, %q) = (%d, %v), want (%d, %v)",
+					tc.namespace, tc.service, tc.portName, port, ok, tc.wantPort, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestServicePortsIgnoresUnlabeledServices(t *testing.T) {
+	// The fake clientset honors label selectors on LIST, so an unlabeled
+	// Service must never be visible to the lister.
+	svc := makeService(testPodName, testPodNS, false, corev1.ServicePort{Name: "http", Port: 8888})
+	s, _ := newServicePorts(t, svc)
+
+	if _, ok := s.ResolvePort(testPodNS, testPodName, "http"); ok {
+		t.Fatalf("unlabeled Service must not be resolvable")
+	}
+}
+
+func TestServicePortsTracksUpdates(t *testing.T) {
+	s, client := newServicePorts(t)
+
+	svc := makeService(testPodName, testPodNS, true, corev1.ServicePort{Name: "http", Port: 8888})
+	if _, err := client.CoreV1().Services(testPodNS).Create(t.Context(), svc, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !waitFor(t, func() bool { _, ok := s.ResolvePort(testPodNS, testPodName, "http"); return ok }) {
+		t.Fatalf("service port not observed after create")
+	}
+
+	if err := client.CoreV1().Services(testPodNS).Delete(t.Context(), testPodName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if !waitFor(t, func() bool { _, ok := s.ResolvePort(testPodNS, testPodName, "http"); return !ok }) {
+		t.Fatalf("service port still resolvable after delete")
+	}
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -42,6 +44,7 @@ import (
 
 	asmetrics "sigs.k8s.io/agent-sandbox/internal/metrics"
 	"sigs.k8s.io/agent-sandbox/internal/version"
+	"sigs.k8s.io/agent-sandbox/sandbox-router/activity"
 	"sigs.k8s.io/agent-sandbox/sandbox-router/authz"
 	"sigs.k8s.io/agent-sandbox/sandbox-router/cache"
 	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
@@ -101,6 +104,11 @@ func run(cfg *config.Config, log logr.Logger) error {
 	defer stop()
 
 	// --- Tracing -----------------------------------------------------------
+	// Extract and re-inject W3C trace context even with tracing off. The
+	// no-op tracer then carries an inbound traceparent through unchanged,
+	// so its trace ID still lands in the access log and reaches the
+	// sandbox. SetupOTel installs the same propagator.
+	otel.SetTextMapPropagator(propagation.TraceContext{})
 	if cfg.EnableTracing {
 		initCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 		_, cleanup, err := asmetrics.SetupOTel(initCtx, "sandbox-router")
@@ -152,16 +160,36 @@ func run(cfg *config.Config, log logr.Logger) error {
 		}
 	}
 
-	// --- Kubernetes client (shared by cache + tokenreview) ----------------
-	// Build once if either feature needs it so we don't load kubeconfig
-	// twice. Nil when neither feature is on; helpers below handle that.
+	// --- Upstream TLS (https sandboxes) -------------------------------------
+	// Always built: X-Sandbox-Scheme can ask for https per request even
+	// when --upstream-scheme is http.
+	var upstreamReloader *tlsutil.CertReloader
+	if cfg.UpstreamTLSCertFile != "" {
+		var err error
+		upstreamReloader, err = tlsutil.NewCertReloader(cfg.UpstreamTLSCertFile, cfg.UpstreamTLSKeyFile, log.WithName("upstream-tls"), nil)
+		if err != nil {
+			return fmt.Errorf("upstream cert reloader: %w", err)
+		}
+		if err := upstreamReloader.Start(ctx); err != nil {
+			return fmt.Errorf("upstream cert watcher: %w", err)
+		}
+	}
+	upstreamTLS, err := tlsutil.BuildUpstreamTLS(cfg, upstreamReloader)
+	if err != nil {
+		return fmt.Errorf("build upstream TLS config: %w", err)
+	}
+
+	// --- Kubernetes client (shared by caches + tokenreview) ---------------
+	// Build once if any feature needs it so we don't load kubeconfig
+	// twice. Nil when no feature is on; helpers below handle that.
 	var k8sClient kubernetes.Interface
-	if cfg.CacheEnabled || cfg.AuthzMode == config.AuthzTokenReview {
-		c, err := buildKubernetesClient(cfg.Kubeconfig)
+	var restConfig *rest.Config
+	if cfg.CacheEnabled || cfg.NamedPortsEnabled || cfg.ReadinessCheckEnabled || cfg.SessionAffinityEnabled || cfg.ActivityTrackingEnabled || cfg.AuthzMode == config.AuthzTokenReview {
+		c, rc, err := buildKubernetesClient(cfg.Kubeconfig)
 		if err != nil {
 			return fmt.Errorf("kubernetes client: %w", err)
 		}
-		k8sClient = c
+		k8sClient, restConfig = c, rc
 	}
 
 	// --- Pod-IP cache (optional, KEP-NNNN fast path) ----------------------
@@ -190,6 +218,71 @@ func run(cfg *config.Config, log logr.Logger) error {
 		log.Info("pod cache synced", "entries", podCache.Len(), "namespace", cfg.CacheNamespace)
 	}
 
+	// --- Service named-port cache (optional) ------------------------------
+	var servicePorts *cache.ServicePorts
+	if cfg.NamedPortsEnabled {
+		var err error
+		servicePorts, err = cache.NewServicePorts(cache.Options{
+			Client:    k8sClient,
+			Log:       log.WithName("service-ports"),
+			Namespace: cfg.CacheNamespace,
+		})
+		if err != nil {
+			return fmt.Errorf("build service port cache: %w", err)
+		}
+		servicePorts.Start(ctx)
+		syncCtx, syncCancel := context.WithTimeout(ctx, 60*time.Second)
+		ok := servicePorts.WaitForSync(syncCtx)
+		syncCancel()
+		if !ok {
+			return fmt.Errorf("service port cache failed initial sync (check RBAC for services get/list/watch)")
+		}
+		log.Info("service port cache synced", "namespace", cfg.CacheNamespace)
+	}
+
+	// --- EndpointSlice cache (optional) -----------------------------------
+	// Shared by the readiness pre-check and cookie session affinity.
+	var endpoints *cache.Endpoints
+	if cfg.ReadinessCheckEnabled || cfg.SessionAffinityEnabled {
+		var err error
+		endpoints, err = cache.NewEndpoints(cache.Options{
+			Client:    k8sClient,
+			Log:       log.WithName("endpoints"),
+			Namespace: cfg.CacheNamespace,
+		})
+		if err != nil {
+			return fmt.Errorf("build endpoints cache: %w", err)
+		}
+		endpoints.Start(ctx)
+		syncCtx, syncCancel := context.WithTimeout(ctx, 60*time.Second)
+		ok := endpoints.WaitForSync(syncCtx)
+		syncCancel()
+		if !ok {
+			return fmt.Errorf("endpoints cache failed initial sync (check RBAC for endpointslices get/list/watch)")
+		}
+		log.Info("endpoints cache synced", "namespace", cfg.CacheNamespace)
+	}
+
+	// --- Activity tracking (optional) -------------------------------------
+	// Sandbox is a CRD, so its annotation is patched through a dynamic
+	// client sharing the typed client's rest config.
+	var activityRecorder *activity.Recorder
+	if cfg.ActivityTrackingEnabled {
+		dc, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("dynamic client: %w", err)
+		}
+		activityRecorder, err = activity.New(activity.Options{
+			Client:   dc,
+			Log:      log.WithName("activity"),
+			Interval: cfg.ActivityInterval,
+		})
+		if err != nil {
+			return fmt.Errorf("build activity recorder: %w", err)
+		}
+		go activityRecorder.Run(ctx)
+	}
+
 	// --- Authorization -----------------------------------------------------
 	var authorizer authz.Authorizer = authz.AllowAll{}
 	if cfg.AuthzMode == config.AuthzTokenReview {
@@ -210,21 +303,43 @@ func run(cfg *config.Config, log logr.Logger) error {
 
 	// --- Proxy handler -----------------------------------------------------
 	proxyOpts := proxy.Options{
-		Config:     cfg,
-		Metrics:    metrics,
-		Propagator: otel.GetTextMapPropagator(),
-		Logger:     log.WithName("proxy"),
-		Authorizer: authorizer,
+		Config:      cfg,
+		Metrics:     metrics,
+		Propagator:  otel.GetTextMapPropagator(),
+		Logger:      log.WithName("proxy"),
+		Authorizer:  authorizer,
+		UpstreamTLS: upstreamTLS,
 	}
 	if podCache != nil {
 		proxyOpts.Cache = podCache
 	}
+	if servicePorts != nil {
+		proxyOpts.Ports = servicePorts
+	}
+	if cfg.ReadinessCheckEnabled {
+		proxyOpts.Endpoints = endpoints
+	}
+	if cfg.SessionAffinityEnabled {
+		proxyOpts.Backends = endpoints
+	}
+	if activityRecorder != nil {
+		proxyOpts.Activity = activityRecorder
+	}
 	handler := proxy.NewHandler(proxyOpts)
 
 	// Top-level mux: /healthz reuses the probes implementation so the
 	// Python router's contract (200 OK with {"status":"ok"}) is preserved.
 	// All other paths fall through to the proxy.
 	probes := server.NewProbes()
+	// /ready on the probe port also checks cluster connectivity: the
+	// apiserver's Service name must resolve, and when a feature talks to
+	// the apiserver, it must answer. /healthz and /readyz stay local.
+	probes.AddReadyCheck("dns", server.DNSCheck("kubernetes.default.svc."+cfg.ClusterDomain))
+	if k8sClient != nil {
+		probes.AddReadyCheck("apiserver", func(ctx context.Context) error {
+			return k8sClient.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+		})
+	}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", probes.Healthz)
 	mux.Handle("/", handler)
@@ -249,9 +364,6 @@ func run(cfg *config.Config, log logr.Logger) error {
 		otel.GetTextMapPropagator(),
 		log,
 	)(rootHandler)
-	if cfg.MaxRequestBodyBytes > 0 {
-		rootHandler = limitBody(rootHandler, cfg.MaxRequestBodyBytes)
-	}
 
 	// --- Server lifecycle --------------------------------------------------
 	srv, err := server.New(server.Options{
@@ -281,14 +393,20 @@ func run(cfg *config.Config, log logr.Logger) error {
 		"tracing", cfg.EnableTracing,
 		"otelMetrics", cfg.EnableOTelMetrics,
 		"cache", cfg.CacheEnabled,
+		"namedPorts", cfg.NamedPortsEnabled,
+		"readinessCheck", cfg.ReadinessCheckEnabled,
+		"pathRouting", cfg.PathRoutingEnabled,
+		"sessionAffinity", cfg.SessionAffinityEnabled,
+		"activityTracking", cfg.ActivityTrackingEnabled,
 		"authz", cfg.AuthzMode,
 	)
 	return srv.Run(ctx)
 }
 
-// buildKubernetesClient returns a typed client built from kubeconfigPath
-// when non-empty, or the in-cluster config (ServiceAccount token + the
-// kubernetes.default API server) when empty. Mirrors clientcmd's
+// buildKubernetesClient returns a typed client, and the rest config it
+// was built from, loaded from kubeconfigPath when non-empty, or the
+// in-cluster config (ServiceAccount token + the kubernetes.default API
+// server) when empty. Mirrors clientcmd's
 // standard precedence so operators can run the router locally with
 // KUBECONFIG and in-cluster without any flag.
 //
@@ -300,12 +418,16 @@ func run(cfg *config.Config, log logr.Logger) error {
 // in-cluster mode despite the documented behavior. Only when
 // InClusterConfig fails (we're not running in a Pod) do we fall back
 // to clientcmd so the local-dev path keeps working.
-func buildKubernetesClient(kubeconfigPath string) (kubernetes.Interface, error) {
+func buildKubernetesClient(kubeconfigPath string) (kubernetes.Interface, *rest.Config, error) {
 	restConfig, err := loadRESTConfig(kubeconfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("build rest config: %w", err)
+		return nil, nil, fmt.Errorf("build rest config: %w", err)
 	}
-	return kubernetes.NewForConfig(restConfig)
+	c, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, restConfig, nil
 }
 
 // loadRESTConfig is split out so the precedence logic is testable
@@ -338,13 +460,3 @@ func loadRESTConfig(kubeconfigPath string) (*rest.Config, error) {
 		clientcmd.NewDefaultClientConfigLoadingRules(),
 		&clientcmd.ConfigOverrides{}).ClientConfig()
 }
-
-// limitBody applies http.MaxBytesReader to the inbound request body.
-func limitBody(next http.Handler, limit int64) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Body != nil {
-			r.Body = http.MaxBytesReader(w, r.Body, limit)
-		}
-		next.ServeHTTP(w, r)
-	})
-}
@@ -18,6 +18,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -48,6 +51,17 @@ const (
 	AuthzTokenReview AuthzMode = "tokenreview"
 )
 
+// UpstreamScheme is the scheme the router uses to reach sandboxes.
+type UpstreamScheme string
+
+const (
+	// UpstreamSchemeHTTP proxies to sandboxes over plain HTTP (Python
+	// router default).
+	UpstreamSchemeHTTP UpstreamScheme = "http"
+	// UpstreamSchemeHTTPS proxies to sandboxes over TLS.
+	UpstreamSchemeHTTPS UpstreamScheme = "https"
+)
+
 // Config is the parsed runtime configuration. All fields are populated by
 // RegisterFlags + flag.Parse and validated by Validate.
 type Config struct {
@@ -58,7 +72,7 @@ type Config struct {
 	HTTPSAddr string
 	// MetricsAddr is the address for the Prometheus /metrics endpoint.
 	MetricsAddr string
-	// ProbeAddr is the address for the /healthz and /readyz endpoints.
+	// ProbeAddr is the address for the /healthz, /readyz and /ready endpoints.
 	ProbeAddr string
 
 	// TLSCertFile is the path to the PEM-encoded server certificate.
@@ -95,9 +109,32 @@ type Config struct {
 	UpstreamRetryInitialDelay time.Duration
 	// UpstreamRetryMaxDelay caps the per-iteration backoff.
 	UpstreamRetryMaxDelay time.Duration
+	// UpstreamScheme is the scheme used to reach sandboxes when a request
+	// does not carry X-Sandbox-Scheme. Defaults to http.
+	UpstreamScheme UpstreamScheme
+	// UpstreamTLSCAFile is the path to the PEM-encoded CA bundle used to
+	// verify sandbox serving certificates on https upstreams. Empty means
+	// the system roots.
+	UpstreamTLSCAFile string
+	// UpstreamTLSCertFile and UpstreamTLSKeyFile are the PEM-encoded client
+	// certificate and key presented to https upstreams that require mTLS.
+	// Both or neither must be set. Hot-reloaded like the server certificate.
+	UpstreamTLSCertFile string
+	UpstreamTLSKeyFile  string
 	// MaxRequestBodyBytes optionally caps the inbound request body size.
 	// 0 means unlimited.
 	MaxRequestBodyBytes int64
+	// RateLimitRPS is the sustained request rate allowed per sandbox by
+	// the router's token-bucket limiter. 0 (the default) disables rate
+	// limiting.
+	RateLimitRPS float64
+	// RateLimitBurst is the per-sandbox bucket size. 0 means ceil(RPS).
+	RateLimitBurst int
+	// RateLimitNamespaceOverrides replaces RateLimitRPS and RateLimitBurst
+	// for sandboxes in specific namespaces. Each entry has the form
+	// "namespace=rps" or "namespace=rps:burst"; an rps of 0 disables
+	// limiting in that namespace.
+	RateLimitNamespaceOverrides []string
 
 	// AllowLoopbackPodIP, when true, lets X-Sandbox-Pod-IP carry a
 	// loopback address (127.0.0.0/8 or ::1). The default-false
@@ -113,6 +150,12 @@ type Config struct {
 	// stay rejected even when this flag is on.
 	AllowLoopbackPodIP bool
 
+	// PathRoutingEnabled turns on path-prefix routing: a request without
+	// X-Sandbox-ID whose path is /s/<namespace>/<id>/<port>/... is routed
+	// to that sandbox with the prefix stripped. Off by default so paths
+	// that happen to start with /s/ keep their current meaning.
+	PathRoutingEnabled bool
+
 	// EnableTracing enables OTel tracing via the OTLP gRPC exporter. The
 	// exporter endpoint is read from OTEL_EXPORTER_OTLP_ENDPOINT.
 	EnableTracing bool
@@ -143,6 +186,34 @@ type Config struct {
 	// namespace. Empty means cluster-wide (recommended; sandboxes can
 	// live in many namespaces).
 	CacheNamespace string
+	// NamedPortsEnabled turns on X-Sandbox-Port-Name routing. When true
+	// the router builds an informer for sandbox Services and resolves
+	// port names against their named ports. Shares CacheNamespace and
+	// Kubeconfig with the Pod cache but is toggled independently since
+	// it needs Service RBAC.
+	NamedPortsEnabled bool
+	// ReadinessCheckEnabled turns on the sandbox readiness pre-check.
+	// When true the router builds an informer for sandbox EndpointSlices
+	// and answers DNS-routed requests for a sandbox without ready
+	// endpoints with 503 instead of dialing it. Shares CacheNamespace and
+	// Kubeconfig; toggled independently since it needs EndpointSlice RBAC.
+	ReadinessCheckEnabled bool
+	// SessionAffinityEnabled lets requests opt into cookie session
+	// affinity with X-Sandbox-Session-Affinity: cookie. When true the
+	// router builds the same EndpointSlice informer as the readiness
+	// check and pins each opted-in client to one of the sandbox's ready
+	// pods. Shares CacheNamespace and Kubeconfig.
+	SessionAffinityEnabled bool
+	// ActivityTrackingEnabled makes the router record each proxied
+	// request on its Sandbox as the agents.x-k8s.io/last-activity
+	// annotation, which the SandboxClaim controller reads to enforce
+	// lifecycle.idleTimeout. Needs Sandbox patch RBAC; uses Kubeconfig.
+	ActivityTrackingEnabled bool
+	// ActivityInterval is the minimum time between two activity writes
+	// for the same sandbox, bounding the apiserver writes proxy traffic
+	// can cause. The recorded time lags the last request by up to this
+	// much, so keep it well below the idle timeouts in use.
+	ActivityInterval time.Duration
 	// Kubeconfig is the path to a kubeconfig file used to build the
 	// informer client. Empty means use in-cluster config. Honors the
 	// standard KUBECONFIG env var.
@@ -187,10 +258,12 @@ func Defaults() Config {
 		UpstreamMaxRetries:        3,
 		UpstreamRetryInitialDelay: 200 * time.Millisecond,
 		UpstreamRetryMaxDelay:     800 * time.Millisecond,
+		UpstreamScheme:            UpstreamSchemeHTTP,
 		AccessLog:                 true,
 		AuthzMode:                 AuthzAllowAll,
 		AuthzTokenReviewTTL:       30 * time.Second,
 		AuthzTokenReviewCacheSize: 2048,
+		ActivityInterval:          30 * time.Second,
 	}
 }
 
@@ -246,6 +319,18 @@ func (c *Config) Validate() error {
 	if c.UpstreamRetryMaxDelay < 0 {
 		return fmt.Errorf("--upstream-retry-max-delay must be non-negative, got %s", c.UpstreamRetryMaxDelay)
 	}
+	switch c.UpstreamScheme {
+	case UpstreamSchemeHTTP, UpstreamSchemeHTTPS:
+	default:
+		return fmt.Errorf("invalid --upstream-scheme %q (want http or https)", c.UpstreamScheme)
+	}
+	if (c.UpstreamTLSCertFile == "") != (c.UpstreamTLSKeyFile == "") {
+		return errors.New("--upstream-tls-cert-file and --upstream-tls-key-file must be set together")
+	}
+
+	if c.ActivityInterval <= 0 {
+		return fmt.Errorf("--activity-interval must be positive, got %s", c.ActivityInterval)
+	}
 
 	switch c.AuthzMode {
 	case AuthzAllowAll, AuthzTokenReview:
@@ -258,5 +343,62 @@ func (c *Config) Validate() error {
 	if c.AuthzTokenReviewCacheSize <= 0 {
 		return fmt.Errorf("--authz-tokenreview-cache-size must be positive, got %d", c.AuthzTokenReviewCacheSize)
 	}
+	if _, _, err := c.RateLimits(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// RateLimit is a per-sandbox token bucket: RPS tokens are added per second,
+// up to Burst. An RPS of 0 means unlimited.
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+// newRateLimit validates rps and burst and fills in the default burst.
+func newRateLimit(rps float64, burst int) (RateLimit, error) {
+	if rps < 0 || math.IsNaN(rps) || math.IsInf(rps, 0) {
+		return RateLimit{}, fmt.Errorf("rate limit must be a non-negative number, got %v", rps)
+	}
+	if burst < 0 {
+		return RateLimit{}, fmt.Errorf("rate limit burst must be non-negative, got %d", burst)
+	}
+	if rps > 0 && burst == 0 {
+		burst = int(math.Ceil(rps))
+	}
+	return RateLimit{RPS: rps, Burst: burst}, nil
+}
+
+// RateLimits resolves the rate-limit settings into the default per-sandbox
+// limit and the per-namespace overrides.
+func (c *Config) RateLimits() (RateLimit, map[string]RateLimit, error) {
+	def, err := newRateLimit(c.RateLimitRPS, c.RateLimitBurst)
+	if err != nil {
+		return RateLimit{}, nil, fmt.Errorf("--rate-limit-rps/--rate-limit-burst: %w", err)
+	}
+	overrides := make(map[string]RateLimit, len(c.RateLimitNamespaceOverrides))
+	for _, entry := range c.RateLimitNamespaceOverrides {
+		ns, spec, ok := strings.Cut(entry, "=")
+		if !ok || ns == "" || spec == "" {
+			return RateLimit{}, nil, fmt.Errorf("invalid --rate-limit-namespace-overrides entry %q (want namespace=rps[:burst])", entry)
+		}
+		rpsStr, burstStr, hasBurst := strings.Cut(spec, ":")
+		rps, err := strconv.ParseFloat(rpsStr, 64)
+		if err != nil {
+			return RateLimit{}, nil, fmt.Errorf("invalid --rate-limit-namespace-overrides entry %q: %w", entry, err)
+		}
+		burst := 0
+		if hasBurst {
+			if burst, err = strconv.Atoi(burstStr); err != nil {
+				return RateLimit{}, nil, fmt.Errorf("invalid --rate-limit-namespace-overrides entry %q: %w", entry, err)
+			}
+		}
+		limit, err := newRateLimit(rps, burst)
+		if err != nil {
+			return RateLimit{}, nil, fmt.Errorf("invalid --rate-limit-namespace-overrides entry %q: %w", entry, err)
+		}
+		overrides[ns] = limit
+	}
+	return def, overrides, nil
+}
@@ -16,6 +16,8 @@ package config
 
 import (
 	"flag"
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -47,6 +49,16 @@ func TestApplyEnvDefaults(t *testing.T) {
 			env:  map[string]string{EnvClusterDomain: "prod.local"},
 			want: func(c *Config) bool { return c.ClusterDomain == "prod.local" },
 		},
+		{
+			name: "access log disabled from env",
+			env:  map[string]string{EnvAccessLog: "false"},
+			want: func(c *Config) bool { return !c.AccessLog },
+		},
+		{
+			name: "invalid access log env ignored",
+			env:  map[string]string{EnvAccessLog: "sometimes"},
+			want: func(c *Config) bool { return c.AccessLog },
+		},
 		{
 			name: "proxy timeout numeric seconds",
 			env:  map[string]string{EnvProxyTimeout: "45"},
@@ -72,6 +84,23 @@ func TestApplyEnvDefaults(t *testing.T) {
 			env:  map[string]string{EnvClusterDomain: ""},
 			want: func(c *Config) bool { return c.ClusterDomain == "cluster.local" },
 		},
+		{
+			name: "rate limits from env",
+			env: map[string]string{
+				EnvRateLimitRPS:                "2.5",
+				EnvRateLimitBurst:              "5",
+				EnvRateLimitNamespaceOverrides: "team-a=10, batch=0",
+			},
+			want: func(c *Config) bool {
+				return c.RateLimitRPS == 2.5 && c.RateLimitBurst == 5 &&
+					slices.Equal(c.RateLimitNamespaceOverrides, []string{"team-a=10", "batch=0"})
+			},
+		},
+		{
+			name: "rate limit invalid keeps default",
+			env:  map[string]string{EnvRateLimitRPS: "-3", EnvRateLimitBurst: "many"},
+			want: func(c *Config) bool { return c.RateLimitRPS == 0 && c.RateLimitBurst == 0 },
+		},
 		{
 			name: "no env keeps defaults",
 			env:  map[string]string{},
@@ -317,6 +346,36 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name:    "invalid upstream scheme",
+			mut:     func(c *Config) { c.UpstreamScheme = "ftp" },
+			wantErr: "invalid --upstream-scheme",
+		},
+		{
+			name:    "upstream client cert without key",
+			mut:     func(c *Config) { c.UpstreamTLSCertFile = "/c" },
+			wantErr: "upstream-tls-key-file",
+		},
+		{
+			name: "valid upstream mtls configuration",
+			mut: func(c *Config) {
+				c.UpstreamScheme = UpstreamSchemeHTTPS
+				c.UpstreamTLSCAFile = "/ca"
+				c.UpstreamTLSCertFile = "/c"
+				c.UpstreamTLSKeyFile = "/k"
+			},
+			wantErr: "",
+		},
+		{
+			name:    "zero activity interval",
+			mut:     func(c *Config) { c.ActivityInterval = 0 },
+			wantErr: "activity-interval",
+		},
+		{
+			name:    "negative activity interval",
+			mut:     func(c *Config) { c.ActivityInterval = -1 * time.Second },
+			wantErr: "activity-interval",
+		},
 		{
 			name:    "invalid authz mode",
 			mut:     func(c *Config) { c.AuthzMode = "bogus" },
@@ -351,6 +410,34 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name:    "negative rate limit",
+			mut:     func(c *Config) { c.RateLimitRPS = -1 },
+			wantErr: "--rate-limit-rps",
+		},
+		{
+			name:    "negative rate limit burst",
+			mut:     func(c *Config) { c.RateLimitRPS = 10; c.RateLimitBurst = -1 },
+			wantErr: "--rate-limit-burst",
+		},
+		{
+			name:    "malformed namespace override",
+			mut:     func(c *Config) { c.RateLimitNamespaceOverrides = []string{"team-a"} },
+			wantErr: "--rate-limit-namespace-overrides",
+		},
+		{
+			name:    "non-numeric namespace override",
+			mut:     func(c *Config) { c.RateLimitNamespaceOverrides = []string{"team-a=fast"} },
+			wantErr: "--rate-limit-namespace-overrides",
+		},
+		{
+			name: "valid rate limit configuration",
+			mut: func(c *Config) {
+				c.RateLimitRPS = 10
+				c.RateLimitNamespaceOverrides = []string{"team-a=100:200", "batch=0"}
+			},
+			wantErr: "",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -368,3 +455,25 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimits(t *testing.T) {
+	c := Defaults()
+	c.RateLimitRPS = 2.5
+	c.RateLimitNamespaceOverrides = []string{"team-a=100:300", "team-b=0.5", "batch=0"}
+
+	def, overrides, err := c.RateLimits()
+	if err != nil {
+		t.Fatalf("RateLimits: %v", err)
+	}
+	if want := (RateLimit{RPS: 2.5, Burst: 3}); def != want {
+		t.Errorf("default: got %+v want %+v (burst defaults to ceil(rps))", def, want)
+	}
+	want := map[string]RateLimit{
+		"team-a": {RPS: 100, Burst: 300},
+		"team-b": {RPS: 0.5, Burst: 1},
+		"batch":  {},
+	}
+	if !maps.Equal(overrides, want) {
+		t.Errorf("overrides: got %+v want %+v", overrides, want)
+	}
+}
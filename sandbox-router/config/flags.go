@@ -31,6 +31,16 @@ const (
 	// flag.
 	EnvKubeconfig = "KUBECONFIG"
 
+	// Rate-limit env vars, so the per-sandbox limits can be tuned from a
+	// Deployment's env block alongside the Python-era settings above.
+	EnvRateLimitRPS                = "RATE_LIMIT_RPS"
+	EnvRateLimitBurst              = "RATE_LIMIT_BURST"
+	EnvRateLimitNamespaceOverrides = "RATE_LIMIT_NAMESPACE_OVERRIDES"
+
+	// EnvAccessLog toggles the per-request access log ("true"/"false")
+	// without editing the container args.
+	EnvAccessLog = "ACCESS_LOG"
+
 	// Standard OpenTelemetry exporter env vars. When any of these is set
 	// and the corresponding --enable-* flag wasn't explicitly passed on
 	// the command line, the relevant signal is auto-enabled. See
@@ -62,7 +72,7 @@ func RegisterFlags(fs *flag.FlagSet, c *Config, lookup LookupEnvFunc) {
 	fs.StringVar(&c.MetricsAddr, "metrics-bind-address", c.MetricsAddr,
 		"Address for the Prometheus /metrics endpoint.")
 	fs.StringVar(&c.ProbeAddr, "health-probe-bind-address", c.ProbeAddr,
-		"Address for the /healthz and /readyz endpoints.")
+		"Address for the /healthz, /readyz and /ready endpoints.")
 
 	fs.StringVar(&c.TLSCertFile, "tls-cert-file", c.TLSCertFile,
 		"Path to the PEM-encoded server certificate.")
@@ -85,13 +95,28 @@ func RegisterFlags(fs *flag.FlagSet, c *Config, lookup LookupEnvFunc) {
 	fs.DurationVar(&c.ShutdownTimeout, "shutdown-timeout", c.ShutdownTimeout,
 		"Time budget for draining in-flight requests on SIGTERM.")
 	fs.Int64Var(&c.MaxRequestBodyBytes, "max-request-body-bytes", c.MaxRequestBodyBytes,
-		"Optional cap on inbound request body size in bytes. 0 means unlimited.")
+		"Optional cap on inbound request body size in bytes. 0 means unlimited. "+
+			"Larger requests are rejected with 413.")
+	fs.Float64Var(&c.RateLimitRPS, "rate-limit-rps", c.RateLimitRPS,
+		"Sustained requests per second allowed per sandbox. Requests over the "+
+			"limit get 429 with Retry-After. 0 disables rate limiting.")
+	fs.IntVar(&c.RateLimitBurst, "rate-limit-burst", c.RateLimitBurst,
+		"Per-sandbox burst size for --rate-limit-rps. 0 means ceil(rps).")
+	stringSliceVar(fs, &c.RateLimitNamespaceOverrides, "rate-limit-namespace-overrides",
+		"Comma-separated per-namespace rate limits as namespace=rps[:burst], "+
+			"overriding --rate-limit-rps/--rate-limit-burst for sandboxes in that "+
+			"namespace. An rps of 0 disables limiting there.")
 	fs.BoolVar(&c.AllowLoopbackPodIP, "allow-loopback-pod-ip", c.AllowLoopbackPodIP,
 		"Allow X-Sandbox-Pod-IP to carry a loopback address (127.0.0.0/8 or ::1). "+
 			"Default false (loopback rejected with 400). Enable for sidecar "+
 			"deployments where the sandbox shares a Pod with the router, or for "+
 			"integration tests using a localhost backend. Link-local, multicast, "+
 			"and unspecified addresses stay rejected regardless of this flag.")
+	fs.BoolVar(&c.PathRoutingEnabled, "path-routing-enabled", c.PathRoutingEnabled,
+		"Route requests without X-Sandbox-ID by path: /s/<namespace>/<id>/<port>/... "+
+			"is proxied to that sandbox and port with the /s/<namespace>/<id>/<port> "+
+			"prefix stripped. Requests carrying X-Sandbox-ID are routed by header "+
+			"as before.")
 	fs.IntVar(&c.UpstreamMaxRetries, "upstream-max-retries", c.UpstreamMaxRetries,
 		"Number of additional dial attempts before returning 502. Only dial-class "+
 			"failures (DNS, connection refused) are retried. Smooths the case "+
@@ -100,6 +125,17 @@ func RegisterFlags(fs *flag.FlagSet, c *Config, lookup LookupEnvFunc) {
 		"Wait before the first retry; subsequent waits double up to --upstream-retry-max-delay.")
 	fs.DurationVar(&c.UpstreamRetryMaxDelay, "upstream-retry-max-delay", c.UpstreamRetryMaxDelay,
 		"Upper bound on the per-iteration retry backoff.")
+	stringEnumVar(fs, (*string)(&c.UpstreamScheme), "upstream-scheme", string(c.UpstreamScheme),
+		"Scheme used to reach sandboxes: http (default) or https. Requests can "+
+			"override it per sandbox with the X-Sandbox-Scheme header.")
+	fs.StringVar(&c.UpstreamTLSCAFile, "upstream-tls-ca-file", c.UpstreamTLSCAFile,
+		"Path to the PEM-encoded CA bundle used to verify sandbox certificates "+
+			"on https upstreams. Empty uses the system roots.")
+	fs.StringVar(&c.UpstreamTLSCertFile, "upstream-tls-cert-file", c.UpstreamTLSCertFile,
+		"Path to the PEM-encoded client certificate presented to https upstreams "+
+			"(mTLS). Requires --upstream-tls-key-file.")
+	fs.StringVar(&c.UpstreamTLSKeyFile, "upstream-tls-key-file", c.UpstreamTLSKeyFile,
+		"Path to the PEM-encoded client private key for --upstream-tls-cert-file.")
 
 	fs.BoolVar(&c.EnableTracing, "enable-tracing", c.EnableTracing,
 		"Enable OpenTelemetry tracing via OTLP. Endpoint is taken from "+
@@ -136,8 +172,34 @@ func RegisterFlags(fs *flag.FlagSet, c *Config, lookup LookupEnvFunc) {
 			"requests carrying X-Sandbox-UID, bypassing DNS. Requires Pod "+
 			"get/list/watch RBAC and either in-cluster config or --kubeconfig.")
 	fs.StringVar(&c.CacheNamespace, "cache-namespace", c.CacheNamespace,
-		"Optional namespace filter for the Pod informer. Empty means "+
-			"cluster-wide. Ignored when --cache-enabled=false.")
+		"Optional namespace filter for the Pod, Service and EndpointSlice informers. "+
+			"Empty means cluster-wide. Ignored when --cache-enabled, --named-ports-enabled, "+
+			"--readiness-check-enabled and --session-affinity-enabled are all false.")
+	fs.BoolVar(&c.NamedPortsEnabled, "named-ports-enabled", c.NamedPortsEnabled,
+		"Enable routing by X-Sandbox-Port-Name. When on, the router watches "+
+			"sandbox Services and resolves the header against their named "+
+			"ports. Requires Service get/list/watch RBAC and either in-cluster "+
+			"config or --kubeconfig. Honors --cache-namespace.")
+	fs.BoolVar(&c.ReadinessCheckEnabled, "readiness-check-enabled", c.ReadinessCheckEnabled,
+		"Reject requests for sandboxes whose Service has no ready endpoints "+
+			"with 503 before dialing. When on, the router watches sandbox "+
+			"EndpointSlices. Only applies to DNS-routed requests. Requires "+
+			"EndpointSlice get/list/watch RBAC and either in-cluster config or "+
+			"--kubeconfig. Honors --cache-namespace.")
+	fs.BoolVar(&c.SessionAffinityEnabled, "session-affinity-enabled", c.SessionAffinityEnabled,
+		"Let requests opt into cookie session affinity with "+
+			"X-Sandbox-Session-Affinity: cookie, pinning the client to one of "+
+			"the sandbox's ready pods. When on, the router watches sandbox "+
+			"EndpointSlices. Requires EndpointSlice get/list/watch RBAC and "+
+			"either in-cluster config or --kubeconfig. Honors --cache-namespace.")
+	fs.BoolVar(&c.ActivityTrackingEnabled, "activity-tracking-enabled", c.ActivityTrackingEnabled,
+		"Record each proxied request on its Sandbox as the "+
+			"agents.x-k8s.io/last-activity annotation, which the SandboxClaim "+
+			"controller uses to shut down idle claims. Requires Sandbox patch "+
+			"RBAC and either in-cluster config or --kubeconfig.")
+	fs.DurationVar(&c.ActivityInterval, "activity-interval", c.ActivityInterval,
+		"Minimum time between two activity writes for the same sandbox. "+
+			"Only used with --activity-tracking-enabled.")
 	// controller-runtime's pkg/client/config registers a "kubeconfig"
 	// flag in its package init. Detect that and reuse the existing
 	// flag rather than redefining it (Go's flag package panics on
@@ -152,7 +214,8 @@ func RegisterFlags(fs *flag.FlagSet, c *Config, lookup LookupEnvFunc) {
 
 	fs.BoolVar(&c.AccessLog, "access-log", c.AccessLog,
 		"Emit one structured log line per inbound request on the proxy "+
-			"port. Health/metrics endpoints are skipped.")
+			"port, with the trace_id of any inbound W3C traceparent. "+
+			"Health/metrics endpoints are skipped. Honors "+EnvAccessLog+".")
 	fs.BoolVar(&c.PrintVersion, "version", c.PrintVersion,
 		"Print version information and exit.")
 	// --config is intentionally registered so it appears in --help, but the
@@ -236,6 +299,26 @@ func applyEnvDefaults(c *Config, lookup LookupEnvFunc) {
 	if v, ok := lookup(EnvKubeconfig); ok && v != "" {
 		c.Kubeconfig = v
 	}
+	if v, ok := lookup(EnvRateLimitRPS); ok && v != "" {
+		if rps, err := strconv.ParseFloat(v, 64); err == nil && rps >= 0 {
+			c.RateLimitRPS = rps
+		}
+	}
+	if v, ok := lookup(EnvRateLimitBurst); ok && v != "" {
+		if burst, err := strconv.Atoi(v); err == nil && burst >= 0 {
+			c.RateLimitBurst = burst
+		}
+	}
+	if v, ok := lookup(EnvAccessLog); ok && v != "" {
+		if on, err := strconv.ParseBool(v); err == nil {
+			c.AccessLog = on
+		}
+	}
+	if v, ok := lookup(EnvRateLimitNamespaceOverrides); ok && v != "" {
+		// Malformed entries are kept and rejected by Validate: silently
+		// dropping a namespace's limit would be worse than failing startup.
+		_ = (&csvFlag{dst: &c.RateLimitNamespaceOverrides}).Set(v)
+	}
 }
 
 // stringEnumVar registers a string flag; the dedicated function exists so the
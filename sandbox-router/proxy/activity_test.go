@@ -0,0 +1,228 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/websocket"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// fakeActivity is an ActivityRecorder that records every touched
+// sandbox as "ns/name".
+type fakeActivity struct {
+	mu      sync.Mutex
+	touched []string
+}
+
+func (f *fakeActivity) Touch(namespace, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.touched = append(f.touched, namespace+"/"+name)
+}
+
+func TestActivityRecordedForProxiedRequests(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	_, backendPort, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+
+	cases := []struct {
+		name        string
+		headers     map[string]string
+		wantStatus  int
+		wantTouched []string
+	}{
+		{
+			name: "proxied request is recorded",
+			headers: map[string]string{
+				HeaderSandboxID:        "box",
+				HeaderSandboxNamespace: "ns",
+				HeaderSandboxPodIP:     "127.0.0.1",
+				HeaderSandboxPort:      backendPort,
+			},
+			wantStatus:  http.StatusOK,
+			wantTouched: []string{"ns/box"},
+		},
+		{
+			name: "sandbox without ready endpoints is not recorded",
+			headers: map[string]string{
+				HeaderSandboxID:        "box",
+				HeaderSandboxNamespace: "ns",
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "invalid request is not recorded",
+			headers:    map[string]string{HeaderSandboxNamespace: "ns"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			activity := &fakeActivity{}
+			cfg := config.Defaults()
+			cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+			cfg.ProxyTimeout = 2 * time.Second
+			cfg.UpstreamMaxRetries = 0
+			router := httptest.NewServer(NewHandler(Options{
+				Config:    &cfg,
+				Endpoints: &fakeEndpoints{},
+				Activity:  activity,
+				Logger:    logr.Discard(),
+			}))
+			defer router.Close()
+
+			req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			activity.mu.Lock()
+			defer activity.mu.Unlock()
+			if !slices.Equal(activity.touched, tc.wantTouched) {
+				t.Fatalf("touched: got %v, want %v", activity.touched, tc.wantTouched)
+			}
+		})
+	}
+}
+
+func TestActivityRecordedWhileConnectionOpen(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	// Held well past several intervals, like an interactive session.
+	const held = 10 * interval
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isUpgradeRequest(r) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("backend upgrade: %v", err)
+				return
+			}
+			defer conn.Close()
+			// Block until the client hangs up.
+			_, _, _ = conn.ReadMessage()
+			return
+		}
+		// Streaming response: one chunk now, the rest once held has passed.
+		_, _ = w.Write([]byte("start\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(held)
+		_, _ = w.Write([]byte("end\n"))
+	}))
+	defer backend.Close()
+	backendHost, backendPort, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+	headers := http.Header{}
+	headers.Set(HeaderSandboxID, "box")
+	headers.Set(HeaderSandboxNamespace, "ns")
+	headers.Set(HeaderSandboxPodIP, backendHost)
+	headers.Set(HeaderSandboxPort, backendPort)
+
+	newRouter := func(activity *fakeActivity) *httptest.Server {
+		cfg := config.Defaults()
+		cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+		cfg.ProxyTimeout = 2 * time.Second
+		cfg.UpstreamMaxRetries = 0
+		cfg.ActivityInterval = interval
+		return httptest.NewServer(NewHandler(Options{
+			Config:   &cfg,
+			Activity: activity,
+			Logger:   logr.Discard(),
+		}))
+	}
+	touches := func(activity *fakeActivity) int {
+		activity.mu.Lock()
+		defer activity.mu.Unlock()
+		return len(activity.touched)
+	}
+	// Once the connection is closed, no further activity is recorded.
+	requireStopped := func(t *testing.T, activity *fakeActivity) {
+		t.Helper()
+		time.Sleep(interval) // let an in-flight tick land
+		closed := touches(activity)
+		time.Sleep(5 * interval)
+		if got := touches(activity); got != closed {
+			t.Fatalf("touches after close: got %d, want %d", got, closed)
+		}
+	}
+
+	t.Run("websocket", func(t *testing.T) {
+		activity := &fakeActivity{}
+		router := newRouter(activity)
+		defer router.Close()
+
+		wsURL := strings.Replace(router.URL, "http://", "ws://", 1) + "/"
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL, headers)
+		if err != nil {
+			t.Fatalf("ws dial: %v", err)
+		}
+		resp.Body.Close()
+		time.Sleep(held)
+		if got := touches(activity); got < 3 {
+			t.Fatalf("touches while connected: got %d, want at least 3", got)
+		}
+		conn.Close()
+		requireStopped(t, activity)
+	})
+
+	t.Run("streaming response", func(t *testing.T) {
+		activity := &fakeActivity{}
+		router := newRouter(activity)
+		defer router.Close()
+
+		req, _ := http.NewRequest("GET", router.URL+"/stream", nil)
+		req.Header = headers.Clone()
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		if _, err := io.ReadAll(resp.Body); err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		resp.Body.Close()
+		if got := touches(activity); got < 3 {
+			t.Fatalf("touches while streaming: got %d, want at least 3", got)
+		}
+		requireStopped(t, activity)
+	})
+}
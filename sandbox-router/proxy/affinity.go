@@ -0,0 +1,88 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+)
+
+// AffinityCookiePrefix starts the name of every session affinity cookie.
+// The full name is AffinityCookiePrefix + "<namespace>.<sandboxID>", so a
+// client talking to several sandboxes through one router keeps a separate
+// pin for each.
+const AffinityCookiePrefix = "sandbox-affinity."
+
+// AffinityCookieName returns the session affinity cookie name for the
+// sandbox namespace/id. Both are DNS labels, which are valid cookie-name
+// characters, and the dot between them can't appear in either.
+func AffinityCookieName(namespace, id string) string {
+	return AffinityCookiePrefix + namespace + "." + id
+}
+
+// applySessionAffinity pins t to one of the sandbox's ready pods and
+// returns the cookie to set on the response, if any:
+//
+//   - a cookie naming a pod that is still a ready backend routes t to
+//     that pod and is left as is;
+//   - a cookie naming anything else (the pod is gone, or the value was
+//     never ours) routes t through the Service as usual and is expired,
+//     so the next request pins afresh;
+//   - without a cookie, t is routed to a random ready pod and a cookie
+//     pinning it is returned. With no ready pods known, t goes through
+//     the Service and nothing is pinned.
+//
+// The cookie value is only ever dialed after it is found among the
+// sandbox's ready endpoint addresses, so a client can't use it to reach
+// an arbitrary IP the way an unchecked X-Sandbox-Pod-IP could. An
+// explicit X-Sandbox-Pod-IP wins over affinity and leaves t untouched.
+func (h *Handler) applySessionAffinity(r *http.Request, t *Target) (*http.Cookie, *Error) {
+	if h.backends == nil {
+		return nil, &Error{Status: http.StatusBadRequest, Detail: "Session affinity is not enabled."}
+	}
+	if t.PodIP != "" {
+		return nil, nil
+	}
+	name := AffinityCookieName(t.Namespace, t.ID)
+	addrs := h.backends.ReadyAddresses(t.Namespace, t.ID)
+	if c, err := r.Cookie(name); err == nil {
+		if slices.Contains(addrs, c.Value) {
+			t.PodIP = c.Value
+			return nil, nil
+		}
+		return affinityCookie(r, name, "", -1), nil
+	}
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	t.PodIP = addrs[rand.IntN(len(addrs))]
+	return affinityCookie(r, name, t.PodIP, 0), nil
+}
+
+// affinityCookie builds a session cookie scoped to the whole router host.
+// A negative maxAge expires it. It is marked Secure on TLS connections so
+// it is never sent back over plain HTTP.
+func affinityCookie(r *http.Request, name, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
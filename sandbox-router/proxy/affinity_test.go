@@ -0,0 +1,223 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// fakeBackends is a BackendLookup serving a mutable address list for
+// every sandbox.
+type fakeBackends struct {
+	mu    sync.Mutex
+	addrs []string
+}
+
+func (f *fakeBackends) ReadyAddresses(_, _ string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.addrs...)
+}
+
+func (f *fakeBackends) set(addrs ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addrs = addrs
+}
+
+// startPodBackends starts one backend per IP, all on the same port like
+// the pods behind a sandbox Service, each answering with its own IP.
+// Skips the test where the extra loopback addresses can't be bound
+// (only Linux routes all of 127.0.0.0/8 to lo by default).
+func startPodBackends(t *testing.T, ips ...string) int {
+	t.Helper()
+	port := 0
+	for _, ip := range ips {
+		ln, err := net.Listen("tcp", net.JoinHostPort(ip, strconv.Itoa(port)))
+		if err != nil {
+			t.Skipf("cannot listen on %s: %v", ip, err)
+		}
+		port = ln.Addr().(*net.TCPAddr).Port
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, ip)
+		}))
+		srv.Listener.Close()
+		srv.Listener = ln
+		srv.Start()
+		t.Cleanup(srv.Close)
+	}
+	return port
+}
+
+func TestSessionAffinity(t *testing.T) {
+	const podA, podB = "127.0.0.1", "127.0.0.2"
+	port := startPodBackends(t, podA, podB)
+	cookieName := AffinityCookieName("ns", "box")
+
+	backends := &fakeBackends{}
+	backends.set(podA, podB)
+	cfg := config.Defaults()
+	cfg.ProxyTimeout = 2 * time.Second
+	cfg.UpstreamMaxRetries = 0
+	// Not-ready fake endpoints make the Service route answer 503 without
+	// a DNS lookup, which marks a request that fell back to it.
+	router := httptest.NewServer(NewHandler(Options{
+		Config:    &cfg,
+		Backends:  backends,
+		Endpoints: &fakeEndpoints{},
+		Logger:    logr.Discard(),
+	}))
+	defer router.Close()
+
+	do := func(t *testing.T, cookie *http.Cookie) (*http.Response, string) {
+		t.Helper()
+		req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+		req.Header.Set(HeaderSandboxID, "box")
+		req.Header.Set(HeaderSandboxNamespace, "ns")
+		req.Header.Set(HeaderSandboxPort, strconv.Itoa(port))
+		req.Header.Set(HeaderSandboxSessionAffinity, SessionAffinityCookie)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+	affinityCookieFrom := func(resp *http.Response) *http.Cookie {
+		for _, c := range resp.Cookies() {
+			if c.Name == cookieName {
+				return c
+			}
+		}
+		return nil
+	}
+
+	// First request: pinned to one of the pods, which the cookie names.
+	resp, pinned := do(t, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d, want 200", resp.StatusCode)
+	}
+	cookie := affinityCookieFrom(resp)
+	if cookie == nil {
+		t.Fatalf("expected a %s cookie, got %v", cookieName, resp.Header.Values("Set-Cookie"))
+	}
+	if cookie.Value != pinned {
+		t.Fatalf("cookie pins %q but request was served by %q", cookie.Value, pinned)
+	}
+	if !cookie.HttpOnly || cookie.Path != "/" {
+		t.Errorf("cookie attributes: got %+v, want HttpOnly with Path=/", cookie)
+	}
+
+	// Requests carrying the cookie all land on the pinned pod.
+	for i := range 10 {
+		resp, servedBy := do(t, cookie)
+		if resp.StatusCode != http.StatusOK || servedBy != pinned {
+			t.Fatalf("request %d: got %d from %q, want 200 from %q", i, resp.StatusCode, servedBy, pinned)
+		}
+		if c := affinityCookieFrom(resp); c != nil {
+			t.Fatalf("request %d: valid cookie should not be reset, got %+v", i, c)
+		}
+	}
+
+	// The pinned pod goes away: the request falls back to the Service
+	// and the stale cookie is expired.
+	other := podA
+	if pinned == podA {
+		other = podB
+	}
+	backends.set(other)
+	resp, _ = do(t, cookie)
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("stale cookie: got %d, want 503 from the Service route", resp.StatusCode)
+	}
+	if c := affinityCookieFrom(resp); c == nil || c.MaxAge >= 0 {
+		t.Fatalf("stale cookie should be expired, got %+v", c)
+	}
+
+	// Without the cookie, the client is pinned to the remaining pod.
+	resp, servedBy := do(t, nil)
+	if resp.StatusCode != http.StatusOK || servedBy != other {
+		t.Fatalf("re-pin: got %d from %q, want 200 from %q", resp.StatusCode, servedBy, other)
+	}
+	if c := affinityCookieFrom(resp); c == nil || c.Value != other {
+		t.Fatalf("re-pin cookie: got %+v, want %q", c, other)
+	}
+
+	// A forged cookie is never dialed.
+	resp, _ = do(t, &http.Cookie{Name: cookieName, Value: "169.254.169.254"})
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("forged cookie: got %d, want 503 from the Service route", resp.StatusCode)
+	}
+}
+
+func TestSessionAffinityRejected(t *testing.T) {
+	cases := []struct {
+		name       string
+		backends   BackendLookup
+		value      string
+		wantDetail string
+	}{
+		{
+			name:       "unsupported mode",
+			backends:   &fakeBackends{},
+			value:      "source-ip",
+			wantDetail: "Invalid session affinity format.",
+		},
+		{
+			name:       "affinity disabled",
+			value:      SessionAffinityCookie,
+			wantDetail: "Session affinity is not enabled.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Defaults()
+			h := NewHandler(Options{Config: &cfg, Backends: tc.backends, Logger: logr.Discard()})
+
+			req := httptest.NewRequest(http.MethodGet, "/x", nil)
+			req.Header.Set(HeaderSandboxID, "box")
+			req.Header.Set(HeaderSandboxSessionAffinity, tc.value)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status: got %d, want 400", rec.Code)
+			}
+			var body errorBody
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Detail != tc.wantDetail {
+				t.Fatalf("detail: got %q, want %q", body.Detail, tc.wantDetail)
+			}
+		})
+	}
+}
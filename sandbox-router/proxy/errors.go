@@ -16,15 +16,50 @@ package proxy
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 )
 
+// HeaderSandboxErrorReason is set on upstream failure responses so clients
+// can tell a sandbox that is still starting from one that is broken without
+// parsing the detail text.
+const HeaderSandboxErrorReason = "X-Sandbox-Error-Reason"
+
+// Values of HeaderSandboxErrorReason.
+const (
+	// ErrorReasonSandboxNotReady means the sandbox Service has no endpoints
+	// yet. The request is safe to retry after Retry-After.
+	ErrorReasonSandboxNotReady = "SandboxNotReady"
+	// ErrorReasonUpstreamTimeout means the sandbox did not answer in time.
+	ErrorReasonUpstreamTimeout = "UpstreamTimeout"
+	// ErrorReasonBadGateway covers every other upstream failure.
+	ErrorReasonBadGateway = "BadGateway"
+	// ErrorReasonRateLimited means the sandbox's request budget is
+	// exhausted. The request is safe to retry after Retry-After.
+	ErrorReasonRateLimited = "RateLimited"
+	// ErrorReasonRequestTooLarge means the request body exceeded
+	// --max-request-body-bytes.
+	ErrorReasonRequestTooLarge = "RequestTooLarge"
+)
+
+// notReadyRetryAfter is the Retry-After hint sent with SandboxNotReady.
+const notReadyRetryAfter = time.Second
+
 // Error carries an HTTP status code and a human-readable detail. It is
 // serialized as `{"detail": "..."}` to match the Python router's error shape
 // so that existing clients parsing those bodies keep working.
 type Error struct {
 	Status int
 	Detail string
+	// Reason, when set, is sent in the X-Sandbox-Error-Reason header.
+	Reason string
+	// RetryAfter, when set, is sent in the Retry-After header, rounded
+	// up to whole seconds.
+	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
@@ -44,6 +79,70 @@ type errorBody struct {
 // useful to do.
 func WriteJSONError(w http.ResponseWriter, e *Error) {
 	w.Header().Set("Content-Type", "application/json")
+	if e.Reason != "" {
+		w.Header().Set(HeaderSandboxErrorReason, e.Reason)
+	}
+	if e.RetryAfter > 0 {
+		seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
 	w.WriteHeader(e.Status)
 	_ = json.NewEncoder(w).Encode(errorBody{Detail: e.Detail})
 }
+
+// upstreamError maps a failed upstream round trip for sandbox id to the
+// response sent back to the client:
+//
+//   - 503 when the sandbox DNS name does not resolve, which for a headless
+//     Service means it has no ready endpoints yet;
+//   - 504 when the dial or the response timed out;
+//   - 502 for everything else.
+func upstreamError(id string, err error) *Error {
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return sandboxNotReadyError(id)
+	case classifyError(err) == "timeout":
+		return &Error{
+			Status: http.StatusGatewayTimeout,
+			Detail: fmt.Sprintf("Timed out waiting for the backend sandbox: %s", id),
+			Reason: ErrorReasonUpstreamTimeout,
+		}
+	}
+	return &Error{
+		Status: http.StatusBadGateway,
+		Detail: fmt.Sprintf("Could not connect to the backend sandbox: %s", id),
+		Reason: ErrorReasonBadGateway,
+	}
+}
+
+// sandboxNotReadyError is the 503 sent while sandbox id has no ready
+// endpoints.
+func sandboxNotReadyError(id string) *Error {
+	return &Error{
+		Status:     http.StatusServiceUnavailable,
+		Detail:     fmt.Sprintf("The backend sandbox is not ready: %s", id),
+		Reason:     ErrorReasonSandboxNotReady,
+		RetryAfter: notReadyRetryAfter,
+	}
+}
+
+// rateLimitedError is the 429 sent when sandbox id is over its rate limit.
+func rateLimitedError(id string, retryAfter time.Duration) *Error {
+	return &Error{
+		Status:     http.StatusTooManyRequests,
+		Detail:     fmt.Sprintf("Rate limit exceeded for sandbox: %s", id),
+		Reason:     ErrorReasonRateLimited,
+		RetryAfter: retryAfter,
+	}
+}
+
+// requestTooLargeError is the 413 sent when the request body exceeds limit
+// bytes.
+func requestTooLargeError(limit int64) *Error {
+	return &Error{
+		Status: http.StatusRequestEntityTooLarge,
+		Detail: fmt.Sprintf("Request body exceeds the %d byte limit", limit),
+		Reason: ErrorReasonRequestTooLarge,
+	}
+}
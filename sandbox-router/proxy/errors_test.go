@@ -15,11 +15,16 @@
 package proxy
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 func TestWriteJSONError(t *testing.T) {
@@ -51,3 +56,89 @@ func TestErrorImplementsError(t *testing.T) {
 		t.Fatalf("Error() got %q want %q", err.Error(), "boom")
 	}
 }
+
+func TestWriteJSONErrorReasonHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSONError(rec, &Error{
+		Status:     http.StatusServiceUnavailable,
+		Detail:     "not ready",
+		Reason:     ErrorReasonSandboxNotReady,
+		RetryAfter: 1500 * time.Millisecond,
+	})
+
+	if got := rec.Header().Get(HeaderSandboxErrorReason); got != ErrorReasonSandboxNotReady {
+		t.Errorf("reason header: got %q want %q", got, ErrorReasonSandboxNotReady)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After: got %q want 2", got)
+	}
+
+	// Validation errors carry neither header.
+	rec = httptest.NewRecorder()
+	WriteJSONError(rec, &Error{Status: http.StatusBadRequest, Detail: "bad"})
+	if got := rec.Header().Get(HeaderSandboxErrorReason); got != "" {
+		t.Errorf("reason header should be unset; got %q", got)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "" {
+		t.Errorf("Retry-After should be unset; got %q", got)
+	}
+}
+
+func TestUpstreamError(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantReason string
+		wantRetry  bool
+	}{
+		{
+			name:       "DNS name not found",
+			err:        &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "box.ns.svc.cluster.local", IsNotFound: true}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantReason: ErrorReasonSandboxNotReady,
+			wantRetry:  true,
+		},
+		{
+			name:       "DNS lookup timeout",
+			err:        &net.OpError{Op: "dial", Err: &net.DNSError{Err: "i/o timeout", Name: "box.ns.svc.cluster.local", IsTimeout: true}},
+			wantStatus: http.StatusGatewayTimeout,
+			wantReason: ErrorReasonUpstreamTimeout,
+		},
+		{
+			name:       "proxy timeout",
+			err:        context.DeadlineExceeded,
+			wantStatus: http.StatusGatewayTimeout,
+			wantReason: ErrorReasonUpstreamTimeout,
+		},
+		{
+			name:       "connection refused",
+			err:        &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED},
+			wantStatus: http.StatusBadGateway,
+			wantReason: ErrorReasonBadGateway,
+		},
+		{
+			name:       "unexpected EOF",
+			err:        errors.New("unexpected EOF"),
+			wantStatus: http.StatusBadGateway,
+			wantReason: ErrorReasonBadGateway,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := upstreamError("my-box", tc.err)
+			if got.Status != tc.wantStatus {
+				t.Errorf("status: got %d want %d", got.Status, tc.wantStatus)
+			}
+			if got.Reason != tc.wantReason {
+				t.Errorf("reason: got %q want %q", got.Reason, tc.wantReason)
+			}
+			if (got.RetryAfter > 0) != tc.wantRetry {
+				t.Errorf("RetryAfter: got %s, want set=%v", got.RetryAfter, tc.wantRetry)
+			}
+			if !strings.Contains(got.Detail, "my-box") {
+				t.Errorf("detail should mention sandbox id; got %q", got.Detail)
+			}
+		})
+	}
+}
@@ -19,18 +19,26 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 // Header names the router consumes. Kept exported so tests and downstream
 // integrations have a single source of truth.
 const (
-	HeaderSandboxID        = "X-Sandbox-Id"
-	HeaderSandboxUID       = "X-Sandbox-Uid"
-	HeaderSandboxNamespace = "X-Sandbox-Namespace"
-	HeaderSandboxPort      = "X-Sandbox-Port"
-	HeaderSandboxPodIP     = "X-Sandbox-Pod-Ip"
+	HeaderSandboxID              = "X-Sandbox-Id"
+	HeaderSandboxUID             = "X-Sandbox-Uid"
+	HeaderSandboxNamespace       = "X-Sandbox-Namespace"
+	HeaderSandboxPort            = "X-Sandbox-Port"
+	HeaderSandboxPortName        = "X-Sandbox-Port-Name"
+	HeaderSandboxPodIP           = "X-Sandbox-Pod-Ip"
+	HeaderSandboxScheme          = "X-Sandbox-Scheme"
+	HeaderSandboxSessionAffinity = "X-Sandbox-Session-Affinity"
 )
 
+// SessionAffinityCookie is the X-Sandbox-Session-Affinity value that pins
+// a client to one sandbox pod with a cookie.
+const SessionAffinityCookie = "cookie"
+
 // Defaults preserved from the Python router.
 const (
 	DefaultSandboxNamespace = "default"
@@ -52,11 +60,23 @@ type Target struct {
 	Namespace string
 	// Port is the upstream port.
 	Port int
+	// PortName is the optional named port from X-Sandbox-Port-Name. When
+	// set, the handler resolves it against the sandbox Service's named
+	// ports and overwrites Port with the result; X-Sandbox-Port and the
+	// default port only apply when no name is given.
+	PortName string
 	// PodIP is the optional direct pod IP from X-Sandbox-Pod-IP. When set,
 	// both DNS and cache lookups are bypassed and the proxy dials this IP
 	// directly. Lets a caller (typically an SDK that just created the
 	// Sandbox) skip the discovery hop entirely.
 	PodIP string
+	// Scheme is the optional upstream scheme from X-Sandbox-Scheme,
+	// "http" or "https". Empty means the router's --upstream-scheme.
+	Scheme string
+	// SessionAffinity is set when X-Sandbox-Session-Affinity asks for
+	// cookie affinity. The handler then pins the client to one of the
+	// sandbox's ready pods unless PodIP already names one.
+	SessionAffinity bool
 }
 
 // ParseOptions controls validation behaviors that need to differ
@@ -111,6 +131,13 @@ func ParseSandboxHeaders(h http.Header, opts ParseOptions) (Target, *Error) {
 		port = n
 	}
 
+	// A port name is only syntax-checked here; resolving it to a number
+	// needs the Service informer and happens in the handler.
+	portName := h.Get(HeaderSandboxPortName)
+	if portName != "" && !validPortName(portName) {
+		return Target{}, &Error{Status: http.StatusBadRequest, Detail: "Invalid port name format."}
+	}
+
 	podIP := h.Get(HeaderSandboxPodIP)
 	if podIP != "" && !validPodIP(podIP, opts.AllowLoopbackPodIP) {
 		// validPodIP folds the parse + class check into one decision.
@@ -120,15 +147,55 @@ func ParseSandboxHeaders(h http.Header, opts ParseOptions) (Target, *Error) {
 		return Target{}, &Error{Status: http.StatusBadRequest, Detail: "Invalid target IP address."}
 	}
 
+	scheme := strings.ToLower(h.Get(HeaderSandboxScheme))
+	if scheme != "" && scheme != "http" && scheme != "https" {
+		return Target{}, &Error{Status: http.StatusBadRequest, Detail: "Invalid scheme format."}
+	}
+
+	affinity := strings.ToLower(h.Get(HeaderSandboxSessionAffinity))
+	if affinity != "" && affinity != SessionAffinityCookie {
+		return Target{}, &Error{Status: http.StatusBadRequest, Detail: "Invalid session affinity format."}
+	}
+
 	return Target{
-		ID:        id,
-		UID:       h.Get(HeaderSandboxUID),
-		Namespace: ns,
-		Port:      port,
-		PodIP:     podIP,
+		ID:              id,
+		UID:             h.Get(HeaderSandboxUID),
+		Namespace:       ns,
+		Port:            port,
+		PortName:        portName,
+		PodIP:           podIP,
+		Scheme:          scheme,
+		SessionAffinity: affinity == SessionAffinityCookie,
 	}, nil
 }
 
+// validPortName reports whether s is a valid Kubernetes port name
+// (IANA_SVC_NAME): at most 15 characters of lowercase alphanumerics and
+// '-', containing at least one letter, with no leading, trailing, or
+// adjacent hyphens. Anything else can never match a Service port, so we
+// reject it up front rather than spend a lookup on it.
+func validPortName(s string) bool {
+	if s == "" || len(s) > 15 {
+		return false
+	}
+	hasLetter := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case c >= 'a' && c <= 'z':
+			hasLetter = true
+		case c == '-':
+			if i == 0 || i == len(s)-1 || s[i-1] == '-' {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return hasLetter
+}
+
 // validDNSLabel reports whether s is a syntactically valid DNS-1123
 // label (RFC 1123). Mirrors the Python router's
 //
@@ -60,6 +60,26 @@ func TestParseSandboxHeaders(t *testing.T) {
 			headers: map[string]string{HeaderSandboxID: "my-box", HeaderSandboxNamespace: "my-ns-1"},
 			want:    Target{ID: "my-box", Namespace: "my-ns-1", Port: DefaultSandboxPort},
 		},
+		{
+			name:    "scheme header captured case-insensitively",
+			headers: map[string]string{HeaderSandboxID: "my-box", HeaderSandboxScheme: "HTTPS"},
+			want:    Target{ID: "my-box", Namespace: DefaultSandboxNamespace, Port: DefaultSandboxPort, Scheme: "https"},
+		},
+		{
+			name:    "session affinity header captured case-insensitively",
+			headers: map[string]string{HeaderSandboxID: "my-box", HeaderSandboxSessionAffinity: "Cookie"},
+			want:    Target{ID: "my-box", Namespace: DefaultSandboxNamespace, Port: DefaultSandboxPort, SessionAffinity: true},
+		},
+		{
+			name:     "unsupported session affinity rejected",
+			headers:  map[string]string{HeaderSandboxID: "my-box", HeaderSandboxSessionAffinity: "client-ip"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported scheme rejected",
+			headers:  map[string]string{HeaderSandboxID: "my-box", HeaderSandboxScheme: "ftp"},
+			wantCode: http.StatusBadRequest,
+		},
 		{
 			name:     "missing sandbox id rejected",
 			headers:  map[string]string{},
@@ -110,6 +130,36 @@ func TestParseSandboxHeaders(t *testing.T) {
 			headers: map[string]string{HeaderSandboxID: "x", HeaderSandboxPort: "65535"},
 			want:    Target{ID: "x", Namespace: DefaultSandboxNamespace, Port: 65535},
 		},
+		{
+			name:    "port name captured alongside default port",
+			headers: map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "jupyter"},
+			want:    Target{ID: "x", Namespace: DefaultSandboxNamespace, Port: DefaultSandboxPort, PortName: "jupyter"},
+		},
+		{
+			name:    "port name with digits and hyphen accepted",
+			headers: map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "http-8080"},
+			want:    Target{ID: "x", Namespace: DefaultSandboxNamespace, Port: DefaultSandboxPort, PortName: "http-8080"},
+		},
+		{
+			name:     "port name all digits rejected",
+			headers:  map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "8080"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "port name uppercase rejected",
+			headers:  map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "HTTP"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "port name double hyphen rejected",
+			headers:  map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "a--b"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "port name over 15 chars rejected",
+			headers:  map[string]string{HeaderSandboxID: "x", HeaderSandboxPortName: "abcdefghijklmnop"},
+			wantCode: http.StatusBadRequest,
+		},
 
 		// ---- DNS-label validation on ID (matches Python's _is_valid_dns_label) ----
 		// These are the inputs the Python router blocks specifically
@@ -0,0 +1,81 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PathRoutingPrefix starts every path-routed request:
+//
+//	/s/<namespace>/<sandboxID>/<port>/<rest>
+//
+// Browser-based tools can't attach X-Sandbox-* headers to navigations,
+// sub-resource loads or WebSocket handshakes, so with
+// --path-routing-enabled the target can ride in the URL instead.
+const PathRoutingPrefix = "/s/"
+
+// HasSandboxPathPrefix reports whether path is in the path-routed form
+// and should be handed to ParseSandboxPath.
+func HasSandboxPathPrefix(path string) bool {
+	return strings.HasPrefix(path, PathRoutingPrefix)
+}
+
+// ParseSandboxPath extracts the target from a path of the form
+// /s/<namespace>/<sandboxID>/<port>/<rest> and returns it together with
+// the path to forward upstream, "/<rest>". The namespace and ID get the
+// same DNS-label check as their headers and the port the same range
+// check as X-Sandbox-Port, so a path can't smuggle anything into the
+// upstream URL that a header couldn't.
+//
+// The prefix is stripped without touching <rest>, so a trailing slash
+// survives: /s/ns/box/8888/lab/ forwards /lab/ and /s/ns/box/8888/lab
+// forwards /lab. A path that ends at the port, with or without a
+// trailing slash, forwards "/".
+//
+// Only namespace, ID and port come from the path; the optional
+// X-Sandbox-* headers are not consulted for path-routed requests.
+func ParseSandboxPath(path string) (Target, string, *Error) {
+	rest, ok := strings.CutPrefix(path, PathRoutingPrefix)
+	if !ok {
+		return Target{}, "", &Error{Status: http.StatusBadRequest, Detail: "Invalid sandbox path."}
+	}
+	// At most four parts: namespace, ID, port and everything after the
+	// port, which is forwarded as-is.
+	parts := strings.SplitN(rest, "/", 4)
+	if len(parts) < 3 {
+		return Target{}, "", &Error{Status: http.StatusBadRequest, Detail: "Invalid sandbox path."}
+	}
+	ns, id, rawPort := parts[0], parts[1], parts[2]
+
+	if !validDNSLabel(ns) {
+		return Target{}, "", &Error{Status: http.StatusBadRequest, Detail: "Invalid namespace format."}
+	}
+	if !validDNSLabel(id) {
+		return Target{}, "", &Error{Status: http.StatusBadRequest, Detail: "Invalid sandbox ID format."}
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil || port < 1 || port > 65535 {
+		return Target{}, "", &Error{Status: http.StatusBadRequest, Detail: "Invalid port format."}
+	}
+
+	upstreamPath := "/"
+	if len(parts) == 4 {
+		upstreamPath += parts[3]
+	}
+	return Target{ID: id, Namespace: ns, Port: port}, upstreamPath, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+func TestParseSandboxPath(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		want       Target
+		wantPath   string
+		wantDetail string // empty means success
+	}{
+		{
+			name:     "rest of path forwarded",
+			path:     "/s/prod/my-box/9000/api/v1/run",
+			want:     Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath: "/api/v1/run",
+		},
+		{
+			name:     "trailing slash preserved",
+			path:     "/s/prod/my-box/9000/lab/",
+			want:     Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath: "/lab/",
+		},
+		{
+			name:     "path ending at port forwards root",
+			path:     "/s/prod/my-box/9000",
+			want:     Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath: "/",
+		},
+		{
+			name:     "path ending at port with slash forwards root",
+			path:     "/s/prod/my-box/9000/",
+			want:     Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath: "/",
+		},
+		{
+			name:     "repeated prefix in rest is not stripped",
+			path:     "/s/prod/my-box/9000/s/other/box/80/",
+			want:     Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath: "/s/other/box/80/",
+		},
+		{
+			name:       "missing port rejected",
+			path:       "/s/prod/my-box",
+			wantDetail: "Invalid sandbox path.",
+		},
+		{
+			name:       "missing prefix rejected",
+			path:       "/prod/my-box/9000/",
+			wantDetail: "Invalid sandbox path.",
+		},
+		{
+			name:       "empty namespace rejected",
+			path:       "/s//my-box/9000/",
+			wantDetail: "Invalid namespace format.",
+		},
+		{
+			name:       "uppercase namespace rejected",
+			path:       "/s/Prod/my-box/9000/",
+			wantDetail: "Invalid namespace format.",
+		},
+		{
+			name:       "dotted sandbox id rejected",
+			path:       "/s/prod/foo.evil.com/9000/",
+			wantDetail: "Invalid sandbox ID format.",
+		},
+		{
+			name:       "traversal sandbox id rejected",
+			path:       "/s/prod/../9000/",
+			wantDetail: "Invalid sandbox ID format.",
+		},
+		{
+			name:       "non-numeric port rejected",
+			path:       "/s/prod/my-box/http/",
+			wantDetail: "Invalid port format.",
+		},
+		{
+			name:       "zero port rejected",
+			path:       "/s/prod/my-box/0/",
+			wantDetail: "Invalid port format.",
+		},
+		{
+			name:       "port above range rejected",
+			path:       "/s/prod/my-box/65536/",
+			wantDetail: "Invalid port format.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotPath, perr := ParseSandboxPath(tc.path)
+			if tc.wantDetail != "" {
+				if perr == nil {
+					t.Fatalf("expected error %q, got target %+v", tc.wantDetail, got)
+				}
+				if perr.Status != http.StatusBadRequest || perr.Detail != tc.wantDetail {
+					t.Fatalf("error: got %d %q, want 400 %q", perr.Status, perr.Detail, tc.wantDetail)
+				}
+				return
+			}
+			if perr != nil {
+				t.Fatalf("unexpected error: %+v", perr)
+			}
+			if got != tc.want {
+				t.Errorf("target: got %+v, want %+v", got, tc.want)
+			}
+			if gotPath != tc.wantPath {
+				t.Errorf("upstream path: got %q, want %q", gotPath, tc.wantPath)
+			}
+		})
+	}
+}
+
+func TestHandlerParseTarget(t *testing.T) {
+	cases := []struct {
+		name        string
+		pathRouting bool
+		path        string
+		headers     map[string]string
+		want        Target
+		wantPath    string
+		wantDetail  string
+	}{
+		{
+			name:        "path routed when enabled",
+			pathRouting: true,
+			path:        "/s/prod/my-box/9000/lab/tree",
+			want:        Target{ID: "my-box", Namespace: "prod", Port: 9000},
+			wantPath:    "/lab/tree",
+		},
+		{
+			name:        "header wins over prefixed path",
+			pathRouting: true,
+			path:        "/s/prod/my-box/9000/",
+			headers:     map[string]string{HeaderSandboxID: "other-box"},
+			want:        Target{ID: "other-box", Namespace: DefaultSandboxNamespace, Port: DefaultSandboxPort},
+			wantPath:    "/s/prod/my-box/9000/",
+		},
+		{
+			name:        "unprefixed path still needs the header",
+			pathRouting: true,
+			path:        "/lab/tree",
+			wantDetail:  "X-Sandbox-ID header is required.",
+		},
+		{
+			name:       "prefixed path ignored when disabled",
+			path:       "/s/prod/my-box/9000/",
+			wantDetail: "X-Sandbox-ID header is required.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Defaults()
+			cfg.PathRoutingEnabled = tc.pathRouting
+			h := NewHandler(Options{Config: &cfg})
+
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			got, gotPath, perr := h.parseTarget(r)
+			if tc.wantDetail != "" {
+				if perr == nil || perr.Detail != tc.wantDetail {
+					t.Fatalf("error: got %+v, want %q", perr, tc.wantDetail)
+				}
+				return
+			}
+			if perr != nil {
+				t.Fatalf("unexpected error: %+v", perr)
+			}
+			if got != tc.want {
+				t.Errorf("target: got %+v, want %+v", got, tc.want)
+			}
+			if gotPath != tc.wantPath {
+				t.Errorf("upstream path: got %q, want %q", gotPath, tc.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// fakePorts is a static PortLookup keyed by "ns/name/portName".
+type fakePorts map[string]int
+
+func (f fakePorts) ResolvePort(namespace, name, portName string) (int, bool) {
+	p, ok := f[namespace+"/"+name+"/"+portName]
+	return p, ok
+}
+
+func TestNamedPortRouting(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	_, portStr, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+	backendPort, _ := strconv.Atoi(portStr)
+
+	ports := fakePorts{
+		"ns/box/jupyter": backendPort,
+		"ns/box/broken":  70000,
+	}
+
+	cases := []struct {
+		name       string
+		ports      PortLookup
+		portName   string
+		port       string
+		wantStatus int
+		wantDetail string
+	}{
+		{
+			name:       "name resolves to backend port",
+			ports:      ports,
+			portName:   "jupyter",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "name takes precedence over numeric port",
+			ports:      ports,
+			portName:   "jupyter",
+			port:       pickFreePortStr(t),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown name rejected",
+			ports:      ports,
+			portName:   "ssh",
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "Unknown port name: ssh",
+		},
+		{
+			name:       "out of range resolved port rejected",
+			ports:      ports,
+			portName:   "broken",
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "Invalid port format.",
+		},
+		{
+			name:       "named ports disabled",
+			portName:   "jupyter",
+			wantStatus: http.StatusBadRequest,
+			wantDetail: "Named port routing is not enabled.",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Defaults()
+			cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+			cfg.ProxyTimeout = 2 * time.Second
+			cfg.UpstreamMaxRetries = 0
+			router := httptest.NewServer(NewHandler(Options{Config: &cfg, Ports: tc.ports, Logger: logr.Discard()}))
+			defer router.Close()
+
+			req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+			req.Header.Set(HeaderSandboxID, "box")
+			req.Header.Set(HeaderSandboxNamespace, "ns")
+			req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+			req.Header.Set(HeaderSandboxPortName, tc.portName)
+			if tc.port != "" {
+				req.Header.Set(HeaderSandboxPort, tc.port)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantDetail != "" {
+				var body errorBody
+				if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if body.Detail != tc.wantDetail {
+					t.Fatalf("detail: got %q, want %q", body.Detail, tc.wantDetail)
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package proxy
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -43,6 +44,11 @@ type Handler struct {
 	propagator propagation.TextMapPropagator
 	transport  http.RoundTripper
 	cache      Lookup
+	ports      PortLookup
+	endpoints  EndpointLookup
+	backends   BackendLookup
+	activity   ActivityRecorder
+	limiter    *sandboxLimiter
 	authz      authz.Authorizer
 	log        logr.Logger
 }
@@ -59,11 +65,28 @@ type Options struct {
 	// nil, the handler resolves every request via DNS — useful for tests
 	// and for deployments running without RBAC for Pod informers.
 	Cache Lookup
+	// Ports resolves X-Sandbox-Port-Name against sandbox Service ports.
+	// When nil, requests carrying a port name are rejected with 400.
+	Ports PortLookup
+	// Endpoints short-circuits DNS-routed requests for sandboxes whose
+	// Service has no ready endpoints with a 503. When nil, such requests
+	// are dialed and fail (or succeed) on their own.
+	Endpoints EndpointLookup
+	// Backends lists a sandbox's ready pods for X-Sandbox-Session-Affinity.
+	// When nil, requests asking for session affinity are rejected with 400.
+	Backends BackendLookup
+	// Activity records each proxied request on its Sandbox so idle claims
+	// can be shut down. When nil, no activity is recorded.
+	Activity ActivityRecorder
 	// Authorizer guards every proxied request. When nil, the handler
 	// uses authz.AllowAll — the Python-compatible default. Set this to
 	// a TokenReview authorizer to enforce per-sandbox auth (KEP-NNNN).
 	Authorizer authz.Authorizer
-	Logger     logr.Logger
+	// UpstreamTLS configures TLS for https upstreams: the CA bundle used
+	// to verify sandboxes and, for mTLS, the client certificate. When nil,
+	// https upstreams are verified against the system roots.
+	UpstreamTLS *tls.Config
+	Logger      logr.Logger
 }
 
 // NewHandler builds a Handler from o.
@@ -74,7 +97,7 @@ func NewHandler(o Options) *Handler {
 	if o.Propagator == nil {
 		o.Propagator = propagation.TraceContext{}
 	}
-	var tr http.RoundTripper = defaultTransport(o.Config)
+	var tr http.RoundTripper = defaultTransport(o.Config, o.UpstreamTLS)
 	// Wrap with retry only if max-retries > 0. The transport is unchanged
 	// when retries are disabled so the request path stays a single Dial.
 	if o.Config.UpstreamMaxRetries > 0 {
@@ -99,12 +122,23 @@ func NewHandler(o Options) *Handler {
 	if authorizer == nil {
 		authorizer = authz.AllowAll{}
 	}
+	// Config.Validate has already rejected malformed limits; an error here
+	// means the caller skipped it, so fail loudly like a missing Config.
+	defLimit, nsLimits, err := o.Config.RateLimits()
+	if err != nil {
+		panic("proxy.NewHandler: " + err.Error())
+	}
 	return &Handler{
 		cfg:        o.Config,
 		metrics:    o.Metrics,
 		propagator: o.Propagator,
 		transport:  tr,
 		cache:      o.Cache,
+		ports:      o.Ports,
+		endpoints:  o.Endpoints,
+		backends:   o.Backends,
+		activity:   o.Activity,
+		limiter:    newSandboxLimiter(defLimit, nsLimits),
 		authz:      authorizer,
 		log:        o.Logger,
 	}
@@ -112,9 +146,7 @@ func NewHandler(o Options) *Handler {
 
 // ServeHTTP implements http.Handler.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	target, perr := ParseSandboxHeaders(r.Header, ParseOptions{
-		AllowLoopbackPodIP: h.cfg.AllowLoopbackPodIP,
-	})
+	target, upstreamPath, perr := h.parseTarget(r)
 	if perr != nil {
 		WriteJSONError(w, perr)
 		return
@@ -149,11 +181,80 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.metrics.AuthzDecisionsTotal.WithLabelValues(target.Namespace, "allow").Inc()
 	}
 
+	// Rate limiting runs after authorization so unauthenticated callers
+	// can't drain another tenant's bucket.
+	if h.limiter != nil {
+		if ok, retryAfter := h.limiter.Allow(target.Namespace, target.ID); !ok {
+			WriteJSONError(w, rateLimitedError(target.ID, retryAfter))
+			return
+		}
+	}
+
+	// Reject oversized bodies up front when the client declared a length;
+	// chunked bodies are capped by MaxBytesReader and surface as a 413
+	// from the ErrorHandler once the limit is crossed mid-copy.
+	if limit := h.cfg.MaxRequestBodyBytes; limit > 0 {
+		if r.ContentLength > limit {
+			WriteJSONError(w, requestTooLargeError(limit))
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+	}
+
+	// Named-port routing. Resolved after authorization so an
+	// unauthorized caller can't use the 400 to enumerate port names.
+	if target.PortName != "" {
+		port, perr := h.resolvePortName(target)
+		if perr != nil {
+			WriteJSONError(w, perr)
+			return
+		}
+		target.Port = port
+	}
+
+	// Cookie affinity. Also resolved after authorization, so a denied
+	// caller never learns a pod IP from the cookie.
+	if target.SessionAffinity {
+		cookie, perr := h.applySessionAffinity(r, &target)
+		if perr != nil {
+			WriteJSONError(w, perr)
+			return
+		}
+		// Set before proxying: ReverseProxy adds the upstream's headers
+		// to these, and error responses carry it too.
+		if cookie != nil {
+			http.SetCookie(w, cookie)
+		}
+	}
+
 	target0 := target // capture for closures
 	// Resolve once per request so the ErrorHandler can see which path
 	// produced the IP (cache vs DNS vs override) and invalidate the cache
 	// entry on dial-class failures. The Rewrite callback re-uses the URL.
-	upstreamURL, src := target0.Resolve("http", h.cfg.ClusterDomain, r.URL.Path, r.URL.RawQuery, h.cache)
+	scheme := target0.Scheme
+	if scheme == "" {
+		scheme = string(h.cfg.UpstreamScheme)
+	}
+	upstreamURL, src := target0.Resolve(scheme, h.cfg.ClusterDomain, upstreamPath, r.URL.RawQuery, h.cache)
+	// Only the DNS path goes through the sandbox Service; a Pod IP from
+	// the header or the cache is dialed directly and may be reachable
+	// before (or without) the Service reporting it ready.
+	if src == SourceDNS && h.endpoints != nil && !h.endpoints.HasReadyEndpoints(target0.Namespace, target0.ID) {
+		WriteJSONError(w, sandboxNotReadyError(target0.ID))
+		return
+	}
+	// Only requests that made it past every check count as activity, so
+	// rejected or rate-limited callers can't keep a sandbox alive.
+	if h.activity != nil {
+		h.activity.Touch(target0.Namespace, target0.ID)
+		// A WebSocket or streaming response can stay open for the whole
+		// session, long after this first touch. Keep touching while it is
+		// proxied so idleTimeout doesn't expire a sandbox with a user still
+		// connected.
+		defer h.touchWhileOpen(target0.Namespace, target0.ID)()
+	}
 	// Detect Upgrade once and reuse: the Rewrite callback uses it to
 	// decide whether to strip Origin, the timeout block below uses it
 	// to skip the per-request deadline. Same predicate, same source of
@@ -238,10 +339,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				"namespace", target0.Namespace,
 				"source", string(src),
 			)
-			WriteJSONError(w, &Error{
-				Status: http.StatusBadGateway,
-				Detail: fmt.Sprintf("Could not connect to the backend sandbox: %s", target0.ID),
-			})
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				WriteJSONError(w, requestTooLargeError(maxBytesErr.Limit))
+				return
+			}
+			WriteJSONError(w, upstreamError(target0.ID, err))
 		},
 	}
 
@@ -262,6 +365,59 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	rp.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// touchWhileOpen records activity on the sandbox every ActivityInterval
+// until the returned stop function is called. The recorder throttles
+// writes to the same interval, so a long-lived connection costs at most
+// one write per interval.
+func (h *Handler) touchWhileOpen(namespace, name string) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(h.cfg.ActivityInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				h.activity.Touch(namespace, name)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// parseTarget reads the routing target off r along with the path to
+// forward upstream. The X-Sandbox-* headers win whenever X-Sandbox-ID is
+// set, so header clients whose own paths start with PathRoutingPrefix are
+// unaffected; otherwise, with path routing enabled, a prefixed path is
+// parsed and the prefix stripped.
+func (h *Handler) parseTarget(r *http.Request) (Target, string, *Error) {
+	if h.cfg.PathRoutingEnabled && r.Header.Get(HeaderSandboxID) == "" && HasSandboxPathPrefix(r.URL.Path) {
+		return ParseSandboxPath(r.URL.Path)
+	}
+	target, perr := ParseSandboxHeaders(r.Header, ParseOptions{
+		AllowLoopbackPodIP: h.cfg.AllowLoopbackPodIP,
+	})
+	return target, r.URL.Path, perr
+}
+
+// resolvePortName maps t.PortName to a port number using the sandbox
+// Service's named ports. The resolved value goes through the same range
+// check as a numeric X-Sandbox-Port.
+func (h *Handler) resolvePortName(t Target) (int, *Error) {
+	if h.ports == nil {
+		return 0, &Error{Status: http.StatusBadRequest, Detail: "Named port routing is not enabled."}
+	}
+	port, ok := h.ports.ResolvePort(t.Namespace, t.ID, t.PortName)
+	if !ok {
+		return 0, &Error{Status: http.StatusBadRequest, Detail: fmt.Sprintf("Unknown port name: %s", t.PortName)}
+	}
+	if port < 1 || port > 65535 {
+		return 0, &Error{Status: http.StatusBadRequest, Detail: "Invalid port format."}
+	}
+	return port, nil
+}
+
 // isUpgradeRequest reports whether r is asking the server to switch
 // protocols (RFC 7230 §6.7). The check follows the same rule
 // httputil.ReverseProxy uses internally to recognize upgrade requests:
@@ -277,8 +433,8 @@ func isUpgradeRequest(r *http.Request) bool {
 
 // defaultTransport builds the shared *http.Transport used for upstream
 // requests. Values mirror Go's DefaultTransport (minus Proxy — see
-// below) plus a configurable ResponseHeaderTimeout and disabled HTTP/2
-// to backends (sandboxes are h1 today; opting in to h2 to backends
+// below) plus a configurable ResponseHeaderTimeout, the upstream TLS
+// config for https sandboxes, and disabled HTTP/2 to backends (sandboxes are h1 today; opting in to h2 to backends
 // would require negotiation we don't want to introduce silently).
 //
 // Note Proxy is deliberately unset (nil), NOT http.ProxyFromEnvironment.
@@ -290,7 +446,7 @@ func isUpgradeRequest(r *http.Request) bool {
 // internal dials through an external proxy — connectivity breaks and
 // in the worst case Pod-IP traffic leaves the cluster. Defaulting to
 // no proxy makes "router goes direct to the sandbox" the guarantee.
-func defaultTransport(cfg *config.Config) *http.Transport {
+func defaultTransport(cfg *config.Config, tlsConfig *tls.Config) *http.Transport {
 	return &http.Transport{
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
@@ -301,6 +457,7 @@ func defaultTransport(cfg *config.Config) *http.Transport {
 		MaxIdleConnsPerHost:   16,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
+		TLSClientConfig:       tlsConfig,
 		ExpectContinueTimeout: 1 * time.Second,
 		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 	}
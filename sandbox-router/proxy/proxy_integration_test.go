@@ -250,6 +250,9 @@ func TestIntegration_UpstreamConnectErrorReturns502(t *testing.T) {
 	if resp.StatusCode != http.StatusBadGateway {
 		t.Fatalf("status: got %d want 502", resp.StatusCode)
 	}
+	if got := resp.Header.Get(HeaderSandboxErrorReason); got != ErrorReasonBadGateway {
+		t.Errorf("reason header: got %q want %q", got, ErrorReasonBadGateway)
+	}
 	body, _ := io.ReadAll(resp.Body)
 	if !strings.Contains(string(body), "ghost") {
 		t.Errorf("body should mention sandbox id; got %q", body)
@@ -0,0 +1,120 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// limiterSweepInterval bounds how often idle buckets are dropped.
+const limiterSweepInterval = time.Minute
+
+// sandboxLimiter is a per-sandbox token-bucket rate limiter. Buckets are
+// created lazily on a sandbox's first request and live in a sync.Map so
+// requests for unrelated sandboxes never contend on a shared lock; the
+// only shared write is the occasional sweep of idle buckets.
+type sandboxLimiter struct {
+	def       config.RateLimit
+	overrides map[string]config.RateLimit
+
+	buckets   sync.Map // limiterKey -> *rate.Limiter
+	lastSweep atomic.Int64
+	now       func() time.Time
+}
+
+// limiterKey identifies a sandbox's bucket. A struct rather than a joined
+// string keeps the hot path allocation-free.
+type limiterKey struct{ namespace, id string }
+
+// newSandboxLimiter returns a limiter enforcing def, or the matching entry
+// of overrides for sandboxes in an overridden namespace. It returns nil
+// when no namespace can ever be limited.
+func newSandboxLimiter(def config.RateLimit, overrides map[string]config.RateLimit) *sandboxLimiter {
+	enabled := def.RPS > 0
+	for _, o := range overrides {
+		enabled = enabled || o.RPS > 0
+	}
+	if !enabled {
+		return nil
+	}
+	l := &sandboxLimiter{def: def, overrides: overrides, now: time.Now}
+	l.lastSweep.Store(l.now().UnixNano())
+	return l
+}
+
+// limitFor returns the limit that applies to sandboxes in namespace.
+func (l *sandboxLimiter) limitFor(namespace string) config.RateLimit {
+	if o, ok := l.overrides[namespace]; ok {
+		return o
+	}
+	return l.def
+}
+
+// Allow takes a token from the bucket of sandbox namespace/id. When the
+// bucket is empty it returns false and how long the caller should wait
+// before retrying.
+func (l *sandboxLimiter) Allow(namespace, id string) (bool, time.Duration) {
+	limit := l.limitFor(namespace)
+	if limit.RPS <= 0 {
+		return true, 0
+	}
+	now := l.now()
+	l.maybeSweep(now)
+
+	key := limiterKey{namespace: namespace, id: id}
+	v, ok := l.buckets.Load(key)
+	if !ok {
+		v, _ = l.buckets.LoadOrStore(key, rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst))
+	}
+	bucket := v.(*rate.Limiter)
+
+	r := bucket.ReserveN(now, 1)
+	if !r.OK() {
+		return false, time.Second
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		// Don't hold the token: the request is rejected, not queued.
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// maybeSweep drops buckets that have refilled completely, at most once per
+// limiterSweepInterval. A full bucket is indistinguishable from a fresh
+// one, so dropping it doesn't change any future decision; it just keeps
+// the map from growing with every sandbox the router has ever seen.
+func (l *sandboxLimiter) maybeSweep(now time.Time) {
+	last := l.lastSweep.Load()
+	if now.UnixNano()-last < int64(limiterSweepInterval) {
+		return
+	}
+	if !l.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	l.buckets.Range(func(key, v any) bool {
+		bucket := v.(*rate.Limiter)
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			l.buckets.Delete(key)
+		}
+		return true
+	})
+}
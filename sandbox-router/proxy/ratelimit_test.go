@@ -0,0 +1,291 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// fakeClock is a manually advanced clock for sandboxLimiter.now.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(t *testing.T, def config.RateLimit, overrides map[string]config.RateLimit) (*sandboxLimiter, *fakeClock) {
+	t.Helper()
+	l := newSandboxLimiter(def, overrides)
+	if l == nil {
+		t.Fatalf("newSandboxLimiter returned nil for %+v / %+v", def, overrides)
+	}
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	l.now = clock.now
+	l.lastSweep.Store(clock.t.UnixNano())
+	return l, clock
+}
+
+func TestNewSandboxLimiterDisabled(t *testing.T) {
+	if l := newSandboxLimiter(config.RateLimit{}, nil); l != nil {
+		t.Fatalf("zero default and no overrides should disable the limiter")
+	}
+	if l := newSandboxLimiter(config.RateLimit{}, map[string]config.RateLimit{"ns": {}}); l != nil {
+		t.Fatalf("zero-rate overrides should disable the limiter")
+	}
+	if l := newSandboxLimiter(config.RateLimit{}, map[string]config.RateLimit{"ns": {RPS: 1, Burst: 1}}); l == nil {
+		t.Fatalf("a single limited namespace should enable the limiter")
+	}
+}
+
+func TestSandboxLimiterAllow(t *testing.T) {
+	l, clock := newTestLimiter(t, config.RateLimit{RPS: 1, Burst: 2}, nil)
+
+	for i := range 2 {
+		if ok, _ := l.Allow("ns", "a"); !ok {
+			t.Fatalf("request %d within burst was rejected", i)
+		}
+	}
+	ok, retryAfter := l.Allow("ns", "a")
+	if ok {
+		t.Fatalf("request over burst was allowed")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("retryAfter: got %v, want (0, 1s]", retryAfter)
+	}
+
+	// Buckets are per sandbox: "a" being empty must not affect "b", nor
+	// a sandbox with the same name in another namespace.
+	if ok, _ := l.Allow("ns", "b"); !ok {
+		t.Errorf("unrelated sandbox was rejected")
+	}
+	if ok, _ := l.Allow("other", "a"); !ok {
+		t.Errorf("same sandbox name in another namespace was rejected")
+	}
+
+	// A rejected request must not consume a token, so exactly one request
+	// is allowed once a token's worth of time has passed.
+	clock.advance(time.Second)
+	if ok, _ := l.Allow("ns", "a"); !ok {
+		t.Fatalf("request after refill was rejected")
+	}
+	if ok, _ := l.Allow("ns", "a"); ok {
+		t.Fatalf("second request after a single-token refill was allowed")
+	}
+}
+
+func TestSandboxLimiterNamespaceOverrides(t *testing.T) {
+	l, _ := newTestLimiter(t, config.RateLimit{RPS: 1, Burst: 1}, map[string]config.RateLimit{
+		"big":       {RPS: 10, Burst: 5},
+		"unlimited": {},
+	})
+
+	cases := []struct {
+		namespace string
+		allowed   int
+	}{
+		{namespace: "default", allowed: 1},
+		{namespace: "big", allowed: 5},
+		{namespace: "unlimited", allowed: 100},
+	}
+	for _, tc := range cases {
+		t.Run(tc.namespace, func(t *testing.T) {
+			got := 0
+			for range 100 {
+				if ok, _ := l.Allow(tc.namespace, "box"); ok {
+					got++
+				}
+			}
+			if got != tc.allowed {
+				t.Fatalf("allowed: got %d want %d", got, tc.allowed)
+			}
+		})
+	}
+}
+
+func TestSandboxLimiterSweepsFullBuckets(t *testing.T) {
+	l, clock := newTestLimiter(t, config.RateLimit{RPS: 1, Burst: 1}, nil)
+	countBuckets := func() int {
+		n := 0
+		l.buckets.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+
+	l.Allow("ns", "idle")
+	clock.advance(limiterSweepInterval)
+	l.Allow("ns", "busy")
+	// The sweep runs before "busy" takes its token, so only the
+	// refilled "idle" bucket is dropped.
+	if n := countBuckets(); n != 1 {
+		t.Fatalf("buckets after sweep: got %d want 1", n)
+	}
+	if _, ok := l.buckets.Load(limiterKey{namespace: "ns", id: "busy"}); !ok {
+		t.Fatalf("the in-use bucket was swept")
+	}
+}
+
+func TestRateLimitedRequestsGet429(t *testing.T) {
+	backend, port := newOKBackend(t)
+	defer backend.Close()
+
+	cfg := config.Defaults()
+	cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+	cfg.RateLimitRPS = 0.001      // one token, then effectively never refills
+	cfg.RateLimitBurst = 1
+	router := httptest.NewServer(NewHandler(Options{Config: &cfg, Logger: logr.Discard()}))
+	defer router.Close()
+
+	do := func(id string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+		req.Header.Set(HeaderSandboxID, id)
+		req.Header.Set(HeaderSandboxNamespace, "ns")
+		req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+		req.Header.Set(HeaderSandboxPort, port)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	if resp := do("a"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("first request: got %d want 200", resp.StatusCode)
+	}
+	resp := do("a")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("second request: got %d want 429", resp.StatusCode)
+	}
+	if got := resp.Header.Get(HeaderSandboxErrorReason); got != ErrorReasonRateLimited {
+		t.Errorf("reason header: got %q want %q", got, ErrorReasonRateLimited)
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err != nil || secs < 1 {
+		t.Errorf("Retry-After: got %q, want a positive number of seconds", resp.Header.Get("Retry-After"))
+	}
+	if resp := do("b"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unrelated sandbox: got %d want 200", resp.StatusCode)
+	}
+}
+
+func TestOversizedBodiesGet413(t *testing.T) {
+	backend, port := newOKBackend(t)
+	defer backend.Close()
+
+	cfg := config.Defaults()
+	cfg.AllowLoopbackPodIP = true
+	cfg.MaxRequestBodyBytes = 8
+	router := httptest.NewServer(NewHandler(Options{Config: &cfg, Logger: logr.Discard()}))
+	defer router.Close()
+
+	cases := []struct {
+		name       string
+		body       string
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "within limit", body: "12345678", wantStatus: http.StatusOK},
+		{name: "declared length over limit", body: "123456789", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "chunked body over limit", body: strings.Repeat("x", 64), chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader = strings.NewReader(tc.body)
+			if tc.chunked {
+				// Hiding the concrete type stops the client from
+				// computing a Content-Length.
+				body = io.MultiReader(body)
+			}
+			req, _ := http.NewRequest("POST", router.URL+"/x", body)
+			req.Header.Set(HeaderSandboxID, "box")
+			req.Header.Set(HeaderSandboxNamespace, "ns")
+			req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+			req.Header.Set(HeaderSandboxPort, port)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusRequestEntityTooLarge {
+				if got := resp.Header.Get(HeaderSandboxErrorReason); got != ErrorReasonRequestTooLarge {
+					t.Errorf("reason header: got %q want %q", got, ErrorReasonRequestTooLarge)
+				}
+			}
+		})
+	}
+}
+
+// newOKBackend starts a backend that drains the request body and answers
+// 200, returning it with its port.
+func newOKBackend(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	_, port, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		backend.Close()
+		t.Fatalf("split backend addr: %v", err)
+	}
+	return backend, port
+}
+
+// BenchmarkSandboxLimiter compares parallel Allow calls that all hit one
+// sandbox against calls spread over many sandboxes. Unrelated sandboxes
+// share no lock, so the distinct case should scale with GOMAXPROCS while
+// the same-sandbox case contends on a single bucket's mutex.
+func BenchmarkSandboxLimiter(b *testing.B) {
+	// High enough that no bucket ever runs dry: the benchmark measures
+	// lookup and bookkeeping cost, not rejections.
+	limit := config.RateLimit{RPS: 1e9, Burst: 1e9}
+	ids := make([]string, 1024)
+	for i := range ids {
+		ids[i] = "sandbox-" + strconv.Itoa(i)
+	}
+
+	b.Run("same-sandbox", func(b *testing.B) {
+		l := newSandboxLimiter(limit, nil)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				l.Allow("ns", "sandbox-0")
+			}
+		})
+	})
+	b.Run("distinct-sandboxes", func(b *testing.B) {
+		l := newSandboxLimiter(limit, nil)
+		var next atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			id := ids[next.Add(1)%int64(len(ids))]
+			for pb.Next() {
+				l.Allow("ns", id)
+			}
+		})
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+// fakeEndpoints is an EndpointLookup that records every lookup and
+// reports the sandboxes in ready ("ns/name") as having ready endpoints.
+type fakeEndpoints struct {
+	mu      sync.Mutex
+	ready   map[string]bool
+	lookups []string
+}
+
+func (f *fakeEndpoints) HasReadyEndpoints(namespace, name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lookups = append(f.lookups, namespace+"/"+name)
+	return f.ready[namespace+"/"+name]
+}
+
+func TestReadinessPreCheck(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	_, backendPort, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+
+	cases := []struct {
+		name        string
+		podIP       bool
+		wantStatus  int
+		wantReason  string
+		wantLookups int
+	}{
+		{
+			name:        "DNS route without ready endpoints is rejected",
+			wantStatus:  http.StatusServiceUnavailable,
+			wantReason:  ErrorReasonSandboxNotReady,
+			wantLookups: 1,
+		},
+		{
+			name:       "Pod IP route skips the check",
+			podIP:      true,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints := &fakeEndpoints{}
+			cfg := config.Defaults()
+			cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+			cfg.ProxyTimeout = 2 * time.Second
+			cfg.UpstreamMaxRetries = 0
+			router := httptest.NewServer(NewHandler(Options{Config: &cfg, Endpoints: endpoints, Logger: logr.Discard()}))
+			defer router.Close()
+
+			req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+			req.Header.Set(HeaderSandboxID, "box")
+			req.Header.Set(HeaderSandboxNamespace, "ns")
+			if tc.podIP {
+				req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+				req.Header.Set(HeaderSandboxPort, backendPort)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := resp.Header.Get(HeaderSandboxErrorReason); got != tc.wantReason {
+				t.Errorf("reason header: got %q want %q", got, tc.wantReason)
+			}
+			if tc.wantReason != "" && resp.Header.Get("Retry-After") == "" {
+				t.Errorf("Retry-After should be set on a not-ready response")
+			}
+			endpoints.mu.Lock()
+			defer endpoints.mu.Unlock()
+			if len(endpoints.lookups) != tc.wantLookups {
+				t.Fatalf("lookups: got %v, want %d", endpoints.lookups, tc.wantLookups)
+			}
+			if tc.wantLookups > 0 && endpoints.lookups[0] != "ns/box" {
+				t.Errorf("lookup: got %q want ns/box", endpoints.lookups[0])
+			}
+		})
+	}
+}
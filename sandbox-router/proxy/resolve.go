@@ -35,6 +35,32 @@ type Lookup interface {
 	Invalidate(uid types.UID) bool
 }
 
+// PortLookup resolves named sandbox ports. Satisfied by
+// cache.ServicePorts; defined here for the same reasons as Lookup.
+type PortLookup interface {
+	ResolvePort(namespace, name, portName string) (int, bool)
+}
+
+// EndpointLookup reports whether a sandbox Service has ready endpoints.
+// Satisfied by cache.Endpoints; defined here for the same reasons as Lookup.
+type EndpointLookup interface {
+	HasReadyEndpoints(namespace, name string) bool
+}
+
+// BackendLookup lists the ready pod addresses behind a sandbox Service.
+// Satisfied by cache.Endpoints; defined here for the same reasons as Lookup.
+type BackendLookup interface {
+	ReadyAddresses(namespace, name string) []string
+}
+
+// ActivityRecorder notes a request about to be proxied to a sandbox,
+// and again every ActivityInterval while the request is still open.
+// Satisfied by activity.Recorder; defined here for the same reasons as
+// Lookup.
+type ActivityRecorder interface {
+	Touch(namespace, name string)
+}
+
 // Source tags how the upstream host was picked. Returned alongside the
 // resolved URL so the handler can log/metric the resolution mode.
 type Source string
@@ -0,0 +1,98 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+	"sigs.k8s.io/agent-sandbox/sandbox-router/observability"
+)
+
+// TestTraceparentCorrelatedWithoutTracing wires the handler the way main
+// does with tracing off (no-op tracer, W3C propagator) and checks that an
+// inbound traceparent reaches the sandbox and the access log with the same
+// trace ID.
+func TestTraceparentCorrelatedWithoutTracing(t *testing.T) {
+	const (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+	)
+
+	var mu sync.Mutex
+	var upstreamTraceparent string
+	var logs []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		upstreamTraceparent = r.Header.Get("traceparent")
+		mu.Unlock()
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+	_, backendPort, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+
+	log := funcr.New(func(prefix, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, args)
+	}, funcr.Options{})
+
+	cfg := config.Defaults()
+	cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+	cfg.ProxyTimeout = 2 * time.Second
+	cfg.UpstreamMaxRetries = 0
+	prop := propagation.TraceContext{}
+	var root http.Handler = NewHandler(Options{Config: &cfg, Propagator: prop, Logger: log})
+	root = observability.AccessLogMiddleware(log, nil)(root)
+	root = observability.TracingMiddleware(noop.NewTracerProvider().Tracer("test"), prop, log)(root)
+	router := httptest.NewServer(root)
+	defer router.Close()
+
+	req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+	req.Header.Set(HeaderSandboxID, "box")
+	req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+	req.Header.Set(HeaderSandboxPort, backendPort)
+	req.Header.Set("traceparent", traceparent)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d want 200", resp.StatusCode)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if upstreamTraceparent != traceparent {
+		t.Errorf("upstream traceparent: got %q want %q", upstreamTraceparent, traceparent)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], `"trace_id"="`+traceID+`"`) {
+		t.Errorf("access log should carry trace_id %s, got %q", traceID, logs)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/agent-sandbox/sandbox-router/config"
+)
+
+func TestUpstreamTLS(t *testing.T) {
+	// The backend requires a client certificate so the mTLS cases also
+	// prove the router presents one.
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	backend.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	backend.StartTLS()
+	defer backend.Close()
+	_, backendPort, err := net.SplitHostPort(backend.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split backend addr: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(backend.Certificate())
+	// Any certificate will do for RequireAnyClientCert; reuse the
+	// backend's own rather than minting one.
+	clientCert := backend.TLS.Certificates[0]
+
+	cases := []struct {
+		name           string
+		upstreamScheme config.UpstreamScheme
+		schemeHeader   string
+		upstreamTLS    *tls.Config
+		wantStatus     int
+	}{
+		{
+			name:         "header selects https with client cert",
+			schemeHeader: "https",
+			upstreamTLS:  &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:           "flag selects https",
+			upstreamScheme: config.UpstreamSchemeHTTPS,
+			upstreamTLS:    &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "header overrides https flag",
+			upstreamScheme: config.UpstreamSchemeHTTPS,
+			schemeHeader:   "http",
+			upstreamTLS:    &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+			// Plain HTTP to a TLS listener gets Go's canned 400.
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "untrusted sandbox certificate",
+			schemeHeader: "https",
+			upstreamTLS:  &tls.Config{Certificates: []tls.Certificate{clientCert}},
+			wantStatus:   http.StatusBadGateway,
+		},
+		{
+			name:         "missing client certificate",
+			schemeHeader: "https",
+			upstreamTLS:  &tls.Config{RootCAs: roots},
+			wantStatus:   http.StatusBadGateway,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Defaults()
+			cfg.AllowLoopbackPodIP = true // httptest binds to 127.0.0.1
+			cfg.ProxyTimeout = 2 * time.Second
+			cfg.UpstreamMaxRetries = 0
+			if tc.upstreamScheme != "" {
+				cfg.UpstreamScheme = tc.upstreamScheme
+			}
+			router := httptest.NewServer(NewHandler(Options{Config: &cfg, UpstreamTLS: tc.upstreamTLS, Logger: logr.Discard()}))
+			defer router.Close()
+
+			req, _ := http.NewRequest("GET", router.URL+"/x", nil)
+			req.Header.Set(HeaderSandboxID, "box")
+			req.Header.Set(HeaderSandboxNamespace, "ns")
+			req.Header.Set(HeaderSandboxPodIP, "127.0.0.1")
+			req.Header.Set(HeaderSandboxPort, backendPort)
+			if tc.schemeHeader != "" {
+				req.Header.Set(HeaderSandboxScheme, tc.schemeHeader)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+		})
+	}
+}
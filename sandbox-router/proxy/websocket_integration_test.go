@@ -172,7 +172,7 @@ func TestIntegration_WebSocketOutlivesProxyTimeout(t *testing.T) {
 // TestIntegration_NonUpgradeStillRespectsProxyTimeout makes sure the
 // upgrade carve-out didn't accidentally disable the timeout for normal
 // requests. A slow backend that holds the response past ProxyTimeout
-// must still be cut off with 502.
+// must still be cut off with 504.
 func TestIntegration_NonUpgradeStillRespectsProxyTimeout(t *testing.T) {
 	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Hold the response longer than the router's ProxyTimeout.
@@ -208,8 +208,11 @@ func TestIntegration_NonUpgradeStillRespectsProxyTimeout(t *testing.T) {
 		t.Fatalf("do: %v", err)
 	}
 	resp.Body.Close()
-	if resp.StatusCode != http.StatusBadGateway {
-		t.Fatalf("status: got %d want 502 (ProxyTimeout should have fired)", resp.StatusCode)
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("status: got %d want 504 (ProxyTimeout should have fired)", resp.StatusCode)
+	}
+	if got := resp.Header.Get(HeaderSandboxErrorReason); got != ErrorReasonUpstreamTimeout {
+		t.Errorf("reason header: got %q want %q", got, ErrorReasonUpstreamTimeout)
 	}
 	// Sanity: we should have failed near the timeout, not near the
 	// backend's 3s hold.
@@ -15,15 +15,35 @@
 package server
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
-// Probes serves /healthz and /readyz. Liveness (/healthz) is always 200.
-// Readiness (/readyz) flips to 503 once MarkUnready is called, which lets
-// load balancers drain the pod ahead of shutdown.
+// readyCheckTimeout bounds each check run by /ready, so a hung DNS server
+// or apiserver fails the probe instead of outliving the kubelet's timeout.
+const readyCheckTimeout = 2 * time.Second
+
+// Probes serves /healthz, /readyz and /ready. Liveness (/healthz) is always
+// 200. Readiness (/readyz) flips to 503 once MarkUnready is called, which
+// lets load balancers drain the pod ahead of shutdown. /ready additionally
+// runs the checks registered with AddReadyCheck, so a router that lost
+// cluster connectivity is taken out of rotation without being restarted.
 type Probes struct {
 	ready atomic.Bool
+
+	mu     sync.Mutex
+	checks []readyCheck
+}
+
+type readyCheck struct {
+	name  string
+	check func(context.Context) error
 }
 
 // NewProbes returns a Probes initialized to not-yet-ready. Call MarkReady
@@ -42,6 +62,14 @@ func (p *Probes) MarkUnready() {
 	p.ready.Store(false)
 }
 
+// AddReadyCheck registers a dependency check for /ready. check should
+// honor its context, which expires after readyCheckTimeout.
+func (p *Probes) AddReadyCheck(name string, check func(context.Context) error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checks = append(p.checks, readyCheck{name: name, check: check})
+}
+
 // Healthz always returns 200 OK with the Python-compatible JSON body so
 // tooling that parses {"status":"ok"} keeps working.
 func (p *Probes) Healthz(w http.ResponseWriter, _ *http.Request) {
@@ -61,10 +89,59 @@ func (p *Probes) Readyz(w http.ResponseWriter, _ *http.Request) {
 	_, _ = w.Write([]byte("draining"))
 }
 
-// Mux returns a mux serving /healthz and /readyz from p.
+// Ready returns 200 if ready and every registered check passes, 503
+// otherwise. The checks run concurrently on every call and the 503 body
+// names each failing one, so a probe failure is explained by the kubelet
+// event without digging through logs.
+func (p *Probes) Ready(w http.ResponseWriter, r *http.Request) {
+	if !p.ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("draining"))
+		return
+	}
+
+	p.mu.Lock()
+	checks := p.checks
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+	defer cancel()
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Go(func() { errs[i] = c.check(ctx) })
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", checks[i].name, err))
+		}
+	}
+	if len(failed) > 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(strings.Join(failed, "\n")))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// DNSCheck returns a check that resolves host, typically the apiserver's
+// in-cluster Service name, to verify the router can still use cluster DNS.
+func DNSCheck(host string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := net.DefaultResolver.LookupHost(ctx, host)
+		return err
+	}
+}
+
+// Mux returns a mux serving /healthz, /readyz and /ready from p.
 func (p *Probes) Mux() *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", p.Healthz)
 	mux.HandleFunc("/readyz", p.Readyz)
+	mux.HandleFunc("/ready", p.Ready)
 	return mux
 }
@@ -15,11 +15,15 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestHealthzAlwaysOK(t *testing.T) {
@@ -85,3 +89,83 @@ func TestReadyzDefaultsToUnready(t *testing.T) {
 		t.Fatalf("default unready: got %d want 503", resp.StatusCode)
 	}
 }
+
+func TestReadyRunsChecks(t *testing.T) {
+	p := NewProbes()
+	p.MarkReady()
+	var apiserverDown atomic.Bool
+	p.AddReadyCheck("dns", func(context.Context) error { return nil })
+	p.AddReadyCheck("apiserver", func(context.Context) error {
+		if apiserverDown.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	srv := httptest.NewServer(http.HandlerFunc(p.Ready))
+	defer srv.Close()
+
+	get := func() (int, string) {
+		t.Helper()
+		resp, err := http.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if code, body := get(); code != http.StatusOK {
+		t.Fatalf("checks passing: got %d %q, want 200", code, body)
+	}
+
+	apiserverDown.Store(true)
+	code, body := get()
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("check failing: got %d want 503", code)
+	}
+	if body != "apiserver: connection refused" {
+		t.Errorf("body: got %q, want only the failing check", body)
+	}
+
+	apiserverDown.Store(false)
+	p.MarkUnready()
+	if code, body := get(); code != http.StatusServiceUnavailable || body != "draining" {
+		t.Fatalf("draining: got %d %q, want 503 draining", code, body)
+	}
+}
+
+func TestReadyCheckTimesOut(t *testing.T) {
+	p := NewProbes()
+	p.MarkReady()
+	p.AddReadyCheck("apiserver", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	p.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if elapsed := time.Since(start); elapsed > 2*readyCheckTimeout {
+		t.Fatalf("hung check held /ready for %v", elapsed)
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status: got %d want 503", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "deadline exceeded") {
+		t.Errorf("body: got %q", rec.Body.String())
+	}
+}
+
+func TestDNSCheck(t *testing.T) {
+	if err := DNSCheck("localhost")(t.Context()); err != nil {
+		t.Errorf("localhost: %v", err)
+	}
+	// .invalid is reserved (RFC 6761) and never resolves. Without a
+	// resolver the lookup times out instead, which fails the check too.
+	ctx, cancel := context.WithTimeout(t.Context(), readyCheckTimeout)
+	defer cancel()
+	if err := DNSCheck("kubernetes.default.svc.invalid")(ctx); err == nil {
+		t.Errorf("expected a lookup error for an .invalid name")
+	}
+}
@@ -38,7 +38,7 @@ type Server struct {
 	proxy     *http.Server // plain HTTP proxy listener (optional)
 	proxyTLS  *http.Server // HTTPS proxy listener (optional)
 	metrics   *http.Server // /metrics endpoint
-	healthSrv *http.Server // /healthz, /readyz
+	healthSrv *http.Server // /healthz, /readyz, /ready
 
 	shutdownTimeout time.Duration
 }
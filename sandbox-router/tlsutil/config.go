@@ -65,6 +65,27 @@ func BuildServerTLS(cfg *config.Config, reloader *CertReloader) (*tls.Config, er
 	return tc, nil
 }
 
+// BuildUpstreamTLS assembles the *tls.Config used when dialing https
+// sandboxes. cfg.UpstreamTLSCAFile, when set, replaces the system roots for
+// verifying sandbox certificates. reloader, when non-nil, supplies the
+// client certificate presented to sandboxes that require mTLS.
+func BuildUpstreamTLS(cfg *config.Config, reloader *CertReloader) (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.UpstreamTLSCAFile != "" {
+		pool, err := LoadCAPool(cfg.UpstreamTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("load upstream CA: %w", err)
+		}
+		tc.RootCAs = pool
+	}
+	if reloader != nil {
+		tc.GetClientCertificate = reloader.GetClientCertificate
+	}
+	return tc, nil
+}
+
 // LoadCAPool reads a PEM-encoded CA bundle from path and returns a new pool
 // containing every parsed certificate. It returns an error if the file is
 // empty or contains no parseable certificates.
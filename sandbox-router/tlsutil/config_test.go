@@ -139,3 +139,41 @@ func TestLoadCAPool(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildUpstreamTLS(t *testing.T) {
+	t.Run("defaults to system roots without a client cert", func(t *testing.T) {
+		tc, err := BuildUpstreamTLS(&config.Config{}, nil)
+		if err != nil {
+			t.Fatalf("BuildUpstreamTLS: %v", err)
+		}
+		if tc.RootCAs != nil {
+			t.Errorf("RootCAs should be nil (system roots) without --upstream-tls-ca-file")
+		}
+		if tc.GetClientCertificate != nil {
+			t.Errorf("GetClientCertificate should be nil without a client cert")
+		}
+	})
+	t.Run("CA bundle and client cert", func(t *testing.T) {
+		caPath := writeCABundle(t, genSelfSignedCert(t, "ca").CertPEM)
+		reloader := newReloaderForTest(t)
+		tc, err := BuildUpstreamTLS(&config.Config{UpstreamTLSCAFile: caPath}, reloader)
+		if err != nil {
+			t.Fatalf("BuildUpstreamTLS: %v", err)
+		}
+		if tc.RootCAs == nil {
+			t.Errorf("RootCAs should be set from --upstream-tls-ca-file")
+		}
+		if tc.GetClientCertificate == nil {
+			t.Fatalf("GetClientCertificate should be wired to the reloader")
+		}
+		cert, err := tc.GetClientCertificate(&tls.CertificateRequestInfo{})
+		if err != nil || cert == nil {
+			t.Fatalf("GetClientCertificate: cert=%v err=%v", cert, err)
+		}
+	})
+	t.Run("missing CA file", func(t *testing.T) {
+		if _, err := BuildUpstreamTLS(&config.Config{UpstreamTLSCAFile: "/nope"}, nil); err == nil {
+			t.Fatalf("expected error for missing CA file")
+		}
+	})
+}
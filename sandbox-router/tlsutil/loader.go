@@ -72,6 +72,16 @@ func (r *CertReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate,
 	return nil, errors.New("no certificate loaded")
 }
 
+// GetClientCertificate implements crypto/tls.Config.GetClientCertificate so
+// the same reloader can supply the router's client certificate for mTLS to
+// upstream sandboxes.
+func (r *CertReloader) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if c := r.cur.Load(); c != nil {
+		return c, nil
+	}
+	return nil, errors.New("no certificate loaded")
+}
+
 // reload reads the cert/key pair from disk, parses it, and atomically swaps
 // it into r.cur on success. Failures leave the previous certificate in place.
 func (r *CertReloader) reload() error {
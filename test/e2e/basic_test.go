@@ -16,40 +16,19 @@ package e2e
 
 import (
 	"fmt"
-	"hash/fnv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 	"sigs.k8s.io/agent-sandbox/test/e2e/framework"
 	"sigs.k8s.io/agent-sandbox/test/e2e/framework/predicates"
 )
 
-// NameHash generates an FNV-1a hash from a string and returns
-// it as a fixed-length hexadecimal string.
-func NameHash(objectName string) string {
-	h := fnv.New32a()
-	h.Write([]byte(objectName))
-	hashValue := h.Sum32()
-
-	// Convert the uint32 to a hexadecimal string.
-	// This results in an 8-character string (e.g., "a5b3c2d1").
-	const hex = "0123456789abcdef"
-	var buf [8]byte
-	buf[0] = hex[(hashValue>>28)&0xf]
-	buf[1] = hex[(hashValue>>24)&0xf]
-	buf[2] = hex[(hashValue>>20)&0xf]
-	buf[3] = hex[(hashValue>>16)&0xf]
-	buf[4] = hex[(hashValue>>12)&0xf]
-	buf[5] = hex[(hashValue>>8)&0xf]
-	buf[6] = hex[(hashValue>>4)&0xf]
-	buf[7] = hex[hashValue&0xf]
-	return string(buf[:])
-}
-
 func simpleSandbox(ns string) *sandboxv1beta1.Sandbox {
 	sandboxObj := &sandboxv1beta1.Sandbox{}
 	sandboxObj.Name = "my-sandbox"
@@ -83,7 +62,7 @@ func TestSimpleSandbox(t *testing.T) {
 	sandboxObj := simpleSandbox(ns.Name)
 	require.NoError(t, tc.CreateWithCleanup(t.Context(), sandboxObj))
 
-	nameHash := NameHash(sandboxObj.Name)
+	nameHash := naming.NameHash(sandboxObj.Name)
 	// Assert Sandbox object status reconciles as expected
 	p := []predicates.ObjectPredicate{
 		predicates.SandboxHasStatus(sandboxv1beta1.SandboxStatus{
@@ -100,8 +79,11 @@ func TestSimpleSandbox(t *testing.T) {
 				},
 			},
 		}),
+		predicates.SandboxHasPodName,
+		predicates.SandboxHasPodIP,
 	}
 	require.NoError(t, tc.WaitForObject(t.Context(), sandboxObj, p...))
+	require.NoError(t, tc.Get(t.Context(), types.NamespacedName{Name: sandboxObj.Name, Namespace: ns.Name}, sandboxObj))
 	// Assert Pod object exists with expected fields
 	p = []predicates.ObjectPredicate{
 		predicates.HasAnnotation("test-anno-key", "val-1"),
@@ -118,7 +100,7 @@ func TestSimpleSandbox(t *testing.T) {
 		}),
 	}
 	pod := &corev1.Pod{}
-	pod.Name = "my-sandbox"
+	pod.Name = sandboxObj.Status.PodName
 	pod.Namespace = ns.Name
 	tc.MustMatchPredicates(pod, p...)
 	// Assert Service object exists with expected fields
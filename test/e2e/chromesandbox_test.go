@@ -249,25 +249,13 @@ func waitForChromeReady(tc *framework.TestContext, podID types.NamespacedName) e
 		case <-ctx.Done():
 			return fmt.Errorf("context cancelled")
 		default:
-			// We have to port-forward in the loop because port-forward exits when it sees an error
-			// https://github.com/kubernetes/kubectl/issues/1249
-			portForwardCtx, portForwardCancel := context.WithCancel(ctx)
-			if err := tc.PortForward(portForwardCtx, podID, 9222, 9222); err != nil {
-				tc.Logf("failed to port forward: %s", err)
-				portForwardCancel()
-				time.Sleep(pollDuration)
-				continue
-			}
-
-			u := "http://localhost:9222/json/version"
-			info, err := getChromeInfo(ctx, u)
-			portForwardCancel()
+			info, err := getChromeInfo(ctx, tc, podID)
 			if err != nil {
 				tc.Logf("failed to get chrome info: %s", err)
 				time.Sleep(pollDuration)
 				continue
 			}
-			tc.Logf("Chrome is ready (%s). Response: %s", u, info)
+			tc.Logf("Chrome is ready. Response: %s", info)
 			return nil
 		}
 	}
@@ -275,17 +263,13 @@ func waitForChromeReady(tc *framework.TestContext, podID types.NamespacedName) e
 
 // getChromeInfo connects to the Chrome Debug Port and retrieves the version information.
 // This is used to verify that Chrome is running inside the sandbox.
-func getChromeInfo(ctx context.Context, u string) (string, error) {
-	httpClient := &http.Client{}
-	httpClient.Timeout = 200 * time.Millisecond
-
-	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
-	}
+func getChromeInfo(ctx context.Context, tc *framework.TestContext, podID types.NamespacedName) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
 
-	// Send the HTTP request
-	response, err := httpClient.Do(req)
+	// Each attempt gets its own port-forward, which ends on the first
+	// failed connection to the pod.
+	response, err := tc.PortForwardAndGet(ctx, podID, 9222, "/json/version")
 	if err != nil {
 		return "", fmt.Errorf("error sending HTTP request to Chrome Debug Port: %w", err)
 	}
@@ -23,7 +23,6 @@ import (
 	"hash/fnv"
 	"math"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -308,7 +307,7 @@ func runChromeSandboxPerf(tc *framework.TestContext, namespace, name, nodeName s
 	chromeCtx, chromeCancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer chromeCancel()
 
-	if err := waitForChromeReadyExec(chromeCtx, podID); err != nil {
+	if err := waitForChromeReadyExec(chromeCtx, tc, podID); err != nil {
 		tc.Errorf("Failed to wait for chrome %s ready: %v", name, err)
 	} else {
 		metrics.ChromeReady.Set(time.Since(startTime))
@@ -318,21 +317,20 @@ func runChromeSandboxPerf(tc *framework.TestContext, namespace, name, nodeName s
 	return metrics
 }
 
-func waitForChromeReadyExec(ctx context.Context, podID types.NamespacedName) error {
+func waitForChromeReadyExec(ctx context.Context, tc *framework.TestContext, podID types.NamespacedName) error {
 	pollDuration := 1 * time.Second
 	for {
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("chrome readiness polling canceled: %w", ctx.Err())
 		default:
-			// Execute wget directly in the chrome-sandbox container via kubectl exec to verify CDP is responsive
-			cmd := exec.CommandContext(ctx, "kubectl", "exec", "-n", podID.Namespace, podID.Name, "-c", "chrome-sandbox", "--", "wget", "-qO-", "http://localhost:9222/json/version")
-			out, err := cmd.CombinedOutput()
+			// Execute wget directly in the chrome-sandbox container to verify CDP is responsive
+			out, _, err := tc.Exec(ctx, podID, "chrome-sandbox", []string{"wget", "-qO-", "http://localhost:9222/json/version"}, nil)
 			if err != nil {
 				time.Sleep(pollDuration)
 				continue
 			}
-			if strings.Contains(string(out), "Browser") {
+			if strings.Contains(out, "Browser") {
 				return nil
 			}
 			time.Sleep(pollDuration)
@@ -340,42 +340,23 @@ func runPodTests(ctx context.Context, testingT *testing.T, testContext *framewor
 			testingT.Logf("Context cancelled, exiting runPodTests")
 			return fmt.Errorf("context cancelled")
 		default:
-			testingT.Logf("Attempting port forward and checks...")
-
-			// Port forward for health check
-			portForwardCtx, portForwardCancel := context.WithCancel(ctx)
-			if err := testContext.PortForward(portForwardCtx, podID, 8888, 8888); err != nil {
-				testingT.Logf("Failed to port forward for health check: %s", err)
-				portForwardCancel()
-				time.Sleep(pollDuration)
-				continue
-			}
-			testingT.Logf("Port forward for health check established.")
+			testingT.Logf("Attempting health and execute checks...")
 
 			// Perform health check
-			healthURL := "http://localhost:8888/"
-			err := checkHealth(ctx, healthURL)
-
-			if err != nil {
+			if err := checkHealth(ctx, testContext, podID); err != nil {
 				testingT.Logf("Failed to get health check: %s", err)
-				portForwardCancel()
 				time.Sleep(pollDuration)
 				continue
 			}
-			testingT.Logf("Health check successful: url - %s", healthURL)
+			testingT.Logf("Health check successful")
 
 			// Perform execute check
-			executeURL := "http://localhost:8888/execute"
-			err = checkExecute(ctx, executeURL)
-			portForwardCancel()
-
-			if err != nil {
+			if err := checkExecute(ctx, testContext, podID); err != nil {
 				testingT.Logf("failed to verify execute endpoint: %v", err)
-				portForwardCancel()
 				time.Sleep(pollDuration)
 				continue
 			}
-			testingT.Logf("Execute endpoint check successful: url - %s", executeURL)
+			testingT.Logf("Execute endpoint check successful")
 
 			// Both checks passed
 			testingT.Logf("Both health and execute checks passed.")
@@ -385,17 +366,11 @@ func runPodTests(ctx context.Context, testingT *testing.T, testContext *framewor
 }
 
 // checkHealth connects to the Python server health check endpoint.
-func checkHealth(ctx context.Context, url string) error {
-	httpClient := &http.Client{}
-	httpClient.Timeout = 200 * time.Millisecond
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %w", err)
-	}
+func checkHealth(ctx context.Context, testContext *framework.TestContext, podID types.NamespacedName) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
 
-	// Send the HTTP request
-	response, err := httpClient.Do(req)
+	response, err := testContext.PortForwardAndGet(ctx, podID, 8888, "/")
 	if err != nil {
 		return fmt.Errorf("error sending HTTP request to health check: %w", err)
 	}
@@ -414,19 +389,19 @@ func checkHealth(ctx context.Context, url string) error {
 }
 
 // checkExecute connects to the Python server execute endpoint.
-func checkExecute(ctx context.Context, url string) error {
-	httpClient := &http.Client{}
-	httpClient.Timeout = 5 * time.Second // Increased timeout for execute
+func checkExecute(ctx context.Context, testContext *framework.TestContext, podID types.NamespacedName) error {
+	ctx, cancel := context.WithTimeout(ctx, 7*time.Second) // Increased timeout for execute
+	defer cancel()
 
 	payload := `{"command": "echo 'hello world'"}`
-	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", "/execute", strings.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	// Send the HTTP request
-	response, err := httpClient.Do(req)
+	response, err := testContext.PortForwardDo(ctx, podID, 8888, req)
 	if err != nil {
 		return fmt.Errorf("error sending HTTP request to execute endpoint: %w", err)
 	}
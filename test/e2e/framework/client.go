@@ -19,8 +19,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"os/exec"
 	"reflect"
 	"strings"
@@ -30,12 +28,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	sandboxextensionsv1beta1 "sigs.k8s.io/agent-sandbox/extensions/api/v1beta1"
@@ -54,6 +55,8 @@ type ClusterClient struct {
 	T
 	client        client.Client
 	dynamicClient dynamic.Interface
+	clientset     kubernetes.Interface
+	restConfig    *rest.Config
 	scheme        *runtime.Scheme
 	watchSet      *WatchSet
 }
@@ -349,6 +352,44 @@ func (cl *ClusterClient) WaitForObject(ctx context.Context, obj client.Object, p
 	return nil
 }
 
+// WaitForCondition waits for the specified object to have a condition of the
+// given type and status. On failure the returned error includes the last
+// observed condition of that type, which usually explains why it never flipped.
+// Timeouts follow WaitForObject.
+func (cl *ClusterClient) WaitForCondition(ctx context.Context, obj client.Object, conditionType string, status metav1.ConditionStatus) error {
+	cl.Helper()
+	err := cl.WaitForObject(ctx, obj, predicates.ConditionStatusEquals(conditionType, status))
+	if err == nil {
+		return nil
+	}
+
+	// ctx has usually expired by now; use a fresh one to fetch the final state.
+	getCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	nn := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	if getErr := cl.client.Get(getCtx, nn, obj); getErr != nil {
+		return fmt.Errorf("waiting for %s=%s on %T (%s): %w", conditionType, status, obj, nn, err)
+	}
+	cond, findErr := predicates.FindCondition(obj, conditionType)
+	switch {
+	case findErr != nil:
+		return fmt.Errorf("waiting for %s=%s on %T (%s): %w", conditionType, status, obj, nn, err)
+	case cond == nil:
+		return fmt.Errorf("waiting for %s=%s on %T (%s), condition never set: %w", conditionType, status, obj, nn, err)
+	default:
+		return fmt.Errorf("waiting for %s=%s on %T (%s), last seen status=%s reason=%q message=%q: %w",
+			conditionType, status, obj, nn, cond.Status, cond.Reason, cond.Message, err)
+	}
+}
+
+// MustWaitForCondition is a wrapper around WaitForCondition that fails the test on error.
+func (cl *ClusterClient) MustWaitForCondition(obj client.Object, conditionType string, status metav1.ConditionStatus) {
+	cl.Helper()
+	if err := cl.WaitForCondition(cl.Context(), obj, conditionType, status); err != nil {
+		cl.Fatalf("MustWaitForCondition(%T, %s=%s) failed with: %v", obj, conditionType, status, err)
+	}
+}
+
 // Watch calls a callback whenever the specified object changes,
 // using a shared WatchSet to avoid per-call watch setup latency.
 // Callback is called for each event, and if the callback returns true or an error, the watch will stop and the value will be returned.
@@ -439,6 +480,10 @@ func gvkForObject(obj runtime.Object) (schema.GroupVersionKind, error) {
 	switch o := obj.(type) {
 	case *corev1.Pod:
 		return schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, nil
+	case *corev1.Service:
+		return schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"}, nil
+	case *corev1.Namespace:
+		return schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"}, nil
 	case *appsv1.Deployment:
 		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, nil
 	case *sandboxv1beta1.Sandbox:
@@ -463,6 +508,8 @@ func gvrForGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error)
 		return gv.WithResource("sandboxwarmpools"), nil
 	case schema.GroupKind{Kind: "Pod"}:
 		return gv.WithResource("pods"), nil
+	case schema.GroupKind{Kind: "Service"}:
+		return gv.WithResource("services"), nil
 	case schema.GroupKind{Kind: "Deployment", Group: "apps"}:
 		return gv.WithResource("deployments"), nil
 	case schema.GroupKind{Kind: "Namespace"}:
@@ -485,6 +532,9 @@ func (cl *ClusterClient) MustWaitForObject(obj client.Object, p ...predicates.Ob
 }
 
 // WaitForObjectNotFound waits for the specified object to not exist.
+// It reacts to Deleted events from the shared WatchSet when the object's
+// resource is known, and re-checks periodically in case a watch restart
+// drops the event.
 func (cl *ClusterClient) WaitForObjectNotFound(ctx context.Context, obj client.Object) error {
 	cl.Helper()
 	// Static 1 minute timeout, this can be adjusted if needed
@@ -503,21 +553,63 @@ func (cl *ClusterClient) WaitForObjectNotFound(ctx context.Context, obj client.O
 		cl.Helper()
 		cl.Logf("WaitForObjectNotFound %T (%s) took %s", obj, nn, time.Since(start))
 	}()
-	var validationErr error
+
+	// Subscribe before the first check so a deletion in between is not missed.
+	// Without a watch (unknown resource type) the object is polled every second.
+	var events <-chan watch.Event
+	recheckInterval := time.Second
+	if gvr, ok := cl.gvrForObject(obj); ok {
+		sub := cl.watchSet.Subscribe(gvr, WatchFilter{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+		defer sub.Close()
+		events = sub.Events
+		recheckInterval = 5 * time.Second
+	}
+
+	validationErr := cl.ValidateObjectNotFound(timeoutCtx, obj)
+	if validationErr == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(recheckInterval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-timeoutCtx.Done():
 			return fmt.Errorf("timed out waiting for object: %w", validationErr)
-		default:
+		case event, ok := <-events:
+			if !ok {
+				// Subscription closed; keep polling on the ticker.
+				events = nil
+				continue
+			}
+			if event.Type == watch.Deleted {
+				return nil
+			}
+		case <-ticker.C:
 			if validationErr = cl.ValidateObjectNotFound(timeoutCtx, obj); validationErr == nil {
 				return nil
 			}
-			// Simple sleep for fixed duration (basic MVP)
-			time.Sleep(time.Second)
 		}
 	}
 }
 
+// gvrForObject returns the GroupVersionResource to watch for obj, if the
+// ClusterClient has a WatchSet and the object's type is known.
+func (cl *ClusterClient) gvrForObject(obj client.Object) (schema.GroupVersionResource, bool) {
+	if cl.watchSet == nil {
+		return schema.GroupVersionResource{}, false
+	}
+	gvk, err := gvkForObject(obj)
+	if err != nil {
+		return schema.GroupVersionResource{}, false
+	}
+	gvr, err := gvrForGVK(gvk)
+	if err != nil {
+		return schema.GroupVersionResource{}, false
+	}
+	return gvr, true
+}
+
 // validateAgentSandboxInstallation verifies agent-sandbox system components are
 // installed.
 func (cl *ClusterClient) validateAgentSandboxInstallation() error {
@@ -550,61 +642,6 @@ func (cl *ClusterClient) validateAgentSandboxInstallation() error {
 	return nil
 }
 
-func (cl *ClusterClient) PortForward(ctx context.Context, pod types.NamespacedName, localPort, remotePort int) error {
-	cl.Helper()
-	// Set up a port-forward to the Chrome Debug Port
-	portForward := exec.CommandContext(ctx, "kubectl", "-n", pod.Namespace,
-		"port-forward", "pod/"+pod.Name, fmt.Sprintf("%d:%d", localPort, remotePort))
-	cl.Logf("starting port-forward: %s", portForward.String())
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	portForward.Stdout = io.MultiWriter(os.Stdout, &stdout)
-	portForward.Stderr = io.MultiWriter(os.Stderr, &stderr)
-	if err := portForward.Start(); err != nil {
-		return fmt.Errorf("failed to start port-forward: %w", err)
-	}
-
-	stopProcess := func() {
-		if portForward.ProcessState != nil {
-			if portForward.ProcessState.Exited() {
-				return
-			}
-		}
-		cl.Log("killing port-forward")
-		if err := portForward.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
-			cl.Errorf("failed to kill port-forward: %s", err)
-		}
-	}
-	cl.Cleanup(stopProcess)
-
-	go func() {
-		cl.Helper()
-		if err := portForward.Wait(); err != nil {
-			cl.Logf("port-forward exited with error: %s", err)
-		} else {
-			cl.Log("port-forward exited")
-		}
-	}()
-
-	// There is a delay after starting the process before it starts listening.
-	// Wait for the "Forwarding from" message
-	for {
-		if portForward.ProcessState != nil {
-			if portForward.ProcessState.Exited() {
-				return fmt.Errorf("port-forward process exited unexpectedly: stdout=%q stderr=%q", stdout.String(), stderr.String())
-			}
-		}
-
-		// Check stdout for the "Forwarding from" message
-		if strings.Contains(stdout.String(), "Forwarding from") {
-			cl.Logf("port-forward is ready\nstdout: %s\nstderr: %s", stdout.String(), stderr.String())
-			break
-		}
-		time.Sleep(5 * time.Millisecond)
-	}
-	return nil
-}
-
 var sandboxGVK = schema.GroupVersionKind{
 	Group:   "agents.x-k8s.io",
 	Version: "v1beta1",
@@ -630,7 +667,7 @@ func (cl *ClusterClient) WaitForSandboxReady(ctx context.Context, sandboxID type
 	sandbox.SetNamespace(sandboxID.Namespace)
 	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
-	if err := cl.WaitForObject(timeoutCtx, sandbox, predicates.ReadyConditionIsTrue); err != nil {
+	if err := cl.WaitForCondition(timeoutCtx, sandbox, string(sandboxv1beta1.SandboxConditionReady), metav1.ConditionTrue); err != nil {
 		cl.Logf("waiting for sandbox to be ready: %v", err)
 		return err
 	}
@@ -18,11 +18,17 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	ktesting "k8s.io/client-go/testing"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
 	"sigs.k8s.io/agent-sandbox/controllers"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,3 +87,54 @@ func TestMustUpdateObjectRetriesOnConflict(t *testing.T) {
 		t.Errorf("label not persisted after conflict retry, got labels: %v", updated.Labels)
 	}
 }
+
+func TestWaitForObjectNotFoundReactsToDeleteEvent(t *testing.T) {
+	sandbox := &sandboxv1beta1.Sandbox{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-sandbox",
+			Namespace: "default",
+		},
+	}
+
+	gvr := schema.GroupVersionResource{Group: "agents.x-k8s.io", Version: "v1beta1", Resource: "sandboxes"}
+	dynClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "SandboxList",
+	})
+	watchersStarted := make(chan *watch.FakeWatcher, 1)
+	dynClient.PrependWatchReactor(gvr.Resource, func(_ ktesting.Action) (bool, watch.Interface, error) {
+		fw := watch.NewFake()
+		watchersStarted <- fw
+		return true, fw, nil
+	})
+	ws := NewWatchSet(dynClient)
+	defer ws.Close()
+
+	// The typed client keeps returning the object, so only the watch event can
+	// end the wait before the periodic re-check.
+	cl := &ClusterClient{
+		T:        t,
+		client:   fake.NewClientBuilder().WithScheme(controllers.Scheme).WithObjects(sandbox).Build(),
+		watchSet: ws,
+	}
+
+	name, namespace := sandbox.Name, sandbox.Namespace
+	go func() {
+		var fw *watch.FakeWatcher
+		select {
+		case fw = <-watchersStarted:
+		case <-time.After(2 * time.Second):
+			return // WaitForObjectNotFound times out and fails the test.
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(sandboxGVK)
+		u.SetName(name)
+		u.SetNamespace(namespace)
+		fw.Delete(u)
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Second)
+	defer cancel()
+	if err := cl.WaitForObjectNotFound(ctx, sandbox); err != nil {
+		t.Fatalf("WaitForObjectNotFound() = %v, want nil after Deleted event", err)
+	}
+}
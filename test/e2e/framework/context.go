@@ -146,6 +146,11 @@ func NewTestContext(t T) *TestContext {
 		t.Fatalf("building dynamic client: %v", err)
 	}
 
+	clientset, err := kubernetes.NewForConfigAndClient(restConfig, httpClient)
+	if err != nil {
+		t.Fatalf("building clientset: %v", err)
+	}
+
 	watchSet := NewWatchSet(dynamicClient)
 	t.Cleanup(func() {
 		watchSet.Close()
@@ -155,6 +160,8 @@ func NewTestContext(t T) *TestContext {
 		T:             t,
 		client:        client,
 		dynamicClient: dynamicClient,
+		clientset:     clientset,
+		restConfig:    restConfig,
 		scheme:        controllers.Scheme,
 		watchSet:      watchSet,
 	}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// execRetryBackoff bounds ExecWithRetry: five attempts over roughly 7.5s,
+// enough to ride out a container that is Ready but whose exec endpoint is
+// not yet accepting streams.
+var execRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Steps:    5,
+	Cap:      4 * time.Second,
+}
+
+// Exec runs command in container of pod through the API server's exec
+// subresource, the same path `kubectl exec` uses, and returns what the
+// command wrote to stdout and stderr. stdin may be nil.
+//
+// A command that runs but exits non-zero returns an error satisfying
+// IsExitError alongside its output.
+func (cl *ClusterClient) Exec(ctx context.Context, pod types.NamespacedName, container string, command []string, stdin io.Reader) (string, string, error) {
+	cl.Helper()
+	req := cl.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(cl.restConfig, "POST", req.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("creating executor for pod %s: %w", pod, err)
+	}
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return stdout.String(), stderr.String(), fmt.Errorf("exec %q in pod %s container %s: %w (stderr: %s)",
+			strings.Join(command, " "), pod, container, err, stderr.String())
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+// ExecWithRetry is Exec with retries for failures to reach the container,
+// such as the exec stream being refused while the container starts. A
+// command that ran and exited non-zero is not retried, since re-running
+// it would not change the outcome and may repeat side effects. stdin is
+// taken as bytes so it can be replayed on each attempt.
+func (cl *ClusterClient) ExecWithRetry(ctx context.Context, pod types.NamespacedName, container string, command []string, stdin []byte) (string, string, error) {
+	cl.Helper()
+	var stdout, stderr string
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, execRetryBackoff, func(ctx context.Context) (bool, error) {
+		var in io.Reader
+		if stdin != nil {
+			in = bytes.NewReader(stdin)
+		}
+		stdout, stderr, lastErr = cl.Exec(ctx, pod, container, command, in)
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case IsExitError(lastErr):
+			return false, lastErr
+		}
+		cl.Logf("exec in pod %s failed, retrying: %v", pod, lastErr)
+		return false, nil
+	})
+	if err != nil && lastErr != nil {
+		err = lastErr
+	}
+	return stdout, stderr, err
+}
+
+// IsExitError reports whether err from Exec means the command ran and
+// exited with a non-zero status, as opposed to a failure to run it.
+func IsExitError(err error) bool {
+	var exitErr utilexec.ExitError
+	return errors.As(err, &exitErr)
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards localPort on localhost to remotePort on pod using
+// the API server's SPDY port-forward subresource, the same mechanism as
+// `kubectl port-forward`, without needing kubectl. It returns once the
+// local listener is accepting connections; the forward stops when ctx is
+// canceled or the test ends.
+//
+// A failed connection to the pod ends the forward, so callers polling a
+// server that may not be listening yet should port-forward per attempt,
+// or use PortForwardDo, which does that for them.
+func (cl *ClusterClient) PortForward(ctx context.Context, pod types.NamespacedName, localPort, remotePort int) error {
+	cl.Helper()
+	_, stop, err := cl.startPortForward(ctx, pod, "localhost", localPort, remotePort)
+	if err != nil {
+		return err
+	}
+	cl.Cleanup(stop)
+	return nil
+}
+
+// PortForwardDo sends req to remotePort on pod through a dedicated
+// port-forward on an ephemeral local port. Only the path, query, method,
+// headers and body of req are used; its host is replaced. The forward is
+// torn down when the returned response body is closed, or immediately if
+// the request fails.
+func (cl *ClusterClient) PortForwardDo(ctx context.Context, pod types.NamespacedName, remotePort int, req *http.Request) (*http.Response, error) {
+	cl.Helper()
+	localPort, stop, err := cl.startPortForward(ctx, pod, "127.0.0.1", 0, remotePort)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(ctx)
+	req.URL.Scheme = "http"
+	req.URL.Host = fmt.Sprintf("127.0.0.1:%d", localPort)
+	req.Host = ""
+	// A fresh transport per forward: pooled connections would point at a
+	// local port that no longer exists once stop runs.
+	transport := &http.Transport{DisableKeepAlives: true}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	resp.Body = &portForwardBody{ReadCloser: resp.Body, stop: stop}
+	return resp, nil
+}
+
+// PortForwardAndGet issues a GET for path against remotePort on pod; see
+// PortForwardDo.
+func (cl *ClusterClient) PortForwardAndGet(ctx context.Context, pod types.NamespacedName, remotePort int, path string) (*http.Response, error) {
+	cl.Helper()
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing path %q: %w", path, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return cl.PortForwardDo(ctx, pod, remotePort, req)
+}
+
+// startPortForward starts forwarding address:localPort to remotePort on
+// pod and waits for the listener to be ready. localPort 0 picks a free
+// port, which is returned. stop is idempotent and also runs when ctx is
+// canceled.
+func (cl *ClusterClient) startPortForward(ctx context.Context, pod types.NamespacedName, address string, localPort, remotePort int) (int, func(), error) {
+	cl.Helper()
+	req := cl.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+	transport, upgrader, err := spdy.RoundTripperFor(cl.restConfig)
+	if err != nil {
+		return 0, nil, fmt.Errorf("building SPDY transport: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	stop := sync.OnceFunc(func() { close(stopCh) })
+	var errOut bytes.Buffer
+	fw, err := portforward.NewOnAddresses(dialer, []string{address},
+		[]string{fmt.Sprintf("%d:%d", localPort, remotePort)}, stopCh, readyCh, io.Discard, &errOut)
+	if err != nil {
+		return 0, nil, fmt.Errorf("creating port-forward to %s: %w", pod, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return 0, nil, fmt.Errorf("port-forward to %s:%d exited before becoming ready: %w (stderr: %s)", pod, remotePort, err, errOut.String())
+	case <-ctx.Done():
+		stop()
+		return 0, nil, fmt.Errorf("waiting for port-forward to %s:%d: %w", pod, remotePort, ctx.Err())
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopCh:
+		}
+	}()
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		stop()
+		return 0, nil, fmt.Errorf("reading forwarded ports for %s: %w", pod, err)
+	}
+	if len(ports) == 0 {
+		stop()
+		return 0, nil, fmt.Errorf("port-forward to %s reported no ports", pod)
+	}
+	cl.Logf("port-forward to %s ready: %s:%d -> %d", pod, address, ports[0].Local, ports[0].Remote)
+	return int(ports[0].Local), stop, nil
+}
+
+// portForwardBody stops its port-forward once the response body is closed.
+type portForwardBody struct {
+	io.ReadCloser
+	stop func()
+}
+
+func (b *portForwardBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.stop()
+	return err
+}
@@ -50,6 +50,36 @@ func ConditionReasonEquals(conditionType, reason string) ObjectPredicate {
 	}
 }
 
+// ConditionStatusEquals checks if the given object has a condition with the
+// specified type and status.
+func ConditionStatusEquals(conditionType string, status metav1.ConditionStatus) ObjectPredicate {
+	return &StatusPredicate{
+		MatchType:   conditionType,
+		MatchStatus: status,
+	}
+}
+
+// FindCondition returns the condition of the given type from the object's
+// status, or nil if the object has no such condition.
+func FindCondition(obj client.Object, conditionType string) (*metav1.Condition, error) {
+	u, err := asUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to unstructured: %w", err)
+	}
+
+	var status objectWithStatus
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &status); err != nil {
+		return nil, fmt.Errorf("failed to convert to objectWithStatus: %v", err)
+	}
+
+	for i := range status.Status.Conditions {
+		if status.Status.Conditions[i].Type == conditionType {
+			return &status.Status.Conditions[i], nil
+		}
+	}
+	return nil, nil
+}
+
 type StatusPredicate struct {
 	MatchType   string
 	MatchStatus metav1.ConditionStatus
@@ -61,22 +91,11 @@ type ConditionReasonPredicate struct {
 }
 
 func (s *StatusPredicate) Matches(obj client.Object) (bool, error) {
-	u, err := asUnstructured(obj)
+	cond, err := FindCondition(obj, s.MatchType)
 	if err != nil {
-		return false, fmt.Errorf("failed to convert to unstructured: %w", err)
+		return false, err
 	}
-
-	var status objectWithStatus
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &status); err != nil {
-		return false, fmt.Errorf("failed to convert to objectWithStatus: %v", err)
-	}
-
-	for _, cond := range status.Status.Conditions {
-		if cond.Type == s.MatchType && cond.Status == s.MatchStatus {
-			return true, nil
-		}
-	}
-	return false, nil
+	return cond != nil && cond.Status == s.MatchStatus, nil
 }
 
 func (s *StatusPredicate) String() string {
@@ -84,22 +103,11 @@ func (s *StatusPredicate) String() string {
 }
 
 func (c *ConditionReasonPredicate) Matches(obj client.Object) (bool, error) {
-	u, err := asUnstructured(obj)
+	cond, err := FindCondition(obj, c.ConditionType)
 	if err != nil {
-		return false, fmt.Errorf("failed to convert to unstructured: %w", err)
+		return false, err
 	}
-
-	var status objectWithStatus
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &status); err != nil {
-		return false, fmt.Errorf("failed to convert to objectWithStatus: %v", err)
-	}
-
-	for _, cond := range status.Status.Conditions {
-		if cond.Type == c.ConditionType && cond.Reason == c.Reason {
-			return true, nil
-		}
-	}
-	return false, nil
+	return cond != nil && cond.Reason == c.Reason, nil
 }
 
 func (c *ConditionReasonPredicate) String() string {
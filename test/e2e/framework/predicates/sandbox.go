@@ -36,6 +36,8 @@ func asSandbox(obj client.Object) (*sandboxv1beta1.Sandbox, error) {
 }
 
 // SandboxHasStatus verifies that the Sandbox object has the specified status.
+// The pod-derived fields (PodName, PodIPs, NodeName) are not compared; use
+// SandboxHasPodName and SandboxHasPodIP to wait on them.
 func SandboxHasStatus(status sandboxv1beta1.SandboxStatus) ObjectPredicate {
 	return &sandboxHasStatusPredicate{
 		WantStatus: status,
@@ -57,10 +59,46 @@ func (s *sandboxHasStatusPredicate) Matches(obj client.Object) (bool, error) {
 	}
 	opts := []cmp.Option{
 		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
-		cmpopts.IgnoreFields(sandboxv1beta1.SandboxStatus{}, "PodIPs", "NodeName"),
+		cmpopts.IgnoreFields(sandboxv1beta1.SandboxStatus{}, "PodName", "PodIPs", "NodeName", "PodFQDN", "AppliedPodTemplateHash", "ObservedGeneration"),
 	}
 	if diff := cmp.Diff(s.WantStatus, sandbox.Status, opts...); diff != "" {
 		return false, nil
 	}
 	return true, nil
 }
+
+// SandboxHasPodName checks that the Sandbox reports the name of its backing
+// Pod in status.podName.
+var SandboxHasPodName = &sandboxHasPodNamePredicate{}
+
+type sandboxHasPodNamePredicate struct{}
+
+func (s *sandboxHasPodNamePredicate) String() string {
+	return "SandboxHasPodName"
+}
+
+func (s *sandboxHasPodNamePredicate) Matches(obj client.Object) (bool, error) {
+	sandbox, err := asSandbox(obj)
+	if err != nil {
+		return false, err
+	}
+	return sandbox.Status.PodName != "", nil
+}
+
+// SandboxHasPodIP checks that the Sandbox reports at least one IP of its
+// backing Pod in status.podIPs.
+var SandboxHasPodIP = &sandboxHasPodIPPredicate{}
+
+type sandboxHasPodIPPredicate struct{}
+
+func (s *sandboxHasPodIPPredicate) String() string {
+	return "SandboxHasPodIP"
+}
+
+func (s *sandboxHasPodIPPredicate) Matches(obj client.Object) (bool, error) {
+	sandbox, err := asSandbox(obj)
+	if err != nil {
+		return false, err
+	}
+	return len(sandbox.Status.PodIPs) > 0, nil
+}
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
@@ -127,8 +128,15 @@ func (ws *WatchSet) Subscribe(gvr schema.GroupVersionResource, filter WatchFilte
 	if filter.Namespace != "" {
 		watchNamespace = filter.Namespace
 	}
-	rw := ws.getOrCreateWatch(gvr, watchNamespace)
-	return rw.subscribe(filter)
+	for {
+		rw := ws.getOrCreateWatch(gvr, watchNamespace)
+		// The watch may have been stopped by its last subscription closing
+		// after we looked it up; it is gone from ws.watches by then, so retry
+		// to get a fresh one.
+		if sub, ok := rw.subscribe(filter); ok {
+			return sub
+		}
+	}
 }
 
 // Close removes a subscription.
@@ -155,10 +163,15 @@ type WatchFilter struct {
 }
 
 // subscribe adds a new subscription with the given key filter.
-func (rw *ResourceWatch) subscribe(filter WatchFilter) *Subscription {
+// It returns false if the watch has already been stopped.
+func (rw *ResourceWatch) subscribe(filter WatchFilter) (*Subscription, bool) {
 	rw.mu.Lock()
 	defer rw.mu.Unlock()
 
+	if rw.subscriptions == nil {
+		return nil, false
+	}
+
 	sub := &Subscription{
 		id:            rw.nextSubID,
 		Events:        make(chan watch.Event, 100), // Buffered to reduce blocking
@@ -168,7 +181,7 @@ func (rw *ResourceWatch) subscribe(filter WatchFilter) *Subscription {
 	rw.nextSubID++
 	rw.subscriptions[sub.id] = sub
 
-	return sub
+	return sub, true
 }
 
 // unsubscribe removes a subscription.
@@ -264,28 +277,46 @@ func (rw *ResourceWatch) watchLoop(ctx context.Context) {
 			}
 		}
 
-		// Process events
-		for {
-			select {
-			case <-ctx.Done():
-				watcher.Stop()
-				return
+		var done bool
+		resourceVersion, done = rw.processEvents(ctx, watcher, resourceVersion)
+		if done {
+			return
+		}
+	}
+}
 
-			case event, ok := <-watcher.ResultChan():
-				if !ok {
-					// Watch channel closed, restart with last resourceVersion
-					break
-				}
+// processEvents broadcasts events from watcher until its channel closes, it
+// reports an error, or ctx is done. It returns the resourceVersion to resume
+// the watch from and whether ctx is done.
+func (rw *ResourceWatch) processEvents(ctx context.Context, watcher watch.Interface, resourceVersion string) (string, bool) {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, true
+
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// Watch channel closed, restart with last resourceVersion
+				return resourceVersion, false
+			}
 
-				if event.Type == watch.Error {
-					// On error, restart from scratch
+			if event.Type == watch.Error {
+				// Only a 410 Gone means our resourceVersion is too old to
+				// resume from; restart from scratch in that case.
+				if status := k8serrors.FromObject(event.Object); k8serrors.IsResourceExpired(status) || k8serrors.IsGone(status) {
 					resourceVersion = ""
-					break
 				}
+				return resourceVersion, false
+			}
 
-				// Broadcast to matching subscriptions
-				rw.broadcast(event)
+			if typedObject, ok := event.Object.(metav1.Object); ok && typedObject.GetResourceVersion() != "" {
+				resourceVersion = typedObject.GetResourceVersion()
 			}
+
+			// Broadcast to matching subscriptions
+			rw.broadcast(event)
 		}
 	}
 }
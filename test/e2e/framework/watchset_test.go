@@ -15,10 +15,15 @@
 package framework
 
 import (
+	"net/http"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
@@ -27,7 +32,7 @@ import (
 
 func TestWatchSetRecreatesWatchAfterLastSubscriptionCloses(t *testing.T) {
 	gvr := schema.GroupVersionResource{Group: "agents.x-k8s.io", Version: "v1beta1", Resource: "sandboxes"}
-	scheme := runtime.NewScheme()
+	scheme := k8sruntime.NewScheme()
 	dynClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
 		gvr: "SandboxList",
 	})
@@ -58,6 +63,148 @@ func TestWatchSetRecreatesWatchAfterLastSubscriptionCloses(t *testing.T) {
 	}
 }
 
+func TestWatchSetStopsWatchGoroutinesAfterLastSubscriptionCloses(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "agents.x-k8s.io", Version: "v1beta1", Resource: "sandboxes"}
+	dynClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "SandboxList",
+	})
+	dynClient.PrependWatchReactor(gvr.Resource, func(_ ktesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+
+	ws := NewWatchSet(dynClient)
+	defer ws.Close()
+
+	baseline := runtime.NumGoroutine()
+
+	var subs []*Subscription
+	for _, ns := range []string{"ns-a", "ns-b", "ns-c"} {
+		subs = append(subs, ws.Subscribe(gvr, WatchFilter{Namespace: ns, Name: "sandbox"}))
+		subs = append(subs, ws.Subscribe(gvr, WatchFilter{Namespace: ns}))
+	}
+	if got := runtime.NumGoroutine(); got <= baseline {
+		t.Fatalf("expected watch goroutines to be running, got %d goroutines (baseline %d)", got, baseline)
+	}
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines did not return to baseline: got %d, want <= %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	if len(ws.watches) != 0 {
+		t.Fatalf("expected no watches after closing all subscriptions, got %d", len(ws.watches))
+	}
+}
+
+func TestWatchSetSubscribeDuringTeardown(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "agents.x-k8s.io", Version: "v1beta1", Resource: "sandboxes"}
+	dynClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "SandboxList",
+	})
+	dynClient.PrependWatchReactor(gvr.Resource, func(_ ktesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+
+	ws := NewWatchSet(dynClient)
+	defer ws.Close()
+
+	// Subscriptions racing with the teardown of the watch they share must
+	// always land on a live watch.
+	filter := WatchFilter{Namespace: "default", Name: "sandbox-a"}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 200 {
+				sub := ws.Subscribe(gvr, filter)
+				rw := sub.resourceWatch
+				rw.mu.RLock()
+				stopped := rw.subscriptions == nil
+				rw.mu.RUnlock()
+				if stopped {
+					t.Errorf("subscription attached to a stopped watch")
+				}
+				sub.Close()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestWatchSetResumesFromLastResourceVersion(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "agents.x-k8s.io", Version: "v1beta1", Resource: "sandboxes"}
+	dynClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "SandboxList",
+	})
+
+	type startedWatch struct {
+		watcher         *watch.FakeWatcher
+		resourceVersion string
+	}
+	watchesStarted := make(chan startedWatch, 4)
+	dynClient.PrependWatchReactor(gvr.Resource, func(action ktesting.Action) (bool, watch.Interface, error) {
+		fw := watch.NewFake()
+		watchesStarted <- startedWatch{
+			watcher:         fw,
+			resourceVersion: action.(ktesting.WatchAction).GetWatchRestrictions().ResourceVersion,
+		}
+		return true, fw, nil
+	})
+
+	ws := NewWatchSet(dynClient)
+	defer ws.Close()
+
+	sub := ws.Subscribe(gvr, WatchFilter{Namespace: "default"})
+	defer sub.Close()
+
+	nextWatch := func(wantResourceVersion string) *watch.FakeWatcher {
+		t.Helper()
+		select {
+		case w := <-watchesStarted:
+			if w.resourceVersion != wantResourceVersion {
+				t.Fatalf("watch started with resourceVersion %q, want %q", w.resourceVersion, wantResourceVersion)
+			}
+			return w.watcher
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for watch to start")
+			return nil
+		}
+	}
+	sandbox := func(resourceVersion string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("agents.x-k8s.io/v1beta1")
+		u.SetKind("Sandbox")
+		u.SetNamespace("default")
+		u.SetName("sandbox-a")
+		u.SetResourceVersion(resourceVersion)
+		return u
+	}
+
+	// The channel closing mid-stream resumes from the last event seen.
+	fw := nextWatch("")
+	fw.Add(sandbox("5"))
+	fw.Modify(sandbox("7"))
+	fw.Stop()
+
+	// A transient error keeps the resourceVersion.
+	fw = nextWatch("7")
+	fw.Error(&metav1.Status{Status: metav1.StatusFailure, Code: http.StatusInternalServerError, Reason: metav1.StatusReasonInternalError})
+
+	// An expired resourceVersion restarts the watch from scratch.
+	fw = nextWatch("7")
+	fw.Error(&metav1.Status{Status: metav1.StatusFailure, Code: http.StatusGone, Reason: metav1.StatusReasonExpired})
+
+	nextWatch("")
+}
+
 func waitForFakeWatcher(t *testing.T, started <-chan *watch.FakeWatcher) *watch.FakeWatcher {
 	t.Helper()
 
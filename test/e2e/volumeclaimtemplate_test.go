@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	sandboxv1beta1 "sigs.k8s.io/agent-sandbox/api/v1beta1"
+	"sigs.k8s.io/agent-sandbox/internal/naming"
 	"sigs.k8s.io/agent-sandbox/test/e2e/framework"
 	"sigs.k8s.io/agent-sandbox/test/e2e/framework/predicates"
 )
@@ -73,7 +74,7 @@ func TestSandboxVolumeClaimTemplates(t *testing.T) {
 	}
 	require.NoError(t, tc.CreateWithCleanup(t.Context(), sandboxObj))
 
-	nameHash := NameHash(sandboxObj.Name)
+	nameHash := naming.NameHash(sandboxObj.Name)
 
 	// Wait for the sandbox to become ready
 	p := []predicates.ObjectPredicate{
@@ -91,8 +92,11 @@ func TestSandboxVolumeClaimTemplates(t *testing.T) {
 				},
 			},
 		}),
+		predicates.SandboxHasPodName,
+		predicates.SandboxHasPodIP,
 	}
 	require.NoError(t, tc.WaitForObject(t.Context(), sandboxObj, p...))
+	require.NoError(t, tc.Get(t.Context(), types.NamespacedName{Name: sandboxObj.Name, Namespace: ns.Name}, sandboxObj))
 
 	// Verify the PVC was created with the expected name (template name + "-" + sandbox name)
 	pvc := &corev1.PersistentVolumeClaim{}
@@ -112,7 +116,7 @@ func TestSandboxVolumeClaimTemplates(t *testing.T) {
 
 	// Verify the pod has a PVC volume mounted
 	pod := &corev1.Pod{}
-	pod.Name = "vct-sandbox"
+	pod.Name = sandboxObj.Status.PodName
 	pod.Namespace = ns.Name
 	tc.MustExist(pod)
 